@@ -0,0 +1,187 @@
+// nsm-load drives many concurrent connections through NSMD using the SDK client, randomly closing them
+// and, for in-process NSMD, injecting dataplane and endpoint faults NSMD has to heal. It reports connect,
+// close and heal latency percentiles, errors and connections leaked in NSMD model and dataplane.
+//
+// By default NSMD is started in the process with a fake dataplane, pass -in-process=false to load a real
+// NSMD, it is reached through the SDK environment variables (NSM_SERVER_SOCKET, NSM_CLIENT_SOCKET, WORKSPACE).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	inProcess := flag.Bool("in-process", true, "start NSMD with a fake dataplane in the process instead of using NSMD from the SDK environment")
+	networkService := flag.String("network-service", "load", "network service to connect to")
+	mechanism := flag.String("mechanism", "kernel", "local mechanism of the connections")
+	endpoints := flag.Int("endpoints", 2, "number of in-process endpoints providing the network service")
+	clients := flag.Int("clients", 50, "number of concurrent SDK clients")
+	connections := flag.Int("connections", 1000, "total number of connections kept open by the clients")
+	closeRatio := flag.Float64("close-ratio", 0.3, "probability to close a random connection instead of opening a new one")
+	interval := flag.Duration("interval", 0, "pause between operations of a client")
+	chaosInterval := flag.Duration("chaos-interval", 5*time.Second, "interval of in-process fault injection, 0 to disable it")
+	duration := flag.Duration("duration", time.Minute, "load duration")
+	drainTimeout := flag.Duration("drain-timeout", 30*time.Second, "time to wait for NSMD to release closed connections")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	tools.InitLogging()
+	if *clients <= 0 || *connections < *clients {
+		logrus.Fatalf("nsm-load: at least one connection per client is required")
+	}
+
+	var t target
+	var err error
+	if *inProcess {
+		t, err = newInProcessTarget(*networkService, *endpoints)
+	} else {
+		t, err = newRemoteTarget()
+	}
+	if err != nil {
+		logrus.Fatalf("nsm-load: failed to start: %v", err)
+	}
+	defer t.close()
+
+	logrus.Infof("nsm-load: seed %d", *seed)
+	rnd := rand.New(rand.NewSource(*seed))
+	s := newStats()
+	workers, err := newWorkers(t, s, rnd, *clients, *connections, *networkService, *mechanism, *closeRatio)
+	if err != nil {
+		logrus.Fatalf("nsm-load: failed to create clients: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			logrus.Info("nsm-load: interrupted, closing connections")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	chaosDone := make(chan struct{})
+	go func() {
+		defer close(chaosDone)
+		runChaos(ctx, t, s, rand.New(rand.NewSource(rnd.Int63())), *chaosInterval)
+	}()
+
+	wg := sync.WaitGroup{}
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			w.run(ctx, *interval)
+		}(w)
+	}
+	wg.Wait()
+	<-chaosDone
+	for _, w := range workers {
+		_ = w.nsc.Destroy()
+	}
+
+	connectionLeaks, crossConnectLeaks, err := waitDrained(t, *drainTimeout)
+	if err != nil {
+		logrus.Errorf("nsm-load: failed to check leaks: %v", err)
+	}
+
+	s.report(os.Stdout)
+	fmt.Println()
+	reportLeaks(os.Stdout, "leaked connections", connectionLeaks)
+	reportLeaks(os.Stdout, "leaked cross connects", crossConnectLeaks)
+	if connectionLeaks > 0 || crossConnectLeaks > 0 || err != nil {
+		os.Exit(1)
+	}
+}
+
+func newWorkers(t target, s *stats, rnd *rand.Rand, clients, connections int, networkService, mechanism string, closeRatio float64) ([]*worker, error) {
+	var workers []*worker
+	for i := 0; i < clients; i++ {
+		nsc, err := t.newClient()
+		if err != nil {
+			for _, w := range workers {
+				_ = w.nsc.Destroy()
+			}
+			return nil, err
+		}
+		maxConnections := connections / clients
+		if i < connections%clients {
+			maxConnections++
+		}
+		workers = append(workers, &worker{
+			id:             i,
+			nsc:            nsc,
+			rnd:            rand.New(rand.NewSource(rnd.Int63())),
+			stats:          s,
+			brokenSince:    map[string]time.Time{},
+			networkService: networkService,
+			mechanism:      mechanism,
+			maxConnections: maxConnections,
+			closeRatio:     closeRatio,
+		})
+	}
+	return workers, nil
+}
+
+func runChaos(ctx context.Context, t target, s *stats, rnd *rand.Rand, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			name, err := t.chaos(rnd)
+			if name == "" && err == nil {
+				// Target does not support faults
+				return
+			}
+			s.observe("chaos", time.Since(start), err)
+			if err != nil {
+				logrus.Errorf("nsm-load: failed to inject %s: %v", name, err)
+				continue
+			}
+			logrus.Infof("nsm-load: injected %s", name)
+			s.event("injected " + name)
+		}
+	}
+}
+
+// waitDrained waits for NSMD and dataplane to release all the connections and returns the leaked ones.
+func waitDrained(t target, timeout time.Duration) (int, int, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		connections, crossConnects, err := t.leaks()
+		if err != nil || (connections <= 0 && crossConnects <= 0) || time.Now().After(deadline) {
+			return connections, crossConnects, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func reportLeaks(w io.Writer, name string, count int) {
+	if count < 0 {
+		fmt.Fprintf(w, "%s: unknown\n", name)
+		return
+	}
+	fmt.Fprintf(w, "%s: %d\n", name, count)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// stats collects latencies and errors of load operations.
+type stats struct {
+	sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	events    map[string]int
+}
+
+func newStats() *stats {
+	return &stats{
+		latencies: map[string][]time.Duration{},
+		errors:    map[string]int{},
+		events:    map[string]int{},
+	}
+}
+
+// observe records the operation latency if err is nil and the operation error otherwise.
+func (s *stats) observe(op string, latency time.Duration, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err != nil {
+		s.errors[op]++
+		return
+	}
+	s.latencies[op] = append(s.latencies[op], latency)
+}
+
+// event counts an operation without latency, e.g. injected fault or connection state change.
+func (s *stats) event(name string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.events[name]++
+}
+
+func (s *stats) errorCount() int {
+	s.Lock()
+	defer s.Unlock()
+
+	count := 0
+	for _, c := range s.errors {
+		count += c
+	}
+	return count
+}
+
+func (s *stats) report(w io.Writer) {
+	s.Lock()
+	defer s.Unlock()
+
+	fmt.Fprintf(w, "%-12s %8s %8s %10s %10s %10s %10s\n", "operation", "ok", "errors", "p50", "p90", "p99", "max")
+	for _, op := range sortedKeys(s.latencies, s.errors) {
+		latencies := s.latencies[op]
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		fmt.Fprintf(w, "%-12s %8d %8d %10v %10v %10v %10v\n", op, len(latencies), s.errors[op],
+			percentile(latencies, 0.5), percentile(latencies, 0.9), percentile(latencies, 0.99), percentile(latencies, 1))
+	}
+	if len(s.events) > 0 {
+		fmt.Fprintln(w)
+		for _, name := range sortedKeys(nil, s.events) {
+			fmt.Fprintf(w, "%-24s %8d\n", name, s.events[name])
+		}
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(p*float64(len(sorted))+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index].Round(time.Microsecond)
+}
+
+func sortedKeys(latencies map[string][]time.Duration, counts map[string]int) []string {
+	keys := map[string]bool{}
+	for k := range latencies {
+		keys[k] = true
+	}
+	for k := range counts {
+		keys[k] = true
+	}
+	var rv []string
+	for k := range keys {
+		rv = append(rv, k)
+	}
+	sort.Strings(rv)
+	return rv
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	"github.com/networkservicemesh/networkservicemesh/test/harness"
+)
+
+// target is NSMD under load.
+type target interface {
+	// newClient creates SDK client connected to NSMD
+	newClient() (*client.NsmClient, error)
+	// chaos injects a random fault NSMD should heal from and returns its name, empty if target does not support faults
+	chaos(rnd *rand.Rand) (string, error)
+	// leaks returns NSMD connections and dataplane cross connects left after the load, -1 if unknown
+	leaks() (connections int, crossConnects int, err error)
+	close()
+}
+
+// inProcessTarget is NSMD running in the process with a fake dataplane and SDK endpoints.
+type inProcessTarget struct {
+	harness        *harness.Harness
+	networkService string
+}
+
+func newInProcessTarget(networkService string, endpoints int) (*inProcessTarget, error) {
+	h, err := harness.New(1)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < endpoints; i++ {
+		if _, err := h.Node(0).NewEndpoint(networkService, nil); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	return &inProcessTarget{
+		harness:        h,
+		networkService: networkService,
+	}, nil
+}
+
+func (t *inProcessTarget) newClient() (*client.NsmClient, error) {
+	return t.harness.Node(0).NewClient()
+}
+
+// chaos either restarts the dataplane or kills an endpoint and starts a new one instead of it.
+func (t *inProcessTarget) chaos(rnd *rand.Rand) (string, error) {
+	node := t.harness.Node(0)
+	if rnd.Intn(2) == 0 {
+		if err := node.KillDataplane(); err != nil {
+			return "", err
+		}
+		return "dataplane restart", node.StartDataplane()
+	}
+
+	endpoints := node.Endpoints()
+	if len(endpoints) > 0 {
+		if err := endpoints[rnd.Intn(len(endpoints))].Kill(); err != nil {
+			return "", err
+		}
+	}
+	_, err := node.NewEndpoint(t.networkService, nil)
+	return "endpoint kill", err
+}
+
+func (t *inProcessTarget) leaks() (int, int, error) {
+	node := t.harness.Node(0)
+	return len(node.Connections()), len(node.Dataplane.CrossConnects()), nil
+}
+
+func (t *inProcessTarget) close() {
+	t.harness.Close()
+}
+
+// remoteTarget is NSMD serving the workspace passed in the SDK environment variables.
+type remoteTarget struct {
+	monitor *client.NsmClient
+	initial int
+}
+
+func newRemoteTarget() (*remoteTarget, error) {
+	monitor, err := client.NewNSMClient(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	t := &remoteTarget{
+		monitor: monitor,
+	}
+	// Connections existing before the load are not leaks.
+	if t.initial, err = t.connections(); err != nil {
+		_ = monitor.Destroy()
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *remoteTarget) newClient() (*client.NsmClient, error) {
+	return client.NewNSMClient(context.Background(), nil)
+}
+
+func (t *remoteTarget) chaos(rnd *rand.Rand) (string, error) {
+	return "", nil
+}
+
+// leaks returns workspace connections left in NSMD, dataplane cross connects are not accessible from the workspace.
+func (t *remoteTarget) leaks() (int, int, error) {
+	connections, err := t.connections()
+	if err != nil {
+		return -1, -1, err
+	}
+	return connections - t.initial, -1, nil
+}
+
+// connections reads the initial state of the workspace connection monitor.
+func (t *remoteTarget) connections() (int, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := connection.NewMonitorConnectionClient(t.monitor.GrpcClient).MonitorConnections(ctx, &connection.MonitorRequest{})
+	if err != nil {
+		return -1, err
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		return -1, err
+	}
+	if event.GetType() != connection.ConnectionEventType_INITIAL_STATE_TRANSFER {
+		return -1, fmt.Errorf("unexpected first monitor event %v", event.GetType())
+	}
+	return len(event.GetConnections()), nil
+}
+
+func (t *remoteTarget) close() {
+	_ = t.monitor.Destroy()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	"github.com/sirupsen/logrus"
+)
+
+// worker keeps up to maxConnections connections of a single SDK client, randomly opening and closing them.
+type worker struct {
+	sync.Mutex
+	id             int
+	nsc            *client.NsmClient
+	rnd            *rand.Rand
+	stats          *stats
+	connections    []*connection.Connection
+	counter        int
+	brokenSince    map[string]time.Time
+	networkService string
+	mechanism      string
+	maxConnections int
+	closeRatio     float64
+}
+
+func (w *worker) run(ctx context.Context, interval time.Duration) {
+	monitorCtx, cancelMonitor := context.WithCancel(context.Background())
+	defer cancelMonitor()
+	go func() {
+		_ = w.nsc.Monitor(monitorCtx, w.onEvent)
+	}()
+
+	for ctx.Err() == nil {
+		if len(w.connections) < w.maxConnections && (len(w.connections) == 0 || w.rnd.Float64() >= w.closeRatio) {
+			w.connect()
+		} else {
+			w.close(w.rnd.Intn(len(w.connections)))
+		}
+		if interval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+		}
+	}
+	for len(w.connections) > 0 {
+		w.close(len(w.connections) - 1)
+	}
+}
+
+func (w *worker) connect() {
+	w.counter++
+	// Kernel interface names are limited to 15 characters.
+	name := fmt.Sprintf("nsm%d", w.counter%10000)
+	start := time.Now()
+	conn, err := w.nsc.ConnectToService(w.networkService, nil, name, w.mechanism, "nsm-load")
+	w.stats.observe("connect", time.Since(start), err)
+	if err != nil {
+		logrus.Errorf("nsm-load: worker %d failed to connect: %v", w.id, err)
+		return
+	}
+	w.connections = append(w.connections, conn)
+}
+
+func (w *worker) close(index int) {
+	conn := w.connections[index]
+	w.connections = append(w.connections[:index], w.connections[index+1:]...)
+	start := time.Now()
+	err := w.nsc.Close(conn)
+	w.stats.observe("close", time.Since(start), err)
+	if err != nil {
+		logrus.Errorf("nsm-load: worker %d failed to close %s: %v", w.id, conn.GetId(), err)
+	}
+}
+
+// onEvent measures how long connections stay DOWN until NSMD heals them and how long lost connections
+// take to be requested again.
+func (w *worker) onEvent(event *client.ConnectionEvent) {
+	w.Lock()
+	defer w.Unlock()
+
+	id := event.Connection.GetId()
+	w.stats.event("connection " + event.State.String())
+	switch event.State {
+	case client.ConnectionDown, client.ConnectionLost:
+		if _, ok := w.brokenSince[id]; !ok {
+			w.brokenSince[id] = time.Now()
+		}
+	case client.ConnectionUp, client.ConnectionRestored:
+		if since, ok := w.brokenSince[id]; ok {
+			delete(w.brokenSince, id)
+			w.stats.observe("heal", time.Since(since), nil)
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 )
 
 func main() {
+	tools.InitLogging()
 	start := time.Now()
 
 	// Capture signals to cleanup before exiting
@@ -29,7 +30,8 @@ func main() {
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
 
-	go nsmd.BeginHealthCheck()
+	metrics := nsmd.NewAdmissionMetrics()
+	go nsmd.BeginHealthCheck(metrics)
 
 	apiRegistry := nsmd.NewApiRegistry()
 	serviceRegistry := nsmd.NewServiceRegistry()
@@ -46,7 +48,8 @@ func main() {
 
 	var server nsmd.NSMServer
 	// Start NSMD server first, laod local NSE/client registry and only then start dataplane/wait for it and recover active connections.
-	if server, err = nsmd.StartNSMServer(model, manager, serviceRegistry, apiRegistry); err != nil {
+	admission := nsmd.NewAdmissionController(nsmd.NewAdmissionPropertiesFromEnv(), metrics)
+	if server, err = nsmd.StartNSMServer(model, manager, serviceRegistry, apiRegistry, admission); err != nil {
 		logrus.Fatalf("Error starting nsmd service: %+v", err)
 		nsmd.SetNSMServerFailed()
 	}
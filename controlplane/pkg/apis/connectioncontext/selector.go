@@ -0,0 +1,66 @@
+package connectioncontext
+
+// ConnectionSelector is a set of connection selectors shared by the monitor requests of local, remote
+// and cross connect monitors. Connection matches if it matches all of the selectors, empty selectors match any value.
+type ConnectionSelector struct {
+	NetworkService             string
+	ConnectionIds              []string
+	NetworkServiceEndpointName string
+	Labels                     map[string]string
+	States                     []int32
+}
+
+// SelectedConnection are connection fields checked by ConnectionSelector.
+type SelectedConnection struct {
+	NetworkService             string
+	Id                         string
+	NetworkServiceEndpointName string
+	Labels                     map[string]string
+	State                      int32
+}
+
+// IsEmpty returns true if there are no selectors, so any connection matches.
+func (s *ConnectionSelector) IsEmpty() bool {
+	return s.NetworkService == "" && len(s.ConnectionIds) == 0 && s.NetworkServiceEndpointName == "" &&
+		len(s.Labels) == 0 && len(s.States) == 0
+}
+
+// Matches returns true if the connection matches all of the selectors.
+func (s *ConnectionSelector) Matches(c *SelectedConnection) bool {
+	if s.NetworkService != "" && s.NetworkService != c.NetworkService {
+		return false
+	}
+	if len(s.ConnectionIds) > 0 && !containsString(s.ConnectionIds, c.Id) {
+		return false
+	}
+	if s.NetworkServiceEndpointName != "" && s.NetworkServiceEndpointName != c.NetworkServiceEndpointName {
+		return false
+	}
+	for k, v := range s.Labels {
+		if value, ok := c.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	if len(s.States) > 0 && !containsState(s.States, c.State) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsState(states []int32, state int32) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -6,7 +6,6 @@ package crossconnect
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import empty "github.com/golang/protobuf/ptypes/empty"
 import connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 import connection1 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 
@@ -49,22 +48,26 @@ func (x CrossConnectEventType) String() string {
 	return proto.EnumName(CrossConnectEventType_name, int32(x))
 }
 func (CrossConnectEventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_crossconnect_1e34ad52957571e6, []int{0}
+	return fileDescriptor_crossconnect_2474ab1a6771b533, []int{0}
 }
 
 type CrossConnectEvent struct {
-	Type                 CrossConnectEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=crossconnect.CrossConnectEventType" json:"type,omitempty"`
-	CrossConnects        map[string]*CrossConnect `protobuf:"bytes,2,rep,name=cross_connects,json=crossConnects,proto3" json:"cross_connects,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Type          CrossConnectEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=crossconnect.CrossConnectEventType" json:"type,omitempty"`
+	CrossConnects map[string]*CrossConnect `protobuf:"bytes,2,rep,name=cross_connects,json=crossConnects,proto3" json:"cross_connects,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// sequence number of the event, INITIAL_STATE_TRANSFER has the sequence number of the last event included into it
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// id of the monitor server instance, sequence numbers of different instances are not comparable
+	ServerId             string   `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CrossConnectEvent) Reset()         { *m = CrossConnectEvent{} }
 func (m *CrossConnectEvent) String() string { return proto.CompactTextString(m) }
 func (*CrossConnectEvent) ProtoMessage()    {}
 func (*CrossConnectEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_crossconnect_1e34ad52957571e6, []int{0}
+	return fileDescriptor_crossconnect_2474ab1a6771b533, []int{0}
 }
 func (m *CrossConnectEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossConnectEvent.Unmarshal(m, b)
@@ -98,6 +101,112 @@ func (m *CrossConnectEvent) GetCrossConnects() map[string]*CrossConnect {
 	return nil
 }
 
+func (m *CrossConnectEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CrossConnectEvent) GetServerId() string {
+	if m != nil {
+		return m.ServerId
+	}
+	return ""
+}
+
+type MonitorRequest struct {
+	// sequence number of the last event received before reconnect, 0 requests the full state transfer
+	ResumeFromSequence uint64 `protobuf:"varint,1,opt,name=resume_from_sequence,json=resumeFromSequence,proto3" json:"resume_from_sequence,omitempty"`
+	// server id of the last event received before reconnect, the full state is transferred if it does not match
+	ResumeFromServerId string `protobuf:"bytes,7,opt,name=resume_from_server_id,json=resumeFromServerId,proto3" json:"resume_from_server_id,omitempty"`
+	// Selectors, the cross connect matches if its source or destination connection matches all of them,
+	// the empty selector matches any cross connect
+	NetworkService string `protobuf:"bytes,2,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	// ids of the cross connects or of their connections
+	ConnectionIds              []string `protobuf:"bytes,3,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	NetworkServiceEndpointName string   `protobuf:"bytes,4,opt,name=network_service_endpoint_name,json=networkServiceEndpointName,proto3" json:"network_service_endpoint_name,omitempty"`
+	// connection labels should contain all of the selector labels
+	Labels               map[string]string  `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	States               []connection.State `protobuf:"varint,6,rep,packed,name=states,proto3,enum=local.connection.State" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *MonitorRequest) Reset()         { *m = MonitorRequest{} }
+func (m *MonitorRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorRequest) ProtoMessage()    {}
+func (*MonitorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crossconnect_2474ab1a6771b533, []int{1}
+}
+func (m *MonitorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorRequest.Unmarshal(m, b)
+}
+func (m *MonitorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorRequest.Marshal(b, m, deterministic)
+}
+func (dst *MonitorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorRequest.Merge(dst, src)
+}
+func (m *MonitorRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorRequest.Size(m)
+}
+func (m *MonitorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorRequest proto.InternalMessageInfo
+
+func (m *MonitorRequest) GetResumeFromSequence() uint64 {
+	if m != nil {
+		return m.ResumeFromSequence
+	}
+	return 0
+}
+
+func (m *MonitorRequest) GetResumeFromServerId() string {
+	if m != nil {
+		return m.ResumeFromServerId
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetConnectionIds() []string {
+	if m != nil {
+		return m.ConnectionIds
+	}
+	return nil
+}
+
+func (m *MonitorRequest) GetNetworkServiceEndpointName() string {
+	if m != nil {
+		return m.NetworkServiceEndpointName
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MonitorRequest) GetStates() []connection.State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
 type CrossConnect struct {
 	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Payload string `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -108,17 +217,19 @@ type CrossConnect struct {
 	// Types that are valid to be assigned to Destination:
 	//	*CrossConnect_LocalDestination
 	//	*CrossConnect_RemoteDestination
-	Destination          isCrossConnect_Destination `protobuf_oneof:"destination"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Destination isCrossConnect_Destination `protobuf_oneof:"destination"`
+	// dataplane specific statistics of the cross connect
+	Metrics              map[string]string `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CrossConnect) Reset()         { *m = CrossConnect{} }
 func (m *CrossConnect) String() string { return proto.CompactTextString(m) }
 func (*CrossConnect) ProtoMessage()    {}
 func (*CrossConnect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_crossconnect_1e34ad52957571e6, []int{1}
+	return fileDescriptor_crossconnect_2474ab1a6771b533, []int{2}
 }
 func (m *CrossConnect) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossConnect.Unmarshal(m, b)
@@ -226,6 +337,13 @@ func (m *CrossConnect) GetRemoteDestination() *connection1.Connection {
 	return nil
 }
 
+func (m *CrossConnect) GetMetrics() map[string]string {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CrossConnect) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CrossConnect_OneofMarshaler, _CrossConnect_OneofUnmarshaler, _CrossConnect_OneofSizer, []interface{}{
@@ -353,7 +471,10 @@ func _CrossConnect_OneofSizer(msg proto.Message) (n int) {
 func init() {
 	proto.RegisterType((*CrossConnectEvent)(nil), "crossconnect.CrossConnectEvent")
 	proto.RegisterMapType((map[string]*CrossConnect)(nil), "crossconnect.CrossConnectEvent.CrossConnectsEntry")
+	proto.RegisterType((*MonitorRequest)(nil), "crossconnect.MonitorRequest")
+	proto.RegisterMapType((map[string]string)(nil), "crossconnect.MonitorRequest.LabelsEntry")
 	proto.RegisterType((*CrossConnect)(nil), "crossconnect.CrossConnect")
+	proto.RegisterMapType((map[string]string)(nil), "crossconnect.CrossConnect.MetricsEntry")
 	proto.RegisterEnum("crossconnect.CrossConnectEventType", CrossConnectEventType_name, CrossConnectEventType_value)
 }
 
@@ -369,7 +490,7 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MonitorCrossConnectClient interface {
-	MonitorCrossConnects(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (MonitorCrossConnect_MonitorCrossConnectsClient, error)
+	MonitorCrossConnects(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (MonitorCrossConnect_MonitorCrossConnectsClient, error)
 }
 
 type monitorCrossConnectClient struct {
@@ -380,7 +501,7 @@ func NewMonitorCrossConnectClient(cc *grpc.ClientConn) MonitorCrossConnectClient
 	return &monitorCrossConnectClient{cc}
 }
 
-func (c *monitorCrossConnectClient) MonitorCrossConnects(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (MonitorCrossConnect_MonitorCrossConnectsClient, error) {
+func (c *monitorCrossConnectClient) MonitorCrossConnects(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (MonitorCrossConnect_MonitorCrossConnectsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_MonitorCrossConnect_serviceDesc.Streams[0], "/crossconnect.MonitorCrossConnect/MonitorCrossConnects", opts...)
 	if err != nil {
 		return nil, err
@@ -414,7 +535,7 @@ func (x *monitorCrossConnectMonitorCrossConnectsClient) Recv() (*CrossConnectEve
 
 // MonitorCrossConnectServer is the server API for MonitorCrossConnect service.
 type MonitorCrossConnectServer interface {
-	MonitorCrossConnects(*empty.Empty, MonitorCrossConnect_MonitorCrossConnectsServer) error
+	MonitorCrossConnects(*MonitorRequest, MonitorCrossConnect_MonitorCrossConnectsServer) error
 }
 
 func RegisterMonitorCrossConnectServer(s *grpc.Server, srv MonitorCrossConnectServer) {
@@ -422,7 +543,7 @@ func RegisterMonitorCrossConnectServer(s *grpc.Server, srv MonitorCrossConnectSe
 }
 
 func _MonitorCrossConnect_MonitorCrossConnects_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(empty.Empty)
+	m := new(MonitorRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -456,40 +577,53 @@ var _MonitorCrossConnect_serviceDesc = grpc.ServiceDesc{
 	Metadata: "crossconnect.proto",
 }
 
-func init() { proto.RegisterFile("crossconnect.proto", fileDescriptor_crossconnect_1e34ad52957571e6) }
-
-var fileDescriptor_crossconnect_1e34ad52957571e6 = []byte{
-	// 508 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x6b, 0xb7, 0x4d, 0xe9, 0xe4, 0x8f, 0x9c, 0x05, 0x2a, 0xcb, 0x02, 0x11, 0x95, 0x4b,
-	0xc4, 0xc1, 0xae, 0xdc, 0x03, 0x88, 0x9b, 0x9b, 0x18, 0x11, 0xb5, 0x44, 0xb0, 0x31, 0x07, 0x24,
-	0xa4, 0xc8, 0x71, 0x16, 0xc7, 0x8a, 0xb3, 0x6b, 0x79, 0x37, 0x41, 0x7e, 0x00, 0x9e, 0x99, 0x2b,
-	0xf2, 0xae, 0x03, 0x8e, 0x9a, 0xd2, 0x1e, 0x7a, 0x89, 0x26, 0xdf, 0xcc, 0xfc, 0xbe, 0xf1, 0xee,
-	0x2c, 0xa0, 0x28, 0x67, 0x9c, 0x47, 0x8c, 0x52, 0x12, 0x09, 0x3b, 0xcb, 0x99, 0x60, 0xa8, 0x55,
-	0xd7, 0xac, 0x45, 0x9c, 0x88, 0xc5, 0x7a, 0x66, 0x47, 0x6c, 0xe5, 0x50, 0x22, 0x7e, 0xb2, 0x7c,
-	0xc9, 0x49, 0xbe, 0x49, 0x22, 0xb2, 0x22, 0x7c, 0xb1, 0x4f, 0x8a, 0x18, 0x15, 0x39, 0x4b, 0xb3,
-	0x34, 0xa4, 0xc4, 0xc9, 0x96, 0xb1, 0x13, 0x66, 0x09, 0x77, 0x52, 0x16, 0x85, 0xa9, 0x53, 0x51,
-	0x13, 0x46, 0x6b, 0xa1, 0xf2, 0xb5, 0x92, 0x47, 0x72, 0xca, 0xc9, 0x8a, 0x09, 0xf2, 0x5f, 0xab,
-	0xcb, 0x9a, 0x55, 0xcc, 0xd2, 0x90, 0xc6, 0x8e, 0x4c, 0xcc, 0xd6, 0x3f, 0x9c, 0x4c, 0x14, 0x19,
-	0xe1, 0x0e, 0x59, 0x65, 0xa2, 0x50, 0xbf, 0xaa, 0xe9, 0xfc, 0x97, 0x0e, 0xdd, 0x41, 0x79, 0x34,
-	0x03, 0x85, 0xf3, 0x37, 0x84, 0x0a, 0xf4, 0x16, 0x8e, 0xca, 0x06, 0x53, 0xeb, 0x69, 0xfd, 0x8e,
-	0xfb, 0xda, 0xde, 0x39, 0xd0, 0x5b, 0xe5, 0x41, 0x91, 0x11, 0x2c, 0x1b, 0xd0, 0x37, 0xe8, 0xc8,
-	0xda, 0x69, 0x55, 0xcc, 0x4d, 0xbd, 0x77, 0xd8, 0x6f, 0xba, 0xee, 0x3d, 0x88, 0x1d, 0x85, 0xfb,
-	0x54, 0xe4, 0x05, 0x6e, 0x47, 0x75, 0xcd, 0xfa, 0x0e, 0xe8, 0x76, 0x11, 0x32, 0xe0, 0x70, 0x49,
-	0x0a, 0x39, 0xe8, 0x29, 0x2e, 0x43, 0x74, 0x01, 0xc7, 0x9b, 0x30, 0x5d, 0x13, 0x53, 0xef, 0x69,
-	0xfd, 0xa6, 0x6b, 0xdd, 0xed, 0x8c, 0x55, 0xe1, 0x7b, 0xfd, 0x9d, 0x76, 0xfe, 0x5b, 0x87, 0x56,
-	0x3d, 0x87, 0x3a, 0xa0, 0x27, 0xf3, 0x8a, 0xab, 0x27, 0x73, 0x64, 0xc2, 0x49, 0x16, 0x16, 0x29,
-	0x0b, 0xe7, 0x12, 0x7c, 0x8a, 0xb7, 0x7f, 0x91, 0x07, 0x2d, 0xb9, 0x07, 0x53, 0xce, 0xd6, 0x79,
-	0x44, 0xcc, 0x23, 0xe9, 0xfb, 0xc2, 0x96, 0xa2, 0x5d, 0xbb, 0xa6, 0xc1, 0xdf, 0xf0, 0xe3, 0x01,
-	0x6e, 0xca, 0xf4, 0x44, 0xb6, 0xa0, 0x21, 0xb4, 0xd5, 0x05, 0x6f, 0x19, 0xc7, 0x92, 0xf1, 0xd2,
-	0x56, 0xea, 0x9d, 0x90, 0x96, 0xca, 0x57, 0x94, 0x6b, 0xe8, 0xaa, 0x41, 0xe6, 0x84, 0x8b, 0x84,
-	0x86, 0x65, 0x91, 0xd9, 0x78, 0xc0, 0x34, 0x1a, 0x36, 0x64, 0x7a, 0xf8, 0xaf, 0x0f, 0x8d, 0x01,
-	0x55, 0x23, 0xd5, 0x69, 0x27, 0x0f, 0x99, 0x4b, 0xc3, 0x5d, 0x95, 0xaf, 0xf1, 0xae, 0x9e, 0x40,
-	0x43, 0x7d, 0xdb, 0x55, 0x1b, 0x9a, 0x35, 0xe4, 0x9b, 0x6b, 0x78, 0xbe, 0x77, 0xa3, 0x90, 0x05,
-	0x67, 0xa3, 0xf1, 0x28, 0x18, 0x79, 0x37, 0xd3, 0x49, 0xe0, 0x05, 0xfe, 0x34, 0xc0, 0xde, 0x78,
-	0xf2, 0xc1, 0xc7, 0xc6, 0x01, 0x02, 0x68, 0x7c, 0xfd, 0x3c, 0xf4, 0x02, 0xdf, 0xd0, 0xca, 0x78,
-	0xe8, 0xdf, 0xf8, 0x81, 0x6f, 0xe8, 0xee, 0x02, 0x9e, 0x7e, 0x62, 0x34, 0x11, 0x2c, 0xdf, 0xb9,
-	0xcc, 0x2f, 0xf0, 0x6c, 0x8f, 0xcc, 0xd1, 0x99, 0x1d, 0x33, 0x16, 0xa7, 0xc4, 0xde, 0x3e, 0x14,
-	0xdb, 0x2f, 0xdf, 0x86, 0xf5, 0xea, 0x9e, 0x75, 0xbd, 0xd0, 0x66, 0x0d, 0xd9, 0x72, 0xf9, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0x41, 0xde, 0xa1, 0x76, 0x6d, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("crossconnect.proto", fileDescriptor_crossconnect_2474ab1a6771b533) }
+
+var fileDescriptor_crossconnect_2474ab1a6771b533 = []byte{
+	// 712 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4b, 0x4f, 0xdb, 0x4a,
+	0x14, 0xc6, 0x31, 0xe4, 0x71, 0xf2, 0xb8, 0x61, 0x2e, 0xdc, 0x6b, 0xf9, 0x82, 0x6e, 0x44, 0x55,
+	0x61, 0x75, 0xe1, 0xd0, 0x74, 0xd1, 0x96, 0x55, 0x03, 0x31, 0x6a, 0x04, 0x44, 0xd5, 0x24, 0x55,
+	0x55, 0xa9, 0x92, 0x65, 0xec, 0x29, 0x58, 0xd8, 0x33, 0xae, 0x67, 0x42, 0x95, 0x3f, 0xd6, 0x65,
+	0x7f, 0x56, 0xd7, 0x55, 0x66, 0x1c, 0xb0, 0x4b, 0x78, 0x54, 0xea, 0x6e, 0xfc, 0x9d, 0xf3, 0x7d,
+	0xfe, 0xce, 0x63, 0x06, 0x90, 0x9f, 0x32, 0xce, 0x7d, 0x46, 0x29, 0xf1, 0x85, 0x9d, 0xa4, 0x4c,
+	0x30, 0xd4, 0xc8, 0x63, 0xe6, 0xc5, 0x79, 0x28, 0x2e, 0xa6, 0x67, 0xb6, 0xcf, 0xe2, 0x2e, 0x25,
+	0xe2, 0x2b, 0x4b, 0x2f, 0x39, 0x49, 0xaf, 0x42, 0x9f, 0xc4, 0x84, 0x5f, 0x2c, 0x83, 0x7c, 0x46,
+	0x45, 0xca, 0xa2, 0x24, 0xf2, 0x28, 0xe9, 0x26, 0x97, 0xe7, 0x5d, 0x2f, 0x09, 0x79, 0x37, 0x62,
+	0xbe, 0x17, 0x75, 0x33, 0xd5, 0x90, 0xd1, 0xdc, 0x51, 0xfd, 0xd7, 0x0c, 0xff, 0xd0, 0x9f, 0x52,
+	0x12, 0x33, 0x41, 0xee, 0xfb, 0xd5, 0xce, 0xf7, 0x12, 0xac, 0x1f, 0xce, 0xab, 0x3c, 0x54, 0x11,
+	0xe7, 0x8a, 0x50, 0x81, 0x5e, 0xc2, 0xaa, 0x98, 0x25, 0xc4, 0xd0, 0x3a, 0x9a, 0xd5, 0xea, 0x3d,
+	0xb1, 0x0b, 0xbd, 0xb9, 0x95, 0x3e, 0x99, 0x25, 0x04, 0x4b, 0x02, 0xfa, 0x08, 0x2d, 0x99, 0xeb,
+	0x66, 0xc9, 0xdc, 0x28, 0x75, 0x74, 0xab, 0xde, 0xeb, 0x3d, 0x20, 0x51, 0x40, 0xb8, 0x43, 0x45,
+	0x3a, 0xc3, 0x4d, 0x3f, 0x8f, 0x21, 0x13, 0xaa, 0x9c, 0x7c, 0x99, 0x12, 0xea, 0x13, 0x43, 0xef,
+	0x68, 0xd6, 0x2a, 0xbe, 0xfe, 0x46, 0xff, 0x41, 0x6d, 0xde, 0x0d, 0x92, 0xba, 0x61, 0x60, 0xac,
+	0x76, 0x34, 0xab, 0x86, 0xab, 0x0a, 0x18, 0x06, 0xe6, 0x27, 0x40, 0xb7, 0xd5, 0x51, 0x1b, 0xf4,
+	0x4b, 0x32, 0x93, 0x15, 0xd6, 0xf0, 0xfc, 0x88, 0xf6, 0x60, 0xed, 0xca, 0x8b, 0xa6, 0xc4, 0x28,
+	0x75, 0x34, 0xab, 0xde, 0x33, 0xef, 0xb6, 0x8c, 0x55, 0xe2, 0x7e, 0xe9, 0x95, 0xb6, 0xf3, 0x4d,
+	0x87, 0xd6, 0x29, 0xa3, 0xa1, 0x60, 0x29, 0x9e, 0xdb, 0xe1, 0x02, 0xed, 0xc1, 0x46, 0x4a, 0xf8,
+	0x34, 0x26, 0xee, 0xe7, 0x94, 0xc5, 0xee, 0xb5, 0x6b, 0x4d, 0xba, 0x46, 0x2a, 0x76, 0x94, 0xb2,
+	0x78, 0xbc, 0xf0, 0xff, 0x1c, 0x36, 0x8b, 0x8c, 0x45, 0x2d, 0x15, 0x69, 0xaf, 0x40, 0x51, 0x55,
+	0xa1, 0x5d, 0xf8, 0x2b, 0xdb, 0x03, 0x37, 0x5b, 0x04, 0xe9, 0xbb, 0x86, 0x5b, 0x19, 0x3c, 0x56,
+	0x28, 0x7a, 0x0a, 0xad, 0x9b, 0xa9, 0xbb, 0x61, 0xc0, 0x0d, 0xbd, 0xa3, 0x5b, 0x35, 0xdc, 0xbc,
+	0x41, 0x87, 0x01, 0x47, 0x7d, 0xd8, 0xfe, 0x45, 0xcf, 0x25, 0x34, 0x48, 0x58, 0x48, 0x85, 0x4b,
+	0xbd, 0x98, 0x64, 0x6d, 0x35, 0x8b, 0xea, 0x4e, 0x96, 0x32, 0xf2, 0x62, 0x82, 0xde, 0x40, 0x39,
+	0xf2, 0xce, 0x48, 0xc4, 0x8d, 0x35, 0x39, 0x74, 0xab, 0xd8, 0xc1, 0x62, 0x97, 0xec, 0x13, 0x99,
+	0xaa, 0x46, 0x9d, 0xf1, 0x50, 0x17, 0xca, 0x5c, 0x78, 0x82, 0x70, 0xa3, 0xdc, 0xd1, 0xad, 0x56,
+	0xef, 0x5f, 0x5b, 0x5e, 0x16, 0x3b, 0xb7, 0xb6, 0xe3, 0x79, 0x1c, 0x67, 0x69, 0xe6, 0x6b, 0xa8,
+	0xe7, 0x74, 0x96, 0x0c, 0x75, 0x23, 0x3f, 0xd4, 0x5a, 0x7e, 0x70, 0x3f, 0x74, 0x68, 0xe4, 0x87,
+	0x8a, 0x5a, 0x50, 0x0a, 0x83, 0x8c, 0x5b, 0x0a, 0x03, 0x64, 0x40, 0x25, 0xf1, 0x66, 0x11, 0xf3,
+	0x82, 0x8c, 0xbc, 0xf8, 0x44, 0x7d, 0x68, 0x48, 0x5f, 0x2e, 0x67, 0xd3, 0xd4, 0x57, 0xad, 0xa9,
+	0xf7, 0xb6, 0x6e, 0x9b, 0x3d, 0xbc, 0x3e, 0xbe, 0x5d, 0xc1, 0x75, 0x19, 0x1e, 0x4b, 0x0a, 0x1a,
+	0x40, 0x53, 0xdd, 0xce, 0x85, 0xc6, 0x9a, 0xd4, 0xd8, 0xb6, 0x15, 0x7a, 0xa7, 0x48, 0x43, 0xc5,
+	0x33, 0x95, 0x63, 0x58, 0x57, 0x46, 0x02, 0xc2, 0x45, 0x48, 0xbd, 0x79, 0x92, 0x51, 0x7e, 0x84,
+	0x1b, 0x0d, 0xb7, 0x65, 0x78, 0x70, 0xc3, 0x43, 0x23, 0x40, 0x99, 0xa5, 0xbc, 0x5a, 0xe5, 0x31,
+	0xbe, 0x34, 0xbc, 0xae, 0xe2, 0x79, 0xbd, 0x3e, 0x54, 0x62, 0x22, 0xd2, 0xd0, 0xe7, 0x46, 0x55,
+	0xee, 0xc3, 0xee, 0xdd, 0x37, 0xca, 0x3e, 0x55, 0x99, 0x6a, 0x1d, 0x16, 0x3c, 0x73, 0x1f, 0x1a,
+	0xf9, 0xc0, 0xef, 0xcc, 0xf7, 0xa0, 0x0a, 0x65, 0xd5, 0xda, 0x83, 0x26, 0xd4, 0x73, 0x15, 0x3d,
+	0x3b, 0x86, 0xcd, 0xa5, 0x4f, 0x18, 0x32, 0xe1, 0x9f, 0xe1, 0x68, 0x38, 0x19, 0xf6, 0x4f, 0xdc,
+	0xf1, 0xa4, 0x3f, 0x71, 0xdc, 0x09, 0xee, 0x8f, 0xc6, 0x47, 0x0e, 0x6e, 0xaf, 0x20, 0x80, 0xf2,
+	0xfb, 0x77, 0x83, 0xfe, 0xc4, 0x69, 0x6b, 0xf3, 0xf3, 0xc0, 0x39, 0x71, 0x26, 0x4e, 0xbb, 0xd4,
+	0xa3, 0xf0, 0x77, 0xb6, 0xd7, 0x85, 0x5d, 0xfa, 0x00, 0x1b, 0x4b, 0x60, 0x8e, 0xb6, 0xee, 0xbb,
+	0x12, 0xe6, 0xff, 0x0f, 0xbc, 0x92, 0x7b, 0xda, 0x59, 0x59, 0x3e, 0xdb, 0x2f, 0x7e, 0x0e, 0x00,
+	0x15, 0xd7, 0x25, 0xe7, 0xaf, 0x06, 0x00, 0x00,
 }
@@ -1,6 +1,12 @@
 package crossconnect
 
-import fmt "fmt"
+import (
+	fmt "fmt"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+)
 
 func (c *CrossConnect) IsValid() error {
 	if c == nil {
@@ -81,3 +87,44 @@ func (c *CrossConnect) IsComplete() error {
 
 	return nil
 }
+
+// Matches - returns true if the source or the destination connection of the cross connect matches all of the monitor selectors.
+func (r *MonitorRequest) Matches(xcon *CrossConnect) bool {
+	selector := &connectioncontext.ConnectionSelector{
+		NetworkService:             r.GetNetworkService(),
+		ConnectionIds:              r.GetConnectionIds(),
+		NetworkServiceEndpointName: r.GetNetworkServiceEndpointName(),
+		Labels:                     r.GetLabels(),
+	}
+	for _, state := range r.GetStates() {
+		selector.States = append(selector.States, int32(state))
+	}
+	if selector.IsEmpty() {
+		return true
+	}
+	// Cross connect id is matched by connection ids as well as ids of its connections.
+	for _, id := range selector.ConnectionIds {
+		if id == xcon.GetId() {
+			selector.ConnectionIds = nil
+			break
+		}
+	}
+
+	var connections []*connectioncontext.SelectedConnection
+	for _, c := range []*connection.Connection{xcon.GetLocalSource(), xcon.GetLocalDestination()} {
+		if c != nil {
+			connections = append(connections, c.Selected())
+		}
+	}
+	for _, c := range []*remote_connection.Connection{xcon.GetRemoteSource(), xcon.GetRemoteDestination()} {
+		if c != nil {
+			connections = append(connections, c.Selected())
+		}
+	}
+	for _, c := range connections {
+		if selector.Matches(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,7 +6,6 @@ package connection
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import empty "github.com/golang/protobuf/ptypes/empty"
 import connectioncontext "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 
 import (
@@ -57,7 +56,7 @@ func (x MechanismType) String() string {
 	return proto.EnumName(MechanismType_name, int32(x))
 }
 func (MechanismType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{0}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{0}
 }
 
 type State int32
@@ -80,7 +79,7 @@ func (x State) String() string {
 	return proto.EnumName(State_name, int32(x))
 }
 func (State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{1}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{1}
 }
 
 type ConnectionEventType int32
@@ -106,7 +105,7 @@ func (x ConnectionEventType) String() string {
 	return proto.EnumName(ConnectionEventType_name, int32(x))
 }
 func (ConnectionEventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{2}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{2}
 }
 
 type Mechanism struct {
@@ -121,7 +120,7 @@ func (m *Mechanism) Reset()         { *m = Mechanism{} }
 func (m *Mechanism) String() string { return proto.CompactTextString(m) }
 func (*Mechanism) ProtoMessage()    {}
 func (*Mechanism) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{0}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{0}
 }
 func (m *Mechanism) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Mechanism.Unmarshal(m, b)
@@ -171,7 +170,7 @@ func (m *Connection) Reset()         { *m = Connection{} }
 func (m *Connection) String() string { return proto.CompactTextString(m) }
 func (*Connection) ProtoMessage()    {}
 func (*Connection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{1}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{1}
 }
 func (m *Connection) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Connection.Unmarshal(m, b)
@@ -234,18 +233,22 @@ func (m *Connection) GetState() State {
 }
 
 type ConnectionEvent struct {
-	Type                 ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=local.connection.ConnectionEventType" json:"type,omitempty"`
-	Connections          map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Type        ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=local.connection.ConnectionEventType" json:"type,omitempty"`
+	Connections map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// sequence number of the event, INITIAL_STATE_TRANSFER has the sequence number of the last event included into it
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// id of the monitor server instance, sequence numbers of different instances are not comparable
+	ServerId             string   `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ConnectionEvent) Reset()         { *m = ConnectionEvent{} }
 func (m *ConnectionEvent) String() string { return proto.CompactTextString(m) }
 func (*ConnectionEvent) ProtoMessage()    {}
 func (*ConnectionEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5bd1d24000c00bdc, []int{2}
+	return fileDescriptor_connection_993b2b9b156baed3, []int{2}
 }
 func (m *ConnectionEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnectionEvent.Unmarshal(m, b)
@@ -279,6 +282,110 @@ func (m *ConnectionEvent) GetConnections() map[string]*Connection {
 	return nil
 }
 
+func (m *ConnectionEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ConnectionEvent) GetServerId() string {
+	if m != nil {
+		return m.ServerId
+	}
+	return ""
+}
+
+type MonitorRequest struct {
+	// sequence number of the last event received before reconnect, 0 requests the full state transfer
+	ResumeFromSequence uint64 `protobuf:"varint,1,opt,name=resume_from_sequence,json=resumeFromSequence,proto3" json:"resume_from_sequence,omitempty"`
+	// server id of the last event received before reconnect, the full state is transferred if it does not match
+	ResumeFromServerId string `protobuf:"bytes,7,opt,name=resume_from_server_id,json=resumeFromServerId,proto3" json:"resume_from_server_id,omitempty"`
+	// Selectors, the empty selector matches any connection
+	NetworkService             string   `protobuf:"bytes,2,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	ConnectionIds              []string `protobuf:"bytes,3,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	NetworkServiceEndpointName string   `protobuf:"bytes,4,opt,name=network_service_endpoint_name,json=networkServiceEndpointName,proto3" json:"network_service_endpoint_name,omitempty"`
+	// connection labels should contain all of the selector labels
+	Labels               map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	States               []State           `protobuf:"varint,6,rep,packed,name=states,proto3,enum=local.connection.State" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MonitorRequest) Reset()         { *m = MonitorRequest{} }
+func (m *MonitorRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorRequest) ProtoMessage()    {}
+func (*MonitorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_connection_993b2b9b156baed3, []int{3}
+}
+func (m *MonitorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorRequest.Unmarshal(m, b)
+}
+func (m *MonitorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorRequest.Marshal(b, m, deterministic)
+}
+func (dst *MonitorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorRequest.Merge(dst, src)
+}
+func (m *MonitorRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorRequest.Size(m)
+}
+func (m *MonitorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorRequest proto.InternalMessageInfo
+
+func (m *MonitorRequest) GetResumeFromSequence() uint64 {
+	if m != nil {
+		return m.ResumeFromSequence
+	}
+	return 0
+}
+
+func (m *MonitorRequest) GetResumeFromServerId() string {
+	if m != nil {
+		return m.ResumeFromServerId
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetConnectionIds() []string {
+	if m != nil {
+		return m.ConnectionIds
+	}
+	return nil
+}
+
+func (m *MonitorRequest) GetNetworkServiceEndpointName() string {
+	if m != nil {
+		return m.NetworkServiceEndpointName
+	}
+	return ""
+}
+
+func (m *MonitorRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MonitorRequest) GetStates() []State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Mechanism)(nil), "local.connection.Mechanism")
 	proto.RegisterMapType((map[string]string)(nil), "local.connection.Mechanism.ParametersEntry")
@@ -286,6 +393,8 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "local.connection.Connection.LabelsEntry")
 	proto.RegisterType((*ConnectionEvent)(nil), "local.connection.ConnectionEvent")
 	proto.RegisterMapType((map[string]*Connection)(nil), "local.connection.ConnectionEvent.ConnectionsEntry")
+	proto.RegisterType((*MonitorRequest)(nil), "local.connection.MonitorRequest")
+	proto.RegisterMapType((map[string]string)(nil), "local.connection.MonitorRequest.LabelsEntry")
 	proto.RegisterEnum("local.connection.MechanismType", MechanismType_name, MechanismType_value)
 	proto.RegisterEnum("local.connection.State", State_name, State_value)
 	proto.RegisterEnum("local.connection.ConnectionEventType", ConnectionEventType_name, ConnectionEventType_value)
@@ -303,7 +412,7 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MonitorConnectionClient interface {
-	MonitorConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (MonitorConnection_MonitorConnectionsClient, error)
+	MonitorConnections(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (MonitorConnection_MonitorConnectionsClient, error)
 }
 
 type monitorConnectionClient struct {
@@ -314,7 +423,7 @@ func NewMonitorConnectionClient(cc *grpc.ClientConn) MonitorConnectionClient {
 	return &monitorConnectionClient{cc}
 }
 
-func (c *monitorConnectionClient) MonitorConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (MonitorConnection_MonitorConnectionsClient, error) {
+func (c *monitorConnectionClient) MonitorConnections(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (MonitorConnection_MonitorConnectionsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_MonitorConnection_serviceDesc.Streams[0], "/local.connection.MonitorConnection/MonitorConnections", opts...)
 	if err != nil {
 		return nil, err
@@ -348,7 +457,7 @@ func (x *monitorConnectionMonitorConnectionsClient) Recv() (*ConnectionEvent, er
 
 // MonitorConnectionServer is the server API for MonitorConnection service.
 type MonitorConnectionServer interface {
-	MonitorConnections(*empty.Empty, MonitorConnection_MonitorConnectionsServer) error
+	MonitorConnections(*MonitorRequest, MonitorConnection_MonitorConnectionsServer) error
 }
 
 func RegisterMonitorConnectionServer(s *grpc.Server, srv MonitorConnectionServer) {
@@ -356,7 +465,7 @@ func RegisterMonitorConnectionServer(s *grpc.Server, srv MonitorConnectionServer
 }
 
 func _MonitorConnection_MonitorConnections_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(empty.Empty)
+	m := new(MonitorRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -390,48 +499,56 @@ var _MonitorConnection_serviceDesc = grpc.ServiceDesc{
 	Metadata: "connection.proto",
 }
 
-func init() { proto.RegisterFile("connection.proto", fileDescriptor_connection_5bd1d24000c00bdc) }
-
-var fileDescriptor_connection_5bd1d24000c00bdc = []byte{
-	// 638 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xef, 0x4f, 0xd3, 0x50,
-	0x14, 0xa5, 0xdd, 0x0f, 0xe5, 0x0e, 0x58, 0x79, 0x20, 0xd6, 0x6a, 0x22, 0x12, 0x8d, 0x0b, 0xc6,
-	0xd6, 0x94, 0x2f, 0x62, 0xa2, 0x71, 0xb2, 0x47, 0x58, 0xd8, 0x06, 0xb6, 0x05, 0x12, 0x63, 0xb2,
-	0x74, 0xe5, 0x51, 0x1a, 0xda, 0xbe, 0xa6, 0x7d, 0xa0, 0xfb, 0xe6, 0x77, 0xff, 0x35, 0xff, 0x14,
-	0xff, 0x08, 0xd3, 0x1f, 0xa3, 0x0f, 0x46, 0x46, 0xfc, 0xb2, 0xac, 0xe7, 0x9e, 0x73, 0xef, 0xb9,
-	0xe7, 0xbd, 0x16, 0x24, 0x87, 0x86, 0x21, 0x71, 0x98, 0x47, 0x43, 0x35, 0x8a, 0x29, 0xa3, 0x48,
-	0xf2, 0xa9, 0x63, 0xfb, 0x6a, 0x89, 0x2b, 0x5b, 0xae, 0xc7, 0xce, 0x2f, 0x47, 0xaa, 0x43, 0x03,
-	0xcd, 0xa5, 0xbe, 0x1d, 0xba, 0x5a, 0x46, 0x1d, 0x5d, 0x9e, 0x69, 0x11, 0x1b, 0x47, 0x24, 0xd1,
-	0x48, 0x10, 0xb1, 0x71, 0xfe, 0x9b, 0xb7, 0x51, 0x22, 0x4e, 0x14, 0x12, 0xf6, 0x83, 0xc6, 0x17,
-	0x09, 0x89, 0xaf, 0x3c, 0x87, 0x04, 0x24, 0x39, 0xbf, 0x0b, 0x72, 0x68, 0xc8, 0x62, 0xea, 0x47,
-	0xbe, 0x1d, 0x12, 0x2d, 0xba, 0x70, 0x35, 0x3b, 0xf2, 0x12, 0xad, 0xf4, 0x91, 0xd6, 0xc9, 0x4f,
-	0x36, 0x8d, 0xe4, 0x13, 0x37, 0xfe, 0x08, 0x30, 0xdf, 0x27, 0xce, 0xb9, 0x1d, 0x7a, 0x49, 0x80,
-	0xb6, 0xa0, 0x9a, 0x5a, 0x93, 0x85, 0x75, 0xa1, 0xb5, 0xa4, 0x3f, 0x57, 0x6f, 0x6f, 0xa5, 0x5e,
-	0x53, 0xad, 0x71, 0x44, 0x8c, 0x8c, 0x8c, 0xf6, 0x01, 0x22, 0x3b, 0xb6, 0x03, 0xc2, 0x48, 0x9c,
-	0xc8, 0xe2, 0x7a, 0xa5, 0xd5, 0xd0, 0xdf, 0xcc, 0x90, 0xaa, 0x87, 0xd7, 0x6c, 0x1c, 0xb2, 0x78,
-	0x6c, 0x70, 0x72, 0xe5, 0x23, 0x34, 0x6f, 0x95, 0x91, 0x04, 0x95, 0x0b, 0x32, 0xce, 0x3c, 0xcd,
-	0x1b, 0xe9, 0x5f, 0xb4, 0x0a, 0xb5, 0x2b, 0xdb, 0xbf, 0x24, 0xb2, 0x98, 0x61, 0xf9, 0xc3, 0x07,
-	0xf1, 0xbd, 0xb0, 0xf1, 0x57, 0x04, 0xd8, 0xb9, 0x9e, 0x89, 0x96, 0x40, 0xf4, 0x4e, 0x0b, 0xa5,
-	0xe8, 0x9d, 0xa2, 0xd7, 0xd0, 0x2c, 0x32, 0x1c, 0x16, 0x21, 0x16, 0x2d, 0x96, 0x0a, 0xd8, 0xcc,
-	0x51, 0xb4, 0x0d, 0xf3, 0xc1, 0xc4, 0xaf, 0x5c, 0x59, 0x17, 0x5a, 0x0d, 0xfd, 0xe9, 0x8c, 0x95,
-	0x8c, 0x92, 0x8d, 0x3e, 0xc1, 0x83, 0x22, 0x62, 0xb9, 0x9a, 0x09, 0x5f, 0xaa, 0xd3, 0xe1, 0x97,
-	0x1e, 0x77, 0x72, 0xc4, 0x98, 0x88, 0xd0, 0x67, 0xa8, 0xfb, 0xf6, 0x88, 0xf8, 0x89, 0x5c, 0xcb,
-	0xa2, 0x6c, 0x4d, 0xcf, 0x2d, 0xd5, 0x6a, 0x2f, 0xa3, 0xe6, 0x39, 0x16, 0x3a, 0xf4, 0x16, 0x6a,
-	0x09, 0xb3, 0x19, 0x91, 0xeb, 0xd9, 0x31, 0x3e, 0x9e, 0x6e, 0x60, 0xa6, 0x65, 0x23, 0x67, 0x29,
-	0xdb, 0xd0, 0xe0, 0xba, 0xfc, 0x57, 0xdc, 0xbf, 0x44, 0x68, 0x96, 0x66, 0xf0, 0x15, 0x09, 0x19,
-	0xda, 0xbe, 0x71, 0x87, 0x5e, 0xcd, 0x72, 0x9f, 0x09, 0xb8, 0x9b, 0x64, 0x41, 0xa3, 0xe4, 0x4d,
-	0xae, 0x92, 0x7e, 0x6f, 0x07, 0xee, 0xb9, 0x48, 0x82, 0x6f, 0xa3, 0x7c, 0x07, 0xe9, 0x36, 0xe1,
-	0x8e, 0x25, 0x75, 0x7e, 0xc9, 0x86, 0xfe, 0x6c, 0xd6, 0x54, 0x2e, 0x82, 0xcd, 0xdf, 0x02, 0x2c,
-	0xde, 0x78, 0x2b, 0xd0, 0x23, 0x58, 0xee, 0xe0, 0xdd, 0xf6, 0x51, 0xcf, 0x1a, 0x76, 0x07, 0x16,
-	0x36, 0x76, 0xdb, 0x3b, 0x58, 0x9a, 0x43, 0xab, 0x20, 0xed, 0x63, 0x63, 0x80, 0x7b, 0x1c, 0x2a,
-	0xa0, 0x15, 0x68, 0x1e, 0xef, 0x1d, 0x98, 0x3c, 0x55, 0x44, 0xcb, 0xb0, 0xd8, 0xc7, 0x7d, 0x0e,
-	0xaa, 0xa4, 0x3c, 0xd3, 0xe8, 0x1e, 0x1c, 0x73, 0x60, 0x15, 0x49, 0xb0, 0xb0, 0x77, 0xc2, 0x21,
-	0xb5, 0xcd, 0x27, 0x50, 0xcb, 0xce, 0x16, 0xd5, 0x41, 0x3c, 0x3a, 0x94, 0xe6, 0xd0, 0x43, 0xa8,
-	0x76, 0x0e, 0x4e, 0x06, 0x92, 0xb0, 0xd9, 0x85, 0x95, 0x3b, 0x92, 0x47, 0x0a, 0xac, 0x75, 0x07,
-	0x5d, 0xab, 0xdb, 0xee, 0x0d, 0x4d, 0xab, 0x6d, 0xe1, 0xa1, 0x65, 0xb4, 0x07, 0xe6, 0x2e, 0x36,
-	0xa4, 0x39, 0x04, 0x50, 0x3f, 0x3a, 0xec, 0xb4, 0xad, 0xd4, 0x28, 0x40, 0xbd, 0x83, 0x7b, 0xd8,
-	0xc2, 0x92, 0xa8, 0x9f, 0xc1, 0x72, 0x9f, 0x86, 0x1e, 0xa3, 0x31, 0xf7, 0xae, 0x7d, 0x05, 0x34,
-	0x05, 0x26, 0x68, 0x4d, 0x75, 0x29, 0x75, 0x7d, 0xa2, 0x4e, 0x3e, 0x7e, 0x2a, 0x4e, 0xbf, 0x77,
-	0xca, 0x8b, 0x7b, 0x4f, 0xf5, 0x9d, 0xf0, 0x65, 0xe1, 0x1b, 0x94, 0xf5, 0x51, 0x3d, 0x6b, 0xb1,
-	0xf5, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xa2, 0x60, 0xd3, 0xf2, 0x7e, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("connection.proto", fileDescriptor_connection_993b2b9b156baed3) }
+
+var fileDescriptor_connection_993b2b9b156baed3 = []byte{
+	// 762 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xed, 0x4e, 0xdb, 0x48,
+	0x14, 0xc5, 0x76, 0x62, 0xc8, 0x0d, 0x24, 0xe6, 0x02, 0xbb, 0x5e, 0xb3, 0xab, 0x4d, 0x51, 0x51,
+	0x23, 0xda, 0x3a, 0x34, 0xfc, 0x29, 0x95, 0x5a, 0x35, 0x25, 0x8e, 0xb0, 0x48, 0x02, 0x72, 0x0c,
+	0x48, 0x6d, 0x25, 0xcb, 0x38, 0x53, 0xb0, 0x88, 0x3f, 0x6a, 0x3b, 0xb4, 0x79, 0x86, 0x3e, 0x51,
+	0xfb, 0x0c, 0x7d, 0x94, 0x3e, 0x44, 0x15, 0xdb, 0x89, 0x9d, 0x0f, 0x85, 0x56, 0xfd, 0xc7, 0x9c,
+	0x39, 0xe7, 0xf8, 0xcc, 0x99, 0x3b, 0x04, 0x38, 0xc3, 0xb1, 0x6d, 0x62, 0x04, 0xa6, 0x63, 0x8b,
+	0xae, 0xe7, 0x04, 0x0e, 0x72, 0x3d, 0xc7, 0xd0, 0x7b, 0x62, 0x82, 0x0b, 0xee, 0xb5, 0x19, 0xdc,
+	0xf4, 0xaf, 0x44, 0xc3, 0xb1, 0x2a, 0x36, 0x09, 0x3e, 0x39, 0xde, 0xad, 0x4f, 0xbc, 0x3b, 0xd3,
+	0x20, 0x16, 0xf1, 0x6f, 0xe6, 0x41, 0x86, 0x63, 0x07, 0x9e, 0xd3, 0x73, 0x7b, 0xba, 0x4d, 0x2a,
+	0xee, 0xed, 0x75, 0x45, 0x77, 0x4d, 0xbf, 0x92, 0x58, 0x0e, 0xf7, 0xc9, 0xe7, 0x60, 0x16, 0x89,
+	0x32, 0xec, 0x7c, 0xa7, 0x20, 0xd7, 0x22, 0xc6, 0x8d, 0x6e, 0x9b, 0xbe, 0x85, 0x07, 0x90, 0x09,
+	0x06, 0x2e, 0xe1, 0xa9, 0x12, 0x55, 0x2e, 0x54, 0xff, 0x17, 0xa7, 0x03, 0x8a, 0x63, 0xaa, 0x3a,
+	0x70, 0x89, 0x12, 0x92, 0xf1, 0x04, 0xc0, 0xd5, 0x3d, 0xdd, 0x22, 0x01, 0xf1, 0x7c, 0x9e, 0x2e,
+	0x31, 0xe5, 0x7c, 0xf5, 0xf1, 0x02, 0xa9, 0x78, 0x36, 0x66, 0x4b, 0x76, 0xe0, 0x0d, 0x94, 0x94,
+	0x5c, 0x78, 0x09, 0xc5, 0xa9, 0x6d, 0xe4, 0x80, 0xb9, 0x25, 0x83, 0x30, 0x53, 0x4e, 0x19, 0xfe,
+	0x89, 0x9b, 0x90, 0xbd, 0xd3, 0x7b, 0x7d, 0xc2, 0xd3, 0x21, 0x16, 0x2d, 0x5e, 0xd0, 0xcf, 0xa9,
+	0x9d, 0x1f, 0x34, 0xc0, 0xd1, 0xf8, 0x9b, 0x58, 0x00, 0xda, 0xec, 0xc6, 0x4a, 0xda, 0xec, 0xe2,
+	0x23, 0x28, 0xc6, 0x1d, 0x6a, 0x71, 0x89, 0xb1, 0x45, 0x21, 0x86, 0x3b, 0x11, 0x8a, 0x87, 0x90,
+	0xb3, 0x46, 0x79, 0x79, 0xa6, 0x44, 0x95, 0xf3, 0xd5, 0xed, 0x05, 0x47, 0x52, 0x12, 0x36, 0xbe,
+	0x82, 0xe5, 0xb8, 0x62, 0x3e, 0x13, 0x0a, 0x1f, 0x8a, 0xb3, 0xe5, 0x27, 0x19, 0x8f, 0x22, 0x44,
+	0x19, 0x89, 0xf0, 0x35, 0xb0, 0x3d, 0xfd, 0x8a, 0xf4, 0x7c, 0x3e, 0x1b, 0x56, 0x59, 0x9e, 0xfd,
+	0x6e, 0xa2, 0x16, 0x9b, 0x21, 0x35, 0xea, 0x31, 0xd6, 0xe1, 0x53, 0xc8, 0xfa, 0x81, 0x1e, 0x10,
+	0x9e, 0x0d, 0xaf, 0xf1, 0xef, 0x59, 0x83, 0xce, 0x70, 0x5b, 0x89, 0x58, 0xc2, 0x21, 0xe4, 0x53,
+	0x2e, 0xbf, 0x55, 0xf7, 0x57, 0x1a, 0x8a, 0x49, 0x18, 0xe9, 0x8e, 0xd8, 0x01, 0x1e, 0x4e, 0xcc,
+	0xd0, 0xee, 0xa2, 0xf4, 0xa1, 0x20, 0x35, 0x49, 0x2a, 0xe4, 0x13, 0xde, 0x68, 0x94, 0xaa, 0xf7,
+	0x3a, 0xa4, 0xd6, 0x71, 0x13, 0x69, 0x1b, 0x14, 0x60, 0xc5, 0x27, 0x1f, 0xfb, 0xc4, 0x36, 0x48,
+	0x78, 0x95, 0x19, 0x65, 0xbc, 0xc6, 0x6d, 0xc8, 0x0d, 0x07, 0x81, 0x78, 0x9a, 0xd9, 0x0d, 0xaf,
+	0x2b, 0xa7, 0xac, 0x44, 0x80, 0xdc, 0x15, 0xde, 0x03, 0x37, 0xed, 0x3c, 0xa7, 0x9d, 0x6a, 0xba,
+	0x9d, 0x7c, 0xf5, 0xdf, 0x45, 0x71, 0xd3, 0xdd, 0x7d, 0x63, 0xa0, 0xd0, 0x72, 0x6c, 0x33, 0x70,
+	0x3c, 0x65, 0x18, 0xc7, 0x0f, 0x70, 0x1f, 0x36, 0x3d, 0xe2, 0xf7, 0x2d, 0xa2, 0x7d, 0xf0, 0x1c,
+	0x4b, 0x1b, 0xa7, 0xa6, 0xc2, 0xd4, 0x18, 0xed, 0x35, 0x3c, 0xc7, 0xea, 0x8c, 0xf2, 0x3f, 0x83,
+	0xad, 0x49, 0xc5, 0xe8, 0x2c, 0xcb, 0x61, 0xc0, 0x09, 0x49, 0x74, 0xaa, 0x5f, 0x7f, 0x03, 0xbb,
+	0x50, 0x48, 0x0e, 0xa1, 0x99, 0x5d, 0x9f, 0x67, 0x4a, 0x4c, 0x39, 0xa7, 0xac, 0x25, 0xa8, 0xdc,
+	0xf5, 0xb1, 0x06, 0xff, 0x4d, 0xf9, 0x69, 0xc4, 0xee, 0xba, 0x8e, 0x69, 0x07, 0x9a, 0xad, 0x5b,
+	0x24, 0xae, 0x55, 0x98, 0x74, 0x97, 0x62, 0x4a, 0x5b, 0xb7, 0x08, 0xd6, 0xa7, 0x46, 0xfe, 0xc9,
+	0x9c, 0xa7, 0x36, 0xd1, 0xd4, 0xdc, 0xb1, 0xaf, 0x00, 0x1b, 0x0e, 0xb4, 0xcf, 0xb3, 0x25, 0x66,
+	0xd1, 0xdc, 0xc7, 0xb4, 0x3f, 0x18, 0xfc, 0xbd, 0x2f, 0x14, 0xac, 0x4d, 0xfc, 0x2f, 0xc4, 0x2d,
+	0x58, 0xaf, 0x4b, 0x8d, 0xda, 0x79, 0x53, 0xd5, 0xe4, 0xb6, 0x2a, 0x29, 0x8d, 0xda, 0x91, 0xc4,
+	0x2d, 0xe1, 0x26, 0x70, 0x27, 0x92, 0xd2, 0x96, 0x9a, 0x29, 0x94, 0xc2, 0x0d, 0x28, 0x5e, 0x1c,
+	0x9f, 0x76, 0xd2, 0x54, 0x1a, 0xd7, 0x61, 0xad, 0x25, 0xb5, 0x52, 0x10, 0x33, 0xe4, 0x75, 0x14,
+	0xf9, 0xf4, 0x22, 0x05, 0x66, 0x90, 0x83, 0xd5, 0xe3, 0xcb, 0x14, 0x92, 0xdd, 0xfb, 0x07, 0xb2,
+	0xe1, 0xc9, 0x90, 0x05, 0xfa, 0xfc, 0x8c, 0x5b, 0xc2, 0x15, 0xc8, 0xd4, 0x4f, 0x2f, 0xdb, 0x1c,
+	0xb5, 0x27, 0xc3, 0xc6, 0x9c, 0xf7, 0x86, 0x02, 0xfc, 0x25, 0xb7, 0x65, 0x55, 0xae, 0x35, 0xb5,
+	0x8e, 0x5a, 0x53, 0x25, 0x4d, 0x55, 0x6a, 0xed, 0x4e, 0x43, 0x52, 0xb8, 0x25, 0x04, 0x60, 0xcf,
+	0xcf, 0xea, 0x35, 0x75, 0x18, 0x14, 0x80, 0xad, 0x4b, 0x4d, 0x49, 0x95, 0x38, 0xba, 0xea, 0xc2,
+	0x7a, 0x7c, 0x0b, 0x89, 0x23, 0xbe, 0x03, 0x9c, 0x01, 0x7d, 0x2c, 0xdd, 0x77, 0x81, 0xc2, 0x83,
+	0x7b, 0x5f, 0xf5, 0x3e, 0xf5, 0x66, 0xf5, 0x2d, 0x24, 0xfb, 0x57, 0x6c, 0xf8, 0x8b, 0x75, 0xf0,
+	0x73, 0x00, 0x7b, 0xbd, 0xa7, 0xff, 0x49, 0x07, 0x00, 0x00,
 }
@@ -4,6 +4,7 @@ const (
 	NetNsInodeKey           = "netnsInode"
 	InterfaceNameKey        = "name"
 	InterfaceDescriptionKey = "description"
+	MtuKey                  = "mtu"
 	LinuxIfMaxLength        = 15 // Linux has a limit of 15 characters for an interface name
 	SocketFilename          = "socketfile"
 	Master                  = "master"
@@ -79,6 +79,12 @@ func (m *Mechanism) IsValid() error {
 		}
 	}
 
+	if mtu, ok := m.GetParameters()[MtuKey]; ok {
+		if _, err := strconv.ParseUint(mtu, 10, 32); err != nil {
+			return fmt.Errorf("Mechanism.Parameters[%s] must be an unsigned int, instead was: %s: %v", MtuKey, mtu, m)
+		}
+	}
+
 	if m.Type == MechanismType_MEM_INTERFACE {
 		_, ok := m.GetParameters()[InterfaceNameKey]
 		if !ok {
@@ -99,3 +105,33 @@ func (c *Connection) GetNetworkServiceEndpointName() string {
 func (c *Connection) SetNetworkServiceName(networkService string) {
 	c.NetworkService = networkService
 }
+
+// Matches - returns true if the connection matches all of the monitor selectors.
+func (r *MonitorRequest) Matches(c *Connection) bool {
+	return r.Selector().Matches(c.Selected())
+}
+
+// Selector returns the monitor selectors of the request.
+func (r *MonitorRequest) Selector() *connectioncontext.ConnectionSelector {
+	selector := &connectioncontext.ConnectionSelector{
+		NetworkService:             r.GetNetworkService(),
+		ConnectionIds:              r.GetConnectionIds(),
+		NetworkServiceEndpointName: r.GetNetworkServiceEndpointName(),
+		Labels:                     r.GetLabels(),
+	}
+	for _, state := range r.GetStates() {
+		selector.States = append(selector.States, int32(state))
+	}
+	return selector
+}
+
+// Selected returns the connection fields checked by monitor selectors.
+func (c *Connection) Selected() *connectioncontext.SelectedConnection {
+	return &connectioncontext.SelectedConnection{
+		NetworkService:             c.GetNetworkService(),
+		Id:                         c.GetId(),
+		NetworkServiceEndpointName: c.GetMechanism().GetParameters()[WorkspaceNSEName],
+		Labels:                     c.GetLabels(),
+		State:                      int32(c.GetState()),
+	}
+}
@@ -72,6 +72,18 @@ func (m *Mechanism) GetDescription() string {
 	return m.GetParameters()[InterfaceDescriptionKey]
 }
 
+// GetMtu returns a requested MTU of the interface, 0 if not requested.
+func (m *Mechanism) GetMtu() uint32 {
+	if m == nil || m.GetParameters() == nil {
+		return 0
+	}
+	mtu, err := strconv.ParseUint(m.GetParameters()[MtuKey], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(mtu)
+}
+
 func (m *Mechanism) GetWorkspace() string {
 	if m == nil || m.GetParameters() == nil {
 		return ""
@@ -62,6 +62,7 @@ type NetworkServiceManager interface {
 	Close(ctx context.Context, clientConnection NSMClientConnection) error
 	Heal(connection NSMClientConnection, healState HealState)
 	RestoreConnections(xcons []*crossconnect.CrossConnect, dataplane string)
+	RestoreCheckpoint(connections []NSMClientConnection)
 	GetHealProperties() *HealTimeouts
 	WaitForDataplane(duration time.Duration) error
 	RemoteConnectionLost(clientConnection NSMClientConnection)
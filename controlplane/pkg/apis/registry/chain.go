@@ -0,0 +1,66 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// ChainServiceLabel is a connection label with a name of network service, which defines a service chain.
+	ChainServiceLabel = "nsm.chain.service"
+	// ChainHopLabel is a connection label with an index of the requested hop of service chain.
+	ChainHopLabel = "nsm.chain.hop"
+)
+
+// GetChainHop returns the hop of service chain requested by connection labels, first hop is requested if labels
+// do not refer to this network service.
+func (ns *NetworkService) GetChainHop(labels map[string]string) (int, *ChainHop, error) {
+	index := 0
+	if labels[ChainServiceLabel] == ns.GetName() {
+		if value, ok := labels[ChainHopLabel]; ok {
+			var err error
+			if index, err = strconv.Atoi(value); err != nil {
+				return 0, nil, fmt.Errorf("invalid %s label value %s: %v", ChainHopLabel, value, err)
+			}
+		}
+	}
+	if index < 0 || index >= len(ns.GetChain()) {
+		return 0, nil, fmt.Errorf("network service %s has no chain hop %d", ns.GetName(), index)
+	}
+	return index, ns.GetChain()[index], nil
+}
+
+// NextChainHopLabels returns connection labels to request the hop following the passed one, nil is returned for the last hop.
+func (ns *NetworkService) NextChainHopLabels(index int) map[string]string {
+	if index+1 >= len(ns.GetChain()) {
+		return nil
+	}
+	return map[string]string{
+		ChainServiceLabel: ns.GetName(),
+		ChainHopLabel:     strconv.Itoa(index + 1),
+	}
+}
+
+// IsMatching checks if endpoint has all labels required by chain hop.
+func (hop *ChainHop) IsMatching(nse *NetworkServiceEndpoint) bool {
+	for k, v := range hop.GetLabels() {
+		if nse.GetLabels()[k] != v {
+			return false
+		}
+	}
+	return true
+}
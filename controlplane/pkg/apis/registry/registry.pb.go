@@ -32,16 +32,22 @@ type NetworkServiceEndpoint struct {
 	EndpointName              string            `protobuf:"bytes,4,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
 	Labels                    map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	State                     string            `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral      struct{}          `json:"-"`
-	XXX_unrecognized          []byte            `json:"-"`
-	XXX_sizecache             int32             `json:"-"`
+	// max number of connections endpoint could serve, 0 means unlimited
+	Capacity uint32 `protobuf:"varint,7,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// current number of connections served by endpoint
+	Load uint32 `protobuf:"varint,8,opt,name=load,proto3" json:"load,omitempty"`
+	// arbitrary endpoint metadata, not used for endpoint selection
+	Metadata             map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *NetworkServiceEndpoint) Reset()         { *m = NetworkServiceEndpoint{} }
 func (m *NetworkServiceEndpoint) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpoint) ProtoMessage()    {}
 func (*NetworkServiceEndpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{0}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{0}
 }
 func (m *NetworkServiceEndpoint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkServiceEndpoint.Unmarshal(m, b)
@@ -103,20 +109,42 @@ func (m *NetworkServiceEndpoint) GetState() string {
 	return ""
 }
 
+func (m *NetworkServiceEndpoint) GetCapacity() uint32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *NetworkServiceEndpoint) GetLoad() uint32 {
+	if m != nil {
+		return m.Load
+	}
+	return 0
+}
+
+func (m *NetworkServiceEndpoint) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type NetworkService struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	Matches              []*Match `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Payload              string      `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Matches              []*Match    `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	Chain                []*ChainHop `protobuf:"bytes,4,rep,name=chain,proto3" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *NetworkService) Reset()         { *m = NetworkService{} }
 func (m *NetworkService) String() string { return proto.CompactTextString(m) }
 func (*NetworkService) ProtoMessage()    {}
 func (*NetworkService) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{1}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{1}
 }
 func (m *NetworkService) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkService.Unmarshal(m, b)
@@ -157,6 +185,59 @@ func (m *NetworkService) GetMatches() []*Match {
 	return nil
 }
 
+func (m *NetworkService) GetChain() []*ChainHop {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+type ChainHop struct {
+	NetworkService       string            `protobuf:"bytes,1,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ChainHop) Reset()         { *m = ChainHop{} }
+func (m *ChainHop) String() string { return proto.CompactTextString(m) }
+func (*ChainHop) ProtoMessage()    {}
+func (*ChainHop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{2}
+}
+func (m *ChainHop) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainHop.Unmarshal(m, b)
+}
+func (m *ChainHop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainHop.Marshal(b, m, deterministic)
+}
+func (dst *ChainHop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainHop.Merge(dst, src)
+}
+func (m *ChainHop) XXX_Size() int {
+	return xxx_messageInfo_ChainHop.Size(m)
+}
+func (m *ChainHop) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainHop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainHop proto.InternalMessageInfo
+
+func (m *ChainHop) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *ChainHop) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type Match struct {
 	SourceSelector       map[string]string `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Routes               []*Destination    `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
@@ -169,7 +250,7 @@ func (m *Match) Reset()         { *m = Match{} }
 func (m *Match) String() string { return proto.CompactTextString(m) }
 func (*Match) ProtoMessage()    {}
 func (*Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{2}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{3}
 }
 func (m *Match) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Match.Unmarshal(m, b)
@@ -215,7 +296,7 @@ func (m *Destination) Reset()         { *m = Destination{} }
 func (m *Destination) String() string { return proto.CompactTextString(m) }
 func (*Destination) ProtoMessage()    {}
 func (*Destination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{3}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{4}
 }
 func (m *Destination) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Destination.Unmarshal(m, b)
@@ -263,7 +344,7 @@ func (m *NetworkServiceManager) Reset()         { *m = NetworkServiceManager{} }
 func (m *NetworkServiceManager) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceManager) ProtoMessage()    {}
 func (*NetworkServiceManager) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{4}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{5}
 }
 func (m *NetworkServiceManager) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkServiceManager.Unmarshal(m, b)
@@ -322,7 +403,7 @@ func (m *RemoveNSERequest) Reset()         { *m = RemoveNSERequest{} }
 func (m *RemoveNSERequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveNSERequest) ProtoMessage()    {}
 func (*RemoveNSERequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{5}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{6}
 }
 func (m *RemoveNSERequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveNSERequest.Unmarshal(m, b)
@@ -349,6 +430,52 @@ func (m *RemoveNSERequest) GetEndpointName() string {
 	return ""
 }
 
+type UpdateNSELoadRequest struct {
+	EndpointName         string   `protobuf:"bytes,1,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	Load                 uint32   `protobuf:"varint,2,opt,name=load,proto3" json:"load,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateNSELoadRequest) Reset()         { *m = UpdateNSELoadRequest{} }
+func (m *UpdateNSELoadRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateNSELoadRequest) ProtoMessage()    {}
+func (*UpdateNSELoadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{7}
+}
+func (m *UpdateNSELoadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNSELoadRequest.Unmarshal(m, b)
+}
+func (m *UpdateNSELoadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNSELoadRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateNSELoadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNSELoadRequest.Merge(dst, src)
+}
+func (m *UpdateNSELoadRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateNSELoadRequest.Size(m)
+}
+func (m *UpdateNSELoadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNSELoadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNSELoadRequest proto.InternalMessageInfo
+
+func (m *UpdateNSELoadRequest) GetEndpointName() string {
+	if m != nil {
+		return m.EndpointName
+	}
+	return ""
+}
+
+func (m *UpdateNSELoadRequest) GetLoad() uint32 {
+	if m != nil {
+		return m.Load
+	}
+	return 0
+}
+
 type FindNetworkServiceRequest struct {
 	NetworkServiceName   string   `protobuf:"bytes,1,opt,name=network_service_name,json=networkServiceName,proto3" json:"network_service_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -360,7 +487,7 @@ func (m *FindNetworkServiceRequest) Reset()         { *m = FindNetworkServiceReq
 func (m *FindNetworkServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceRequest) ProtoMessage()    {}
 func (*FindNetworkServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{6}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{8}
 }
 func (m *FindNetworkServiceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindNetworkServiceRequest.Unmarshal(m, b)
@@ -401,7 +528,7 @@ func (m *FindNetworkServiceResponse) Reset()         { *m = FindNetworkServiceRe
 func (m *FindNetworkServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceResponse) ProtoMessage()    {}
 func (*FindNetworkServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{7}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{9}
 }
 func (m *FindNetworkServiceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindNetworkServiceResponse.Unmarshal(m, b)
@@ -462,7 +589,7 @@ func (m *NSERegistration) Reset()         { *m = NSERegistration{} }
 func (m *NSERegistration) String() string { return proto.CompactTextString(m) }
 func (*NSERegistration) ProtoMessage()    {}
 func (*NSERegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{8}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{10}
 }
 func (m *NSERegistration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NSERegistration.Unmarshal(m, b)
@@ -514,7 +641,7 @@ func (m *NetworkServiceEndpointList) Reset()         { *m = NetworkServiceEndpoi
 func (m *NetworkServiceEndpointList) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpointList) ProtoMessage()    {}
 func (*NetworkServiceEndpointList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{9}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{11}
 }
 func (m *NetworkServiceEndpointList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkServiceEndpointList.Unmarshal(m, b)
@@ -553,7 +680,7 @@ func (m *ClusterConfiguration) Reset()         { *m = ClusterConfiguration{} }
 func (m *ClusterConfiguration) String() string { return proto.CompactTextString(m) }
 func (*ClusterConfiguration) ProtoMessage()    {}
 func (*ClusterConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_a4b6557fc191ae86, []int{10}
+	return fileDescriptor_registry_3bf1fc2086b2be35, []int{12}
 }
 func (m *ClusterConfiguration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ClusterConfiguration.Unmarshal(m, b)
@@ -590,13 +717,17 @@ func (m *ClusterConfiguration) GetServiceSubnet() string {
 func init() {
 	proto.RegisterType((*NetworkServiceEndpoint)(nil), "registry.NetworkServiceEndpoint")
 	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceEndpoint.LabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceEndpoint.MetadataEntry")
 	proto.RegisterType((*NetworkService)(nil), "registry.NetworkService")
+	proto.RegisterType((*ChainHop)(nil), "registry.ChainHop")
+	proto.RegisterMapType((map[string]string)(nil), "registry.ChainHop.LabelsEntry")
 	proto.RegisterType((*Match)(nil), "registry.Match")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Match.SourceSelectorEntry")
 	proto.RegisterType((*Destination)(nil), "registry.Destination")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Destination.DestinationSelectorEntry")
 	proto.RegisterType((*NetworkServiceManager)(nil), "registry.NetworkServiceManager")
 	proto.RegisterType((*RemoveNSERequest)(nil), "registry.RemoveNSERequest")
+	proto.RegisterType((*UpdateNSELoadRequest)(nil), "registry.UpdateNSELoadRequest")
 	proto.RegisterType((*FindNetworkServiceRequest)(nil), "registry.FindNetworkServiceRequest")
 	proto.RegisterType((*FindNetworkServiceResponse)(nil), "registry.FindNetworkServiceResponse")
 	proto.RegisterMapType((map[string]*NetworkServiceManager)(nil), "registry.FindNetworkServiceResponse.NetworkServiceManagersEntry")
@@ -619,6 +750,7 @@ const _ = grpc.SupportPackageIsVersion4
 type NetworkServiceRegistryClient interface {
 	RegisterNSE(ctx context.Context, in *NSERegistration, opts ...grpc.CallOption) (*NSERegistration, error)
 	RemoveNSE(ctx context.Context, in *RemoveNSERequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateNSELoad(ctx context.Context, in *UpdateNSELoadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type networkServiceRegistryClient struct {
@@ -647,10 +779,20 @@ func (c *networkServiceRegistryClient) RemoveNSE(ctx context.Context, in *Remove
 	return out, nil
 }
 
+func (c *networkServiceRegistryClient) UpdateNSELoad(ctx context.Context, in *UpdateNSELoadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/registry.NetworkServiceRegistry/UpdateNSELoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceRegistryServer is the server API for NetworkServiceRegistry service.
 type NetworkServiceRegistryServer interface {
 	RegisterNSE(context.Context, *NSERegistration) (*NSERegistration, error)
 	RemoveNSE(context.Context, *RemoveNSERequest) (*empty.Empty, error)
+	UpdateNSELoad(context.Context, *UpdateNSELoadRequest) (*empty.Empty, error)
 }
 
 func RegisterNetworkServiceRegistryServer(s *grpc.Server, srv NetworkServiceRegistryServer) {
@@ -693,6 +835,24 @@ func _NetworkServiceRegistry_RemoveNSE_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServiceRegistry_UpdateNSELoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNSELoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceRegistryServer).UpdateNSELoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.NetworkServiceRegistry/UpdateNSELoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceRegistryServer).UpdateNSELoad(ctx, req.(*UpdateNSELoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "registry.NetworkServiceRegistry",
 	HandlerType: (*NetworkServiceRegistryServer)(nil),
@@ -705,6 +865,10 @@ var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveNSE",
 			Handler:    _NetworkServiceRegistry_RemoveNSE_Handler,
 		},
+		{
+			MethodName: "UpdateNSELoad",
+			Handler:    _NetworkServiceRegistry_UpdateNSELoad_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "registry.proto",
@@ -935,64 +1099,73 @@ var _ClusterInfo_serviceDesc = grpc.ServiceDesc{
 	Metadata: "registry.proto",
 }
 
-func init() { proto.RegisterFile("registry.proto", fileDescriptor_registry_a4b6557fc191ae86) }
-
-var fileDescriptor_registry_a4b6557fc191ae86 = []byte{
-	// 890 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0x96, 0x93, 0x36, 0xbb, 0x3d, 0xde, 0xfe, 0x68, 0xb6, 0x4d, 0x5d, 0xb3, 0x82, 0x2a, 0xdd,
-	0x8b, 0x45, 0x02, 0x17, 0x65, 0x85, 0x96, 0x9f, 0x8b, 0x65, 0xd5, 0x66, 0x57, 0x48, 0x6d, 0x10,
-	0x0e, 0x12, 0x02, 0x21, 0x05, 0x27, 0x39, 0x75, 0xcd, 0xda, 0x33, 0xc6, 0x33, 0xee, 0xca, 0x7d,
-	0x02, 0x2e, 0x78, 0x06, 0x9e, 0x80, 0x77, 0xe0, 0x92, 0x5b, 0x5e, 0x81, 0x37, 0x41, 0x19, 0x8f,
-	0xe3, 0x9f, 0xd8, 0x4d, 0x23, 0x6e, 0xac, 0x99, 0x33, 0xdf, 0xf9, 0xce, 0xff, 0x91, 0x61, 0x27,
-	0x42, 0xd7, 0xe3, 0x22, 0x4a, 0xac, 0x30, 0x62, 0x82, 0x91, 0x87, 0xd9, 0xdd, 0x7c, 0xee, 0x7a,
-	0xe2, 0x3a, 0x9e, 0x58, 0x53, 0x16, 0x9c, 0xba, 0xcc, 0x77, 0xa8, 0x7b, 0x2a, 0x21, 0x93, 0xf8,
-	0xea, 0x34, 0x14, 0x49, 0x88, 0xfc, 0x14, 0x83, 0x50, 0x24, 0xe9, 0x37, 0x55, 0x37, 0xbf, 0x5c,
-	0xad, 0x24, 0xbc, 0x00, 0xb9, 0x70, 0x82, 0x30, 0x3f, 0xa5, 0xca, 0xbd, 0x7f, 0x5b, 0xd0, 0x1d,
-	0xa2, 0x78, 0xc7, 0xa2, 0xb7, 0x23, 0x8c, 0x6e, 0xbc, 0x29, 0x0e, 0xe8, 0x2c, 0x64, 0x1e, 0x15,
-	0xe4, 0x13, 0xd8, 0xa7, 0xe9, 0xcb, 0x98, 0xa7, 0x4f, 0x63, 0xea, 0x04, 0x68, 0x68, 0xc7, 0xda,
-	0xb3, 0x2d, 0x9b, 0xd0, 0x92, 0xd6, 0xd0, 0x09, 0x90, 0x18, 0xf0, 0x20, 0x74, 0x12, 0x9f, 0x39,
-	0x33, 0xa3, 0x25, 0x41, 0xd9, 0x95, 0xbc, 0x84, 0x27, 0x55, 0xae, 0xc0, 0xa1, 0x8e, 0x8b, 0x51,
-	0xca, 0xd9, 0x96, 0xf0, 0xa3, 0x32, 0xe7, 0x65, 0x8a, 0x90, 0xd4, 0x27, 0xb0, 0x8d, 0xca, 0xb1,
-	0x54, 0x63, 0x43, 0x6a, 0x3c, 0xca, 0x84, 0x12, 0x74, 0x0e, 0x1d, 0xdf, 0x99, 0xa0, 0xcf, 0x8d,
-	0xcd, 0xe3, 0xf6, 0x33, 0xbd, 0xff, 0x91, 0xb5, 0xc8, 0x74, 0x7d, 0x8c, 0xd6, 0x85, 0x84, 0x0f,
-	0xa8, 0x88, 0x12, 0x5b, 0xe9, 0x92, 0x7d, 0xd8, 0xe4, 0xc2, 0x11, 0x68, 0x74, 0xa4, 0x89, 0xf4,
-	0x62, 0x7e, 0x0e, 0x7a, 0x01, 0x4c, 0xf6, 0xa0, 0xfd, 0x16, 0x13, 0x95, 0x8b, 0xf9, 0x71, 0xae,
-	0x76, 0xe3, 0xf8, 0x31, 0xaa, 0xd0, 0xd3, 0xcb, 0x17, 0xad, 0xcf, 0xb4, 0x9e, 0x07, 0x3b, 0x65,
-	0xf3, 0x84, 0xc0, 0x46, 0x21, 0x95, 0xf2, 0x7c, 0x47, 0xf2, 0x3e, 0x84, 0x07, 0x81, 0x23, 0xa6,
-	0xd7, 0xc8, 0x8d, 0xb6, 0x8c, 0x6b, 0x37, 0x8f, 0xeb, 0x72, 0xfe, 0x60, 0x67, 0xef, 0xbd, 0xbf,
-	0x35, 0xd8, 0x94, 0x22, 0x72, 0x01, 0xbb, 0x9c, 0xc5, 0xd1, 0x14, 0xc7, 0x1c, 0x7d, 0x9c, 0x0a,
-	0x16, 0x19, 0x9a, 0x54, 0x3e, 0xa9, 0x28, 0x5b, 0x23, 0x09, 0x1b, 0x29, 0x54, 0x9a, 0x8b, 0x1d,
-	0x5e, 0x12, 0x92, 0x8f, 0xa1, 0x13, 0xb1, 0x58, 0x20, 0x37, 0x5a, 0x92, 0xe4, 0x20, 0x27, 0x39,
-	0x47, 0x2e, 0x3c, 0xea, 0x08, 0x8f, 0x51, 0x5b, 0x81, 0xcc, 0x57, 0xf0, 0xb8, 0x86, 0x75, 0xad,
-	0xa4, 0xfd, 0xa3, 0x81, 0x5e, 0xa0, 0x26, 0x0e, 0xec, 0xcf, 0xf2, 0x6b, 0x35, 0x28, 0xab, 0xd6,
-	0x9f, 0xe2, 0xb9, 0x1c, 0xdf, 0xe3, 0xd9, 0xf2, 0x0b, 0xe9, 0x42, 0xe7, 0x1d, 0x7a, 0xee, 0xb5,
-	0x90, 0xde, 0x6c, 0xdb, 0xea, 0x66, 0xbe, 0x06, 0xa3, 0x89, 0x68, 0xad, 0x90, 0x7e, 0xd7, 0xe0,
-	0x60, 0x58, 0xd7, 0xe1, 0xb5, 0xfd, 0xb0, 0x07, 0xed, 0x38, 0xf2, 0x15, 0xcb, 0xfc, 0x48, 0x5e,
-	0xc0, 0x96, 0xef, 0x70, 0x31, 0xe6, 0x88, 0x54, 0x4e, 0x8c, 0xde, 0x37, 0x2d, 0x97, 0x31, 0xd7,
-	0x47, 0x2b, 0x9b, 0x78, 0xeb, 0xbb, 0x6c, 0xc0, 0xed, 0x87, 0x73, 0xf0, 0x08, 0x91, 0xe6, 0x1d,
-	0xbd, 0x51, 0xe8, 0xe8, 0xde, 0x0b, 0xd8, 0xb3, 0x31, 0x60, 0x37, 0x38, 0x1c, 0x0d, 0x6c, 0xfc,
-	0x35, 0x46, 0x2e, 0x96, 0xc7, 0x4c, 0x5b, 0x1e, 0xb3, 0xde, 0x25, 0x1c, 0xbd, 0xf6, 0xe8, 0xac,
-	0x1c, 0x4a, 0xc6, 0xb0, 0xf6, 0xd6, 0xe8, 0xfd, 0xd5, 0x06, 0xb3, 0x8e, 0x8f, 0x87, 0x8c, 0xf2,
-	0xd2, 0x5c, 0x68, 0xe5, 0xb9, 0x78, 0x05, 0xbb, 0x15, 0x53, 0x32, 0x5b, 0x7a, 0xdf, 0x68, 0x9a,
-	0x7b, 0x7b, 0xa7, 0x6c, 0x9f, 0xdc, 0x82, 0xd1, 0xb0, 0x97, 0xb2, 0x59, 0xfb, 0x2a, 0xe7, 0x6a,
-	0x76, 0xd2, 0xaa, 0x2d, 0xab, 0xda, 0x2b, 0xdd, 0xda, 0xad, 0xc6, 0xc9, 0x4f, 0x70, 0x54, 0xb5,
-	0x9d, 0xa5, 0x99, 0x1b, 0x1b, 0xd2, 0xf8, 0xf1, 0xaa, 0x05, 0x66, 0x1f, 0xd2, 0x5a, 0x39, 0x37,
-	0x7f, 0x81, 0xf7, 0xee, 0x70, 0xaa, 0xa6, 0x6f, 0x3f, 0x2d, 0xf6, 0xad, 0xde, 0xff, 0xa0, 0xc9,
-	0xb4, 0xe2, 0x29, 0x36, 0xf6, 0x6f, 0x2d, 0xd8, 0x95, 0x4d, 0x24, 0x15, 0xd2, 0x79, 0xad, 0x29,
-	0x8e, 0xb6, 0x66, 0x71, 0xbe, 0x87, 0xc3, 0x86, 0xe2, 0xdc, 0xd7, 0xc7, 0x83, 0xda, 0xd4, 0x93,
-	0x1f, 0x16, 0xc4, 0xd5, 0xc4, 0xab, 0xb1, 0x5a, 0x9d, 0xf7, 0x6e, 0x99, 0x20, 0x93, 0xf7, 0x6e,
-	0xc1, 0xac, 0xd7, 0xb8, 0xf0, 0xb8, 0xb8, 0xbb, 0xe4, 0xda, 0xff, 0x2c, 0x79, 0xef, 0x47, 0xd8,
-	0x3f, 0xf3, 0x63, 0x2e, 0x30, 0x3a, 0x63, 0xf4, 0xca, 0x73, 0x63, 0x55, 0x8a, 0x27, 0xb0, 0x15,
-	0xb2, 0xd9, 0x28, 0x9e, 0x50, 0x14, 0xaa, 0xe2, 0xb9, 0x80, 0x3c, 0x85, 0x6d, 0xe5, 0x8b, 0x42,
-	0xa4, 0x1b, 0xa7, 0x2c, 0xec, 0xff, 0xa1, 0x55, 0xff, 0x13, 0x54, 0xb5, 0x13, 0x72, 0x06, 0x7a,
-	0x7a, 0xc6, 0x68, 0x38, 0x1a, 0x90, 0xa3, 0x42, 0x00, 0xe5, 0x9e, 0x30, 0x9b, 0x9f, 0xc8, 0x4b,
-	0xd8, 0x5a, 0x2c, 0x23, 0x62, 0xe6, 0xb8, 0xea, 0x86, 0x32, 0xbb, 0x4b, 0x1b, 0x6f, 0x30, 0xff,
-	0x17, 0xea, 0xdf, 0xc2, 0x61, 0xd9, 0xbf, 0x73, 0x8f, 0x4f, 0xd9, 0x0d, 0x46, 0x09, 0x19, 0x03,
-	0x59, 0x1e, 0x5d, 0x72, 0x72, 0xf7, 0x60, 0xa7, 0xd6, 0x9e, 0xde, 0x67, 0xfa, 0xfb, 0x7f, 0x6a,
-	0xa0, 0x0f, 0x79, 0xb0, 0xc8, 0xc8, 0x37, 0xc5, 0x8c, 0x5c, 0x92, 0x55, 0x6d, 0x6a, 0xae, 0x02,
-	0x90, 0x0b, 0x78, 0xf4, 0x06, 0xc5, 0xa2, 0xd2, 0xa4, 0x21, 0x09, 0x45, 0x77, 0x9b, 0xbb, 0xb0,
-	0xff, 0x33, 0xe8, 0xaa, 0x4f, 0xbe, 0xa6, 0x57, 0x8c, 0x7c, 0x0b, 0x87, 0x6f, 0x50, 0xd4, 0x76,
-	0x4e, 0x93, 0x9d, 0xf7, 0x73, 0x3b, 0x75, 0x7a, 0x93, 0x8e, 0xc4, 0x3f, 0xff, 0x2f, 0x00, 0x00,
-	0xff, 0xff, 0xaf, 0xf5, 0x56, 0x3a, 0xea, 0x0a, 0x00, 0x00,
+func init() { proto.RegisterFile("registry.proto", fileDescriptor_registry_3bf1fc2086b2be35) }
+
+var fileDescriptor_registry_3bf1fc2086b2be35 = []byte{
+	// 1033 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcf, 0x6e, 0xe3, 0x44,
+	0x18, 0x97, 0x93, 0xb4, 0x4d, 0xbf, 0x6c, 0xda, 0x6a, 0x36, 0x4d, 0x5d, 0xb3, 0x82, 0xca, 0xad,
+	0x44, 0x91, 0xc0, 0x45, 0x59, 0xc1, 0x02, 0x7b, 0x58, 0xaa, 0x36, 0x5b, 0x40, 0x49, 0x56, 0x38,
+	0x20, 0x04, 0x42, 0x0a, 0x93, 0x64, 0x9a, 0x9a, 0xb5, 0x67, 0x8c, 0x67, 0xdc, 0x55, 0xfa, 0x04,
+	0x1c, 0xb8, 0x71, 0xe3, 0x01, 0x38, 0xf1, 0x0e, 0x1c, 0xb9, 0xf2, 0x16, 0xbc, 0x06, 0xca, 0x78,
+	0x1c, 0xdb, 0x89, 0xdd, 0x6c, 0xb4, 0x97, 0x68, 0xe6, 0x9b, 0xdf, 0xf7, 0x9b, 0xef, 0xdf, 0xfc,
+	0x1c, 0xd8, 0x09, 0xc8, 0xc4, 0xe1, 0x22, 0x98, 0x5a, 0x7e, 0xc0, 0x04, 0x43, 0xd5, 0x78, 0x6f,
+	0x3c, 0x9e, 0x38, 0xe2, 0x26, 0x1c, 0x5a, 0x23, 0xe6, 0x9d, 0x4d, 0x98, 0x8b, 0xe9, 0xe4, 0x4c,
+	0x42, 0x86, 0xe1, 0xf5, 0x99, 0x2f, 0xa6, 0x3e, 0xe1, 0x67, 0xc4, 0xf3, 0xc5, 0x34, 0xfa, 0x8d,
+	0xdc, 0x8d, 0xa7, 0xab, 0x9d, 0x84, 0xe3, 0x11, 0x2e, 0xb0, 0xe7, 0x27, 0xab, 0xc8, 0xd9, 0xfc,
+	0xa3, 0x02, 0xcd, 0x1e, 0x11, 0xaf, 0x58, 0xf0, 0xb2, 0x4f, 0x82, 0x5b, 0x67, 0x44, 0xda, 0x74,
+	0xec, 0x33, 0x87, 0x0a, 0xf4, 0x21, 0x34, 0x68, 0x74, 0x32, 0xe0, 0xd1, 0xd1, 0x80, 0x62, 0x8f,
+	0xe8, 0xda, 0x91, 0x76, 0xba, 0x6d, 0x23, 0x9a, 0xf1, 0xea, 0x61, 0x8f, 0x20, 0x1d, 0xb6, 0x7c,
+	0x3c, 0x75, 0x19, 0x1e, 0xeb, 0x25, 0x09, 0x8a, 0xb7, 0xe8, 0x19, 0x3c, 0x5a, 0xe4, 0xf2, 0x30,
+	0xc5, 0x13, 0x12, 0x44, 0x9c, 0x65, 0x09, 0x3f, 0xcc, 0x72, 0x76, 0x23, 0x84, 0xa4, 0x3e, 0x86,
+	0x3a, 0x51, 0x81, 0x45, 0x1e, 0x15, 0xe9, 0xf1, 0x20, 0x36, 0x4a, 0xd0, 0x25, 0x6c, 0xba, 0x78,
+	0x48, 0x5c, 0xae, 0x6f, 0x1c, 0x95, 0x4f, 0x6b, 0xad, 0xf7, 0xad, 0x79, 0xa5, 0xf3, 0x73, 0xb4,
+	0x3a, 0x12, 0xde, 0xa6, 0x22, 0x98, 0xda, 0xca, 0x17, 0x35, 0x60, 0x83, 0x0b, 0x2c, 0x88, 0xbe,
+	0x29, 0xaf, 0x88, 0x36, 0xc8, 0x80, 0xea, 0x08, 0xfb, 0x78, 0xe4, 0x88, 0xa9, 0xbe, 0x75, 0xa4,
+	0x9d, 0xd6, 0xed, 0xf9, 0x1e, 0x21, 0xa8, 0xc8, 0xa4, 0xab, 0xd2, 0x2e, 0xd7, 0xe8, 0x2b, 0xa8,
+	0x7a, 0x44, 0xe0, 0x31, 0x16, 0x58, 0xdf, 0x96, 0xd1, 0x58, 0x2b, 0xa3, 0xe9, 0x2a, 0x87, 0x28,
+	0x9e, 0xb9, 0xbf, 0xf1, 0x29, 0xd4, 0x52, 0x81, 0xa2, 0x3d, 0x28, 0xbf, 0x24, 0x53, 0xd5, 0x87,
+	0xd9, 0x72, 0x16, 0xf2, 0x2d, 0x76, 0x43, 0xa2, 0xca, 0x1e, 0x6d, 0x3e, 0x2b, 0x7d, 0xa2, 0x19,
+	0x4f, 0xa1, 0x9e, 0x61, 0x5d, 0xc7, 0xd9, 0xfc, 0x5d, 0x83, 0x9d, 0x6c, 0xa8, 0xb3, 0x54, 0x53,
+	0x43, 0x50, 0xa1, 0xf7, 0xb7, 0xfd, 0x3d, 0xd8, 0xf2, 0xb0, 0x18, 0xdd, 0x10, 0xae, 0x97, 0x65,
+	0x0d, 0x76, 0x93, 0x1a, 0x74, 0x67, 0x07, 0x76, 0x7c, 0x8e, 0x4e, 0x61, 0x63, 0x74, 0x83, 0x1d,
+	0xaa, 0x57, 0x24, 0x10, 0x25, 0xc0, 0x8b, 0x99, 0xf9, 0x0b, 0xe6, 0xdb, 0x11, 0xc0, 0xfc, 0x53,
+	0x83, 0x6a, 0x6c, 0x43, 0xef, 0xc2, 0xee, 0xc2, 0x60, 0xa9, 0xd0, 0x76, 0xb2, 0xb3, 0x84, 0x3e,
+	0x9e, 0xcf, 0x46, 0x49, 0x5e, 0xf0, 0xf6, 0xf2, 0x05, 0x79, 0xd3, 0xf0, 0x06, 0xb5, 0x37, 0xff,
+	0xd1, 0x60, 0x43, 0x66, 0x89, 0x3a, 0xb0, 0xcb, 0x59, 0x18, 0x8c, 0xc8, 0x80, 0x13, 0x97, 0x8c,
+	0x04, 0x0b, 0x74, 0x4d, 0x46, 0x71, 0xbc, 0x50, 0x0f, 0xab, 0x2f, 0x61, 0x7d, 0x85, 0x8a, 0x42,
+	0xd9, 0xe1, 0x19, 0x23, 0xfa, 0x00, 0x36, 0x03, 0x16, 0x0a, 0x12, 0xa7, 0xb2, 0x9f, 0x90, 0x5c,
+	0x12, 0x2e, 0x1c, 0x8a, 0x85, 0xc3, 0xa8, 0xad, 0x40, 0xc6, 0x39, 0x3c, 0xcc, 0x61, 0x5d, 0x2b,
+	0x93, 0x7f, 0x35, 0xa8, 0xa5, 0xa8, 0x11, 0x86, 0xc6, 0x38, 0xd9, 0x2e, 0x26, 0x65, 0xe5, 0xc6,
+	0x93, 0x5e, 0x67, 0xf3, 0x7b, 0x38, 0x5e, 0x3e, 0x41, 0x4d, 0xd8, 0x7c, 0x45, 0x9c, 0xc9, 0x8d,
+	0x90, 0xd1, 0xd4, 0x6d, 0xb5, 0x33, 0x9e, 0x83, 0x5e, 0x44, 0xb4, 0x56, 0x4a, 0xbf, 0x69, 0xb0,
+	0xdf, 0xcb, 0x93, 0x9b, 0xdc, 0x11, 0xdf, 0x83, 0x72, 0x18, 0xb8, 0x8a, 0x65, 0xb6, 0x44, 0x4f,
+	0x60, 0xdb, 0xc5, 0x5c, 0x0c, 0x38, 0x21, 0x54, 0xca, 0x57, 0xad, 0x65, 0x58, 0x13, 0xc6, 0x26,
+	0x2e, 0xb1, 0x62, 0xf9, 0xb5, 0xbe, 0x89, 0xd5, 0xd6, 0xae, 0xce, 0xc0, 0x7d, 0x42, 0x68, 0x22,
+	0x2f, 0x95, 0x94, 0xbc, 0x98, 0x4f, 0x60, 0xcf, 0x26, 0x1e, 0xbb, 0x25, 0xbd, 0x7e, 0xdb, 0x26,
+	0xbf, 0x84, 0x84, 0x8b, 0x65, 0xcd, 0xd3, 0x96, 0x35, 0xcf, 0x7c, 0x01, 0x8d, 0x6f, 0xfd, 0x31,
+	0x16, 0x33, 0xc7, 0x0e, 0xc3, 0xe3, 0x75, 0x9c, 0xe7, 0xc2, 0x55, 0x4a, 0x84, 0xcb, 0xec, 0xc2,
+	0xe1, 0x73, 0x87, 0x8e, 0xb3, 0xb5, 0x89, 0x59, 0xd7, 0xfe, 0x26, 0x98, 0x7f, 0x97, 0xc1, 0xc8,
+	0xe3, 0xe3, 0x3e, 0xa3, 0x3c, 0xa3, 0x1d, 0x5a, 0x56, 0x3b, 0xce, 0x97, 0x5f, 0x76, 0x49, 0x96,
+	0x59, 0x2f, 0xd2, 0xd1, 0xa5, 0x37, 0x7f, 0x07, 0x7a, 0xc1, 0x57, 0x27, 0xd6, 0xa3, 0xcf, 0x13,
+	0xae, 0xe2, 0x20, 0xad, 0xdc, 0x39, 0x51, 0x3a, 0xd1, 0xcc, 0xfd, 0x66, 0x71, 0xf4, 0x23, 0x1c,
+	0x2e, 0xde, 0x1d, 0x97, 0x9e, 0x2b, 0x8d, 0x3b, 0x5a, 0xf5, 0x41, 0xb0, 0x0f, 0x68, 0xae, 0x9d,
+	0x1b, 0x3f, 0xc3, 0x5b, 0xf7, 0x04, 0x95, 0xf3, 0x10, 0x3e, 0x4a, 0x3f, 0x84, 0x5a, 0xeb, 0x9d,
+	0xa2, 0xab, 0x15, 0x4f, 0xfa, 0xa5, 0xfc, 0x5a, 0x82, 0x5d, 0x39, 0x95, 0xd2, 0x21, 0x12, 0x80,
+	0xf3, 0x7c, 0xd9, 0x5d, 0xa7, 0x39, 0xdf, 0xc1, 0x41, 0x41, 0x73, 0x5e, 0x37, 0xc6, 0xfd, 0xdc,
+	0xd2, 0xa3, 0xef, 0xe7, 0xc4, 0x8b, 0x85, 0x57, 0xef, 0x74, 0x75, 0xdd, 0x9b, 0x59, 0x82, 0xd8,
+	0x6e, 0xde, 0x81, 0x91, 0xef, 0xd1, 0x71, 0xb8, 0xb8, 0xbf, 0xe5, 0xda, 0x1b, 0xb6, 0xdc, 0xfc,
+	0x01, 0x1a, 0x17, 0x6e, 0xc8, 0x05, 0x09, 0x2e, 0x18, 0xbd, 0x76, 0x26, 0xa1, 0x6a, 0xc5, 0x23,
+	0xd8, 0xf6, 0xd9, 0xb8, 0x1f, 0x0e, 0x29, 0x11, 0xaa, 0xe3, 0x89, 0x01, 0x9d, 0x40, 0x5d, 0xc5,
+	0xa2, 0x10, 0x91, 0x84, 0x65, 0x8d, 0xad, 0xff, 0xb4, 0xc5, 0x7f, 0x81, 0xaa, 0xdb, 0x53, 0x74,
+	0x01, 0xb5, 0x68, 0x4d, 0x82, 0x5e, 0xbf, 0x8d, 0x0e, 0x53, 0x09, 0x64, 0x67, 0xc2, 0x28, 0x3e,
+	0x42, 0xcf, 0x60, 0x7b, 0xae, 0x6e, 0xc8, 0x48, 0x70, 0x8b, 0x92, 0x67, 0x34, 0x97, 0x24, 0xb4,
+	0x3d, 0xfb, 0xa7, 0x8b, 0xae, 0xa0, 0x9e, 0x51, 0x39, 0x94, 0xfa, 0x7c, 0xe7, 0xc9, 0x5f, 0x11,
+	0x51, 0xeb, 0x0e, 0x0e, 0xb2, 0x89, 0x5e, 0x3a, 0x7c, 0xc4, 0x6e, 0x49, 0x30, 0x45, 0x03, 0x40,
+	0xcb, 0x1a, 0x80, 0x8e, 0xef, 0x57, 0x88, 0xe8, 0xb6, 0x93, 0xd7, 0x91, 0x91, 0xd6, 0x5f, 0x1a,
+	0xd4, 0x7a, 0xdc, 0x9b, 0x97, 0xf6, 0x45, 0xba, 0xb4, 0x5d, 0xb4, 0x6a, 0xde, 0x8d, 0x55, 0x00,
+	0xd4, 0x81, 0x07, 0x57, 0x44, 0xcc, 0x47, 0x06, 0x15, 0x14, 0xc1, 0x38, 0x29, 0x22, 0x4a, 0x8f,
+	0x73, 0xeb, 0x27, 0xa8, 0xa9, 0x81, 0xfb, 0x92, 0x5e, 0x33, 0xf4, 0x35, 0x1c, 0x5c, 0x11, 0x91,
+	0x3b, 0x82, 0x45, 0xf7, 0xa4, 0xff, 0x63, 0xe5, 0xf8, 0x0d, 0x37, 0x25, 0xfe, 0xf1, 0xff, 0x03,
+	0x00, 0x48, 0x5c, 0xdd, 0x52, 0x11, 0x0d, 0x00, 0x00,
 }
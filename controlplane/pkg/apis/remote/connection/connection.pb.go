@@ -62,7 +62,7 @@ func (x MechanismType) String() string {
 	return proto.EnumName(MechanismType_name, int32(x))
 }
 func (MechanismType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{0}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{0}
 }
 
 type State int32
@@ -85,7 +85,7 @@ func (x State) String() string {
 	return proto.EnumName(State_name, int32(x))
 }
 func (State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{1}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{1}
 }
 
 type ConnectionEventType int32
@@ -111,7 +111,7 @@ func (x ConnectionEventType) String() string {
 	return proto.EnumName(ConnectionEventType_name, int32(x))
 }
 func (ConnectionEventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{2}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{2}
 }
 
 type Mechanism struct {
@@ -126,7 +126,7 @@ func (m *Mechanism) Reset()         { *m = Mechanism{} }
 func (m *Mechanism) String() string { return proto.CompactTextString(m) }
 func (*Mechanism) ProtoMessage()    {}
 func (*Mechanism) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{0}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{0}
 }
 func (m *Mechanism) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Mechanism.Unmarshal(m, b)
@@ -179,7 +179,7 @@ func (m *Connection) Reset()         { *m = Connection{} }
 func (m *Connection) String() string { return proto.CompactTextString(m) }
 func (*Connection) ProtoMessage()    {}
 func (*Connection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{1}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{1}
 }
 func (m *Connection) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Connection.Unmarshal(m, b)
@@ -263,18 +263,22 @@ func (m *Connection) GetState() State {
 }
 
 type ConnectionEvent struct {
-	Type                 ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=remote.connection.ConnectionEventType" json:"type,omitempty"`
-	Connections          map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Type        ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=remote.connection.ConnectionEventType" json:"type,omitempty"`
+	Connections map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// sequence number of the event, INITIAL_STATE_TRANSFER has the sequence number of the last event included into it
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// id of the monitor server instance, sequence numbers of different instances are not comparable
+	ServerId             string   `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ConnectionEvent) Reset()         { *m = ConnectionEvent{} }
 func (m *ConnectionEvent) String() string { return proto.CompactTextString(m) }
 func (*ConnectionEvent) ProtoMessage()    {}
 func (*ConnectionEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{2}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{2}
 }
 func (m *ConnectionEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnectionEvent.Unmarshal(m, b)
@@ -308,18 +312,44 @@ func (m *ConnectionEvent) GetConnections() map[string]*Connection {
 	return nil
 }
 
+func (m *ConnectionEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ConnectionEvent) GetServerId() string {
+	if m != nil {
+		return m.ServerId
+	}
+	return ""
+}
+
 type MonitorScopeSelector struct {
-	NetworkServiceManagerName string   `protobuf:"bytes,1,opt,name=network_service_manager_name,json=networkServiceManagerName,proto3" json:"network_service_manager_name,omitempty"`
-	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
-	XXX_unrecognized          []byte   `json:"-"`
-	XXX_sizecache             int32    `json:"-"`
+	// connections having the manager as a source or a destination, the name should be set to match any connection
+	NetworkServiceManagerName string `protobuf:"bytes,1,opt,name=network_service_manager_name,json=networkServiceManagerName,proto3" json:"network_service_manager_name,omitempty"`
+	// sequence number of the last event received before reconnect, 0 requests the full state transfer
+	ResumeFromSequence uint64 `protobuf:"varint,2,opt,name=resume_from_sequence,json=resumeFromSequence,proto3" json:"resume_from_sequence,omitempty"`
+	// server id of the last event received before reconnect, the full state is transferred if it does not match
+	ResumeFromServerId string `protobuf:"bytes,8,opt,name=resume_from_server_id,json=resumeFromServerId,proto3" json:"resume_from_server_id,omitempty"`
+	// Selectors, the empty selector matches any connection
+	NetworkService             string   `protobuf:"bytes,3,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	ConnectionIds              []string `protobuf:"bytes,4,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	NetworkServiceEndpointName string   `protobuf:"bytes,5,opt,name=network_service_endpoint_name,json=networkServiceEndpointName,proto3" json:"network_service_endpoint_name,omitempty"`
+	// connection labels should contain all of the selector labels
+	Labels               map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	States               []State           `protobuf:"varint,7,rep,packed,name=states,proto3,enum=remote.connection.State" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *MonitorScopeSelector) Reset()         { *m = MonitorScopeSelector{} }
 func (m *MonitorScopeSelector) String() string { return proto.CompactTextString(m) }
 func (*MonitorScopeSelector) ProtoMessage()    {}
 func (*MonitorScopeSelector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_connection_5aea164b3f5efe0c, []int{3}
+	return fileDescriptor_connection_726a4ebd31f2552f, []int{3}
 }
 func (m *MonitorScopeSelector) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MonitorScopeSelector.Unmarshal(m, b)
@@ -346,6 +376,55 @@ func (m *MonitorScopeSelector) GetNetworkServiceManagerName() string {
 	return ""
 }
 
+func (m *MonitorScopeSelector) GetResumeFromSequence() uint64 {
+	if m != nil {
+		return m.ResumeFromSequence
+	}
+	return 0
+}
+
+func (m *MonitorScopeSelector) GetResumeFromServerId() string {
+	if m != nil {
+		return m.ResumeFromServerId
+	}
+	return ""
+}
+
+func (m *MonitorScopeSelector) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *MonitorScopeSelector) GetConnectionIds() []string {
+	if m != nil {
+		return m.ConnectionIds
+	}
+	return nil
+}
+
+func (m *MonitorScopeSelector) GetNetworkServiceEndpointName() string {
+	if m != nil {
+		return m.NetworkServiceEndpointName
+	}
+	return ""
+}
+
+func (m *MonitorScopeSelector) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MonitorScopeSelector) GetStates() []State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Mechanism)(nil), "remote.connection.Mechanism")
 	proto.RegisterMapType((map[string]string)(nil), "remote.connection.Mechanism.ParametersEntry")
@@ -354,6 +433,7 @@ func init() {
 	proto.RegisterType((*ConnectionEvent)(nil), "remote.connection.ConnectionEvent")
 	proto.RegisterMapType((map[string]*Connection)(nil), "remote.connection.ConnectionEvent.ConnectionsEntry")
 	proto.RegisterType((*MonitorScopeSelector)(nil), "remote.connection.MonitorScopeSelector")
+	proto.RegisterMapType((map[string]string)(nil), "remote.connection.MonitorScopeSelector.LabelsEntry")
 	proto.RegisterEnum("remote.connection.MechanismType", MechanismType_name, MechanismType_value)
 	proto.RegisterEnum("remote.connection.State", State_name, State_value)
 	proto.RegisterEnum("remote.connection.ConnectionEventType", ConnectionEventType_name, ConnectionEventType_value)
@@ -458,53 +538,61 @@ var _MonitorConnection_serviceDesc = grpc.ServiceDesc{
 	Metadata: "connection.proto",
 }
 
-func init() { proto.RegisterFile("connection.proto", fileDescriptor_connection_5aea164b3f5efe0c) }
-
-var fileDescriptor_connection_5aea164b3f5efe0c = []byte{
-	// 716 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x6d, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0xe4, 0xb7, 0x78, 0x9c, 0x97, 0xcd, 0x5e, 0x38, 0x14, 0x93, 0x70, 0x26, 0x17, 0x2e,
-	0xbe, 0x70, 0xc8, 0x87, 0x73, 0x1c, 0x77, 0x81, 0xb6, 0xb8, 0xb1, 0x1a, 0x0c, 0xb6, 0x62, 0x24,
-	0x3b, 0x29, 0x85, 0x62, 0x14, 0x79, 0x89, 0x45, 0xac, 0x5d, 0x21, 0xad, 0xd3, 0xf8, 0x73, 0xff,
-	0x5f, 0x3f, 0xf7, 0xe7, 0x14, 0xad, 0x14, 0x4b, 0x7e, 0x89, 0x43, 0xbf, 0xed, 0xce, 0x3c, 0xf3,
-	0xcc, 0xcc, 0xb3, 0xb3, 0x03, 0xc8, 0x66, 0x94, 0x12, 0x9b, 0x3b, 0x8c, 0xaa, 0x9e, 0xcf, 0x38,
-	0xc3, 0x7b, 0x3e, 0x71, 0x19, 0x27, 0x6a, 0xe2, 0x28, 0x7b, 0xf7, 0x0e, 0x1f, 0x4d, 0xee, 0x54,
-	0x9b, 0xb9, 0x35, 0x4a, 0xf8, 0x17, 0xe6, 0x3f, 0x04, 0xc4, 0x7f, 0x74, 0x6c, 0xe2, 0x92, 0x60,
-	0xb4, 0xca, 0x64, 0x33, 0xca, 0x7d, 0x36, 0xf6, 0xc6, 0x16, 0x25, 0x35, 0xef, 0xe1, 0xbe, 0x66,
-	0x79, 0x4e, 0x50, 0x4b, 0x28, 0x43, 0x3f, 0x79, 0xe2, 0xcb, 0x96, 0xa8, 0x88, 0xe3, 0x6f, 0x12,
-	0x14, 0x3b, 0xc4, 0x1e, 0x59, 0xd4, 0x09, 0x5c, 0xfc, 0x0f, 0x64, 0xf9, 0xd4, 0x23, 0x8a, 0x54,
-	0x91, 0xaa, 0x3b, 0xf5, 0x8a, 0xba, 0x54, 0xa1, 0x3a, 0xc3, 0xf6, 0xa6, 0x1e, 0x31, 0x04, 0x1a,
-	0xb7, 0x01, 0x3c, 0xcb, 0xb7, 0x5c, 0xc2, 0x89, 0x1f, 0x28, 0x72, 0x25, 0x53, 0x2d, 0xd5, 0xff,
-	0x5a, 0x17, 0xab, 0x76, 0x67, 0x70, 0x8d, 0x72, 0x7f, 0x6a, 0xa4, 0xe2, 0xcb, 0x6f, 0x60, 0x77,
-	0xc1, 0x8d, 0x11, 0x64, 0x1e, 0xc8, 0x54, 0x54, 0x55, 0x34, 0xc2, 0x23, 0xde, 0x87, 0xdc, 0xa3,
-	0x35, 0x9e, 0x10, 0x45, 0x16, 0xb6, 0xe8, 0x72, 0x21, 0xff, 0x27, 0x1d, 0x7f, 0xcf, 0x02, 0x5c,
-	0xce, 0x72, 0xe2, 0x1d, 0x90, 0x9d, 0x61, 0x1c, 0x29, 0x3b, 0x43, 0x7c, 0x0a, 0xbb, 0xb1, 0x8a,
-	0x83, 0x58, 0xc6, 0x98, 0x62, 0x27, 0x36, 0x9b, 0x91, 0x15, 0x5f, 0x40, 0xd1, 0x7d, 0xae, 0x57,
-	0xc9, 0x54, 0xa4, 0x6a, 0xa9, 0x7e, 0xb8, 0xae, 0x27, 0x23, 0x81, 0xe3, 0xb7, 0x50, 0x88, 0x55,
-	0x56, 0xb2, 0x22, 0xf2, 0x44, 0x5d, 0xd6, 0x3f, 0x29, 0xf2, 0x32, 0xb2, 0x18, 0xcf, 0x41, 0xb8,
-	0x01, 0xf9, 0xb1, 0x75, 0x47, 0xc6, 0x81, 0x92, 0x13, 0x62, 0xfe, 0xb9, 0x22, 0x71, 0x12, 0xae,
-	0xb6, 0x05, 0x36, 0x52, 0x32, 0x0e, 0xc4, 0x6d, 0xf8, 0x3d, 0x60, 0x13, 0xdf, 0x26, 0x83, 0x85,
-	0x76, 0x07, 0xae, 0x45, 0xad, 0x7b, 0xe2, 0x0f, 0xa8, 0xe5, 0x12, 0x25, 0x2f, 0x7a, 0xff, 0x2d,
-	0x82, 0xea, 0x73, 0x0a, 0x74, 0x22, 0x9c, 0x6e, 0xb9, 0x04, 0xdf, 0x40, 0x75, 0x48, 0x02, 0xee,
-	0x50, 0x2b, 0x4c, 0xb8, 0x9e, 0xb2, 0x20, 0x28, 0x4f, 0x52, 0xf8, 0x97, 0x79, 0x1b, 0x70, 0xb4,
-	0xc8, 0x45, 0xe8, 0xd0, 0x63, 0x0e, 0xe5, 0x11, 0xd9, 0xa6, 0x20, 0x2b, 0xcf, 0xbf, 0x8d, 0x16,
-	0x43, 0x04, 0x85, 0x0a, 0xb9, 0x80, 0x5b, 0x9c, 0x28, 0x45, 0x31, 0xb3, 0xca, 0x0a, 0xa9, 0xcc,
-	0xd0, 0x6f, 0x44, 0xb0, 0xf2, 0xff, 0x50, 0x4a, 0xe9, 0xf5, 0x53, 0xa3, 0xf5, 0x55, 0x86, 0xdd,
-	0x44, 0x76, 0xed, 0x91, 0x50, 0x8e, 0x2f, 0xe6, 0x7e, 0xcc, 0x1f, 0x6b, 0x1f, 0x4a, 0x44, 0xa4,
-	0xfe, 0x4d, 0x1f, 0x4a, 0x09, 0xee, 0xf9, 0xe3, 0x9c, 0xbf, 0x4e, 0x91, 0xba, 0xc7, 0xaf, 0x9e,
-	0xe6, 0x29, 0x7f, 0x06, 0xb4, 0x08, 0x58, 0xd1, 0xe6, 0x79, 0xba, 0xcd, 0x52, 0xfd, 0x68, 0x6d,
-	0xda, 0xb4, 0x0a, 0xb7, 0xb0, 0xdf, 0x61, 0xd4, 0xe1, 0xcc, 0x37, 0x6d, 0xe6, 0x11, 0x93, 0x8c,
-	0x89, 0xcd, 0x99, 0x8f, 0xdf, 0xc1, 0xe1, 0xda, 0xb9, 0x88, 0x72, 0x1f, 0xd0, 0x97, 0x86, 0xe1,
-	0x6c, 0x02, 0xdb, 0x73, 0xdb, 0x05, 0x6f, 0x42, 0x56, 0xbf, 0xd6, 0x35, 0xb4, 0x81, 0x8b, 0x90,
-	0xbb, 0xf9, 0xd8, 0x6e, 0xe8, 0x48, 0xc2, 0xdb, 0x50, 0x14, 0xc7, 0xc1, 0x55, 0x57, 0x43, 0x32,
-	0x2e, 0x40, 0xe6, 0xca, 0xd0, 0x50, 0x26, 0x04, 0x9b, 0xc6, 0xcd, 0xbf, 0x28, 0x8b, 0xf7, 0x60,
-	0xbb, 0xd3, 0x6d, 0x9b, 0x4c, 0xe3, 0x23, 0xe2, 0x53, 0xc2, 0x51, 0x0e, 0x6f, 0xc1, 0xa6, 0x30,
-	0x85, 0xd0, 0xfc, 0xec, 0xd6, 0x6f, 0x76, 0x51, 0xe1, 0xec, 0x00, 0x72, 0x62, 0x40, 0x70, 0x1e,
-	0xe4, 0x7e, 0x17, 0x6d, 0x84, 0x4c, 0xcd, 0xeb, 0x5b, 0x1d, 0x49, 0x67, 0x2d, 0xf8, 0x65, 0xc5,
-	0xeb, 0xe1, 0x32, 0xfc, 0xda, 0xd2, 0x5b, 0xbd, 0x56, 0xa3, 0x3d, 0x30, 0x7b, 0x8d, 0x9e, 0x36,
-	0xe8, 0x19, 0x0d, 0xdd, 0xfc, 0xa0, 0x19, 0x68, 0x03, 0x03, 0xe4, 0xfb, 0xdd, 0x66, 0xa3, 0xa7,
-	0x21, 0x29, 0x3c, 0x37, 0xb5, 0xb6, 0xd6, 0xd3, 0x90, 0x5c, 0x7f, 0x82, 0xbd, 0x58, 0xb5, 0xd4,
-	0x72, 0xb2, 0x01, 0x2f, 0x19, 0x03, 0x7c, 0xba, 0x6a, 0xcd, 0xac, 0x50, 0xbc, 0x7c, 0xfc, 0xfa,
-	0xa8, 0xfc, 0x2d, 0xbd, 0xdf, 0xfa, 0x04, 0x89, 0xff, 0x2e, 0x2f, 0xd6, 0xfe, 0xf9, 0x8f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x58, 0xe9, 0xdf, 0x40, 0x8f, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("connection.proto", fileDescriptor_connection_726a4ebd31f2552f) }
+
+var fileDescriptor_connection_726a4ebd31f2552f = []byte{
+	// 846 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xff, 0x6e, 0xe3, 0x44,
+	0x10, 0xae, 0x9d, 0x9f, 0x9e, 0x5c, 0x5b, 0x77, 0x29, 0xc8, 0x17, 0xee, 0x44, 0x54, 0x0e, 0x2e,
+	0x54, 0xc8, 0x2d, 0x29, 0x42, 0x50, 0x09, 0x50, 0xb8, 0xfa, 0x4e, 0x11, 0x69, 0x2e, 0xb2, 0xd3,
+	0x82, 0x90, 0x90, 0xe5, 0x3a, 0x43, 0x6b, 0x35, 0xde, 0x35, 0xeb, 0x4d, 0x69, 0x1f, 0x81, 0x77,
+	0xe2, 0x19, 0xf8, 0x9b, 0xc7, 0x41, 0x5e, 0x3b, 0x71, 0xd2, 0xba, 0x09, 0xe8, 0xfe, 0xf3, 0xce,
+	0x7c, 0xf3, 0xed, 0xcc, 0xb7, 0x33, 0x63, 0xd0, 0x7d, 0x46, 0x29, 0xfa, 0x22, 0x60, 0xd4, 0x8c,
+	0x38, 0x13, 0x8c, 0xec, 0x70, 0x0c, 0x99, 0x40, 0x33, 0x77, 0x34, 0xa3, 0xcb, 0x40, 0x5c, 0x4d,
+	0x2f, 0x4c, 0x9f, 0x85, 0x07, 0x14, 0xc5, 0x1f, 0x8c, 0x5f, 0xc7, 0xc8, 0x6f, 0x02, 0x1f, 0x43,
+	0x8c, 0xaf, 0x8a, 0x4c, 0x3e, 0xa3, 0x82, 0xb3, 0x49, 0x34, 0xf1, 0x28, 0x1e, 0x44, 0xd7, 0x97,
+	0x07, 0x5e, 0x14, 0xc4, 0x07, 0x39, 0x65, 0xe2, 0xc7, 0x5b, 0xf1, 0xd0, 0x92, 0x26, 0xb1, 0xf7,
+	0xb7, 0x02, 0xda, 0x29, 0xfa, 0x57, 0x1e, 0x0d, 0xe2, 0x90, 0x7c, 0x09, 0x65, 0x71, 0x17, 0xa1,
+	0xa1, 0xb4, 0x94, 0xf6, 0x56, 0xa7, 0x65, 0x3e, 0xc8, 0xd0, 0x9c, 0x63, 0x47, 0x77, 0x11, 0xda,
+	0x12, 0x4d, 0xfa, 0x00, 0x91, 0xc7, 0xbd, 0x10, 0x05, 0xf2, 0xd8, 0x50, 0x5b, 0xa5, 0x76, 0xa3,
+	0xf3, 0xf9, 0xaa, 0x58, 0x73, 0x38, 0x87, 0x5b, 0x54, 0xf0, 0x3b, 0x7b, 0x21, 0xbe, 0xf9, 0x2d,
+	0x6c, 0xdf, 0x73, 0x13, 0x1d, 0x4a, 0xd7, 0x78, 0x27, 0xb3, 0xd2, 0xec, 0xe4, 0x93, 0xec, 0x42,
+	0xe5, 0xc6, 0x9b, 0x4c, 0xd1, 0x50, 0xa5, 0x2d, 0x3d, 0x1c, 0xab, 0x5f, 0x2b, 0x7b, 0xff, 0x94,
+	0x01, 0x5e, 0xcd, 0xef, 0x24, 0x5b, 0xa0, 0x06, 0xe3, 0x2c, 0x52, 0x0d, 0xc6, 0xe4, 0x25, 0x6c,
+	0x67, 0x2a, 0xba, 0x99, 0x8c, 0x19, 0xc5, 0x56, 0x66, 0x76, 0x52, 0x2b, 0x39, 0x06, 0x2d, 0x9c,
+	0xe5, 0x6b, 0x94, 0x5a, 0x4a, 0xbb, 0xd1, 0x79, 0xb6, 0xaa, 0x26, 0x3b, 0x87, 0x93, 0xef, 0xa0,
+	0x96, 0xa9, 0x6c, 0x94, 0x65, 0xe4, 0x0b, 0xf3, 0xa1, 0xfe, 0x79, 0x92, 0xaf, 0x52, 0x8b, 0x3d,
+	0x0b, 0x22, 0x5d, 0xa8, 0x4e, 0xbc, 0x0b, 0x9c, 0xc4, 0x46, 0x45, 0x8a, 0xf9, 0x59, 0xc1, 0xc5,
+	0x79, 0xb8, 0xd9, 0x97, 0xd8, 0x54, 0xc9, 0x2c, 0x90, 0xf4, 0xe1, 0xe3, 0x98, 0x4d, 0xb9, 0x8f,
+	0xee, 0xbd, 0x72, 0xdd, 0xd0, 0xa3, 0xde, 0x25, 0x72, 0x97, 0x7a, 0x21, 0x1a, 0x55, 0x59, 0xfb,
+	0x47, 0x29, 0x74, 0xb0, 0xa4, 0xc0, 0x69, 0x8a, 0x1b, 0x78, 0x21, 0x92, 0x73, 0x68, 0x8f, 0x31,
+	0x16, 0x01, 0xf5, 0x92, 0x0b, 0x57, 0x53, 0xd6, 0x24, 0xe5, 0x8b, 0x05, 0xfc, 0xe3, 0xbc, 0x5d,
+	0x78, 0x7e, 0x9f, 0x0b, 0xe9, 0x38, 0x62, 0x01, 0x15, 0x29, 0x59, 0x5d, 0x92, 0x35, 0x97, 0xdf,
+	0xc6, 0xca, 0x20, 0x92, 0xc2, 0x84, 0x4a, 0x2c, 0x3c, 0x81, 0x86, 0x26, 0x7b, 0xd6, 0x28, 0x90,
+	0xca, 0x49, 0xfc, 0x76, 0x0a, 0x6b, 0x7e, 0x03, 0x8d, 0x05, 0xbd, 0xfe, 0x57, 0x6b, 0xfd, 0xa5,
+	0xc2, 0x76, 0x2e, 0xbb, 0x75, 0x83, 0x54, 0x90, 0xe3, 0xa5, 0x89, 0xf9, 0x74, 0xe5, 0x43, 0xc9,
+	0x88, 0x85, 0xb9, 0x39, 0x83, 0x46, 0x8e, 0x9b, 0x0d, 0xce, 0xd1, 0x7a, 0x8a, 0x85, 0x73, 0xf6,
+	0xea, 0x8b, 0x3c, 0xa4, 0x09, 0xf5, 0x18, 0x7f, 0x9f, 0x22, 0xf5, 0x51, 0x36, 0x6e, 0xd9, 0x9e,
+	0x9f, 0xc9, 0x87, 0xa0, 0x25, 0x42, 0x23, 0x77, 0x83, 0xb1, 0xec, 0x4d, 0xcd, 0xae, 0xa7, 0x86,
+	0xde, 0xb8, 0xf9, 0x2b, 0xe8, 0xf7, 0x99, 0x0b, 0xf4, 0x39, 0x5a, 0xd4, 0xa7, 0xd1, 0x79, 0xbe,
+	0x32, 0xdf, 0x45, 0xf9, 0xfe, 0x2c, 0xc3, 0xee, 0x29, 0xa3, 0x81, 0x60, 0xdc, 0xf1, 0x59, 0x84,
+	0x0e, 0x4e, 0xd0, 0x17, 0x8c, 0x93, 0xef, 0xe1, 0xd9, 0xca, 0x8e, 0x4a, 0x2f, 0x7f, 0x4a, 0x1f,
+	0x6d, 0xa3, 0x43, 0xd8, 0xe5, 0x18, 0x4f, 0x43, 0x74, 0x7f, 0xe3, 0x2c, 0x74, 0xe7, 0xd5, 0xab,
+	0xb2, 0x7a, 0x92, 0xfa, 0x5e, 0x73, 0x16, 0x3a, 0x33, 0x1d, 0xbe, 0x80, 0xf7, 0x97, 0x23, 0x66,
+	0x9a, 0xa4, 0x0d, 0xb7, 0x14, 0x92, 0xaa, 0x53, 0xb4, 0x39, 0x4a, 0x85, 0x9b, 0xe3, 0x13, 0xd8,
+	0xca, 0xb5, 0x70, 0x83, 0x71, 0x6c, 0x94, 0x5b, 0xa5, 0xb6, 0x66, 0x6f, 0xe6, 0xd6, 0xde, 0x38,
+	0x5e, 0xdf, 0xfb, 0x95, 0xb5, 0xbd, 0xff, 0xe3, 0x7c, 0x4f, 0x54, 0x1f, 0xed, 0x9d, 0x22, 0xc5,
+	0x0b, 0x37, 0xc6, 0x21, 0x54, 0xe5, 0x84, 0xc4, 0x46, 0xad, 0x55, 0x5a, 0x39, 0x49, 0x19, 0xee,
+	0x1d, 0x46, 0x69, 0x7f, 0x0a, 0x9b, 0x4b, 0x7f, 0x12, 0x52, 0x87, 0xf2, 0xe0, 0xed, 0xc0, 0xd2,
+	0x37, 0x88, 0x06, 0x95, 0xf3, 0x9f, 0xfb, 0xdd, 0x81, 0xae, 0x90, 0x4d, 0xd0, 0xe4, 0xa7, 0xfb,
+	0x66, 0x68, 0xe9, 0x2a, 0xa9, 0x41, 0xe9, 0x8d, 0x6d, 0xe9, 0xa5, 0x04, 0xec, 0xd8, 0xe7, 0x5f,
+	0xe9, 0x65, 0xb2, 0x03, 0x9b, 0xa7, 0xc3, 0xbe, 0xc3, 0x2c, 0x71, 0x85, 0x9c, 0xa2, 0xd0, 0x2b,
+	0xe4, 0x09, 0xd4, 0xa5, 0x29, 0x81, 0x56, 0xe7, 0xa7, 0xb3, 0x93, 0xa1, 0x5e, 0xdb, 0x7f, 0x0a,
+	0x15, 0x59, 0x02, 0xa9, 0x82, 0x7a, 0x36, 0xd4, 0x37, 0x12, 0xa6, 0x93, 0xb7, 0x3f, 0x0d, 0x74,
+	0x65, 0xbf, 0x07, 0xef, 0x15, 0x4c, 0x2a, 0x69, 0xc2, 0x07, 0xbd, 0x41, 0x6f, 0xd4, 0xeb, 0xf6,
+	0x5d, 0x67, 0xd4, 0x1d, 0x59, 0xee, 0xc8, 0xee, 0x0e, 0x9c, 0xd7, 0x96, 0xad, 0x6f, 0x10, 0x80,
+	0xea, 0xd9, 0xf0, 0xa4, 0x3b, 0xb2, 0x74, 0x25, 0xf9, 0x3e, 0xb1, 0xfa, 0xd6, 0xc8, 0xd2, 0xd5,
+	0xce, 0x2d, 0xec, 0x64, 0xaa, 0xe7, 0x8c, 0xc4, 0x07, 0xf2, 0xc0, 0x18, 0x93, 0x97, 0xff, 0xf1,
+	0xc5, 0x9a, 0x7b, 0xeb, 0xd7, 0xc2, 0xa1, 0xf2, 0xc3, 0x93, 0x5f, 0x20, 0xf7, 0x5f, 0x54, 0xe5,
+	0x2f, 0xfe, 0xe8, 0xdf, 0x01, 0x00, 0xd7, 0xbe, 0xfc, 0x5f, 0x7b, 0x08, 0x00, 0x00,
 }
@@ -157,3 +157,37 @@ func (c *Connection) SetId(id string) {
 func (c *Connection) SetNetworkServiceName(networkService string) {
 	c.NetworkService = networkService
 }
+
+// Matches - returns true if the connection has the manager as a source or a destination and matches all
+// of the monitor selectors. Empty manager name matches connections without a source or a destination manager only.
+func (s *MonitorScopeSelector) Matches(c *Connection) bool {
+	if nsm := s.GetNetworkServiceManagerName(); nsm != c.GetSourceNetworkServiceManagerName() && nsm != c.GetDestinationNetworkServiceManagerName() {
+		return false
+	}
+	return s.Selector().Matches(c.Selected())
+}
+
+// Selector returns the monitor selectors of the scope selector, except of the manager name.
+func (s *MonitorScopeSelector) Selector() *connectioncontext.ConnectionSelector {
+	selector := &connectioncontext.ConnectionSelector{
+		NetworkService:             s.GetNetworkService(),
+		ConnectionIds:              s.GetConnectionIds(),
+		NetworkServiceEndpointName: s.GetNetworkServiceEndpointName(),
+		Labels:                     s.GetLabels(),
+	}
+	for _, state := range s.GetStates() {
+		selector.States = append(selector.States, int32(state))
+	}
+	return selector
+}
+
+// Selected returns the connection fields checked by monitor selectors.
+func (c *Connection) Selected() *connectioncontext.SelectedConnection {
+	return &connectioncontext.SelectedConnection{
+		NetworkService:             c.GetNetworkService(),
+		Id:                         c.GetId(),
+		NetworkServiceEndpointName: c.GetNetworkServiceEndpointName(),
+		Labels:                     c.GetLabels(),
+		State:                      int32(c.GetState()),
+	}
+}
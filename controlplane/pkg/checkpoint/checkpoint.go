@@ -0,0 +1,246 @@
+// Package checkpoint persists NSMD client connections, so NSMD restores them with the original requests,
+// endpoints and remote NSMs after restart instead of guessing them from the dataplane cross connects.
+//
+// The checkpoint is a model listener, connections are encoded when the model notifies about the change and
+// are written to the record log by a background goroutine, so the model is not blocked by the disk.
+package checkpoint
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// compactMinRecords is a number of records the file could have before it is compacted
+	compactMinRecords = 64
+)
+
+// Checkpoint is a file with client connections of the model.
+type Checkpoint struct {
+	model.ModelListenerImpl
+	log *recordlog.Log
+
+	lock        sync.Mutex
+	connections map[string][]byte // encoded records of the live connections
+	pending     [][]byte          // records not written yet
+	records     int               // number of records in the file
+	rewrite     bool              // the file should be replaced with the live connections
+	stopped     bool
+
+	// writeLock serializes file access of the writer and Delete
+	writeLock sync.Mutex
+	updates   chan struct{}
+	done      chan struct{}
+	finished  chan struct{}
+}
+
+// New creates a checkpoint of the file and starts its writer, Load should be called before the changes
+// are passed to the checkpoint, otherwise the file is replaced by them.
+func New(file string) *Checkpoint {
+	c := &Checkpoint{
+		log:         recordlog.New(file, fileMagic, fileVersion),
+		connections: map[string][]byte{},
+		rewrite:     true,
+		updates:     make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		finished:    make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// Load returns the connections stored in the file. Connections are also kept as the checkpoint state,
+// connections not restored into the model should be passed to ClientConnectionDeleted.
+func (c *Checkpoint) Load() ([]*model.ClientConnection, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	payloads, err := c.log.Read()
+	if os.IsNotExist(err) {
+		logrus.Infof("No stored connection checkpoint exists")
+		c.reset(map[string][]byte{})
+		return nil, nil
+	}
+	if _, broken := err.(*recordlog.BrokenError); err != nil && !broken {
+		// Unknown file content, the file is replaced by the next write.
+		c.reset(map[string][]byte{})
+		return nil, err
+	}
+
+	live := map[string][]byte{}
+	connections := map[string]*model.ClientConnection{}
+	for idx, payload := range payloads {
+		id, clientConnection, decodeErr := decodeRecord(payload)
+		if decodeErr != nil {
+			payloads, err = payloads[:idx], decodeErr
+			break
+		}
+		if clientConnection == nil {
+			delete(live, id)
+			delete(connections, id)
+		} else {
+			live[id] = payload
+			connections[id] = clientConnection
+		}
+	}
+	if err != nil {
+		// Only the records written before the broken one are trusted, drop the rest.
+		logrus.Errorf("Connection checkpoint %s is broken, %d records are restored: %v", c.log.File(), len(payloads), err)
+	}
+	c.reset(live)
+
+	var rv []*model.ClientConnection
+	for _, clientConnection := range connections {
+		rv = append(rv, clientConnection)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].GetId() < rv[j].GetId()
+	})
+	return rv, err
+}
+
+// Delete removes the file and the stored connections.
+func (c *Checkpoint) Delete() {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.reset(map[string][]byte{})
+	c.log.Delete()
+}
+
+// Stop writes pending changes and stops the writer, changes after Stop are ignored.
+func (c *Checkpoint) Stop() {
+	c.lock.Lock()
+	if c.stopped {
+		c.lock.Unlock()
+		return
+	}
+	c.stopped = true
+	c.lock.Unlock()
+
+	close(c.done)
+	<-c.finished
+}
+
+func (c *Checkpoint) ClientConnectionAdded(clientConnection *model.ClientConnection) {
+	c.put(clientConnection)
+}
+
+func (c *Checkpoint) ClientConnectionUpdated(clientConnection *model.ClientConnection) {
+	c.put(clientConnection)
+}
+
+func (c *Checkpoint) ClientConnectionDeleted(clientConnection *model.ClientConnection) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	id := clientConnection.GetId()
+	if _, ok := c.connections[id]; !ok || c.stopped {
+		return
+	}
+	delete(c.connections, id)
+	c.pending = append(c.pending, encodeDelete(id))
+	c.notify()
+}
+
+func (c *Checkpoint) put(clientConnection *model.ClientConnection) {
+	payload, err := encodeConnection(clientConnection)
+	if err != nil {
+		logrus.Errorf("Failed to checkpoint connection: %v", err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	id := clientConnection.GetId()
+	if bytes.Equal(c.connections[id], payload) || c.stopped {
+		return
+	}
+	c.connections[id] = payload
+	c.pending = append(c.pending, payload)
+	c.notify()
+}
+
+// reset replaces the state with the live connections and schedules the file rewrite.
+func (c *Checkpoint) reset(live map[string][]byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.connections = live
+	c.pending = nil
+	c.records = 0
+	c.rewrite = true
+	c.notify()
+}
+
+// notify wakes up the writer, should be called under the lock.
+func (c *Checkpoint) notify() {
+	select {
+	case c.updates <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Checkpoint) run() {
+	defer close(c.finished)
+	for {
+		select {
+		case <-c.updates:
+			c.flush()
+		case <-c.done:
+			c.flush()
+			return
+		}
+	}
+}
+
+// flush writes pending records, the file is compacted if most of its records are outdated.
+func (c *Checkpoint) flush() {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.lock.Lock()
+	pending := c.pending
+	c.pending = nil
+	records := c.records + len(pending)
+	rewrite := c.rewrite || (records > compactMinRecords && records > 2*len(c.connections))
+	var snapshot [][]byte
+	if rewrite {
+		ids := make([]string, 0, len(c.connections))
+		for id := range c.connections {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			snapshot = append(snapshot, c.connections[id])
+		}
+		c.rewrite = false
+	}
+	c.lock.Unlock()
+
+	var err error
+	if rewrite {
+		err = c.log.Rewrite(snapshot)
+		records = len(snapshot)
+	} else if len(pending) > 0 {
+		err = c.log.Append(pending...)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err != nil {
+		// The file content is unknown, replace it on the next write.
+		logrus.Errorf("Failed to store connection checkpoint %s: %v", c.log.File(), err)
+		c.rewrite = true
+		return
+	}
+	// Records appended while the file was written are counted when they are written.
+	c.records = records
+}
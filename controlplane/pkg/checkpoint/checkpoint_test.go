@@ -0,0 +1,191 @@
+package checkpoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+	. "github.com/onsi/gomega"
+)
+
+func localConnection(id string) *model.ClientConnection {
+	src := &connection.Connection{
+		Id:             id,
+		NetworkService: "golden_network",
+		Mechanism: &connection.Mechanism{
+			Type:       connection.MechanismType_KERNEL_INTERFACE,
+			Parameters: map[string]string{connection.Workspace: "nsm-1"},
+		},
+	}
+	return &model.ClientConnection{
+		ConnectionId: id,
+		Xcon: &crossconnect.CrossConnect{
+			Id:          id,
+			Source:      &crossconnect.CrossConnect_LocalSource{LocalSource: src},
+			Destination: &crossconnect.CrossConnect_RemoteDestination{RemoteDestination: &remote_connection.Connection{Id: "7"}},
+		},
+		RemoteNsm: &registry.NetworkServiceManager{Name: "nsm2", Url: "127.0.0.2:5000"},
+		Endpoint: &registry.NSERegistration{
+			NetworkserviceEndpoint: &registry.NetworkServiceEndpoint{EndpointName: "ep1"},
+		},
+		Dataplane:       &model.Dataplane{RegisteredName: "dataplane1"},
+		ConnectionState: model.ClientConnection_Ready,
+		DataplaneState:  model.DataplaneState_Ready,
+		Request: &networkservice.NetworkServiceRequest{
+			Connection: src,
+		},
+	}
+}
+
+func remoteConnection(id string) *model.ClientConnection {
+	src := &remote_connection.Connection{
+		Id:                              id,
+		NetworkService:                  "golden_network",
+		SourceNetworkServiceManagerName: "nsm2",
+	}
+	return &model.ClientConnection{
+		ConnectionId: id,
+		Xcon: &crossconnect.CrossConnect{
+			Id:     id,
+			Source: &crossconnect.CrossConnect_RemoteSource{RemoteSource: src},
+		},
+		Dataplane:       &model.Dataplane{RegisteredName: "dataplane1"},
+		ConnectionState: model.ClientConnection_Requesting,
+		Request: &remote_networkservice.NetworkServiceRequest{
+			Connection: src,
+		},
+	}
+}
+
+func expectConnection(actual, expected *model.ClientConnection) {
+	Expect(actual.GetId()).To(Equal(expected.GetId()))
+	Expect(actual.ConnectionState).To(Equal(expected.ConnectionState))
+	Expect(actual.DataplaneState).To(Equal(expected.DataplaneState))
+	Expect(actual.Dataplane.RegisteredName).To(Equal(expected.Dataplane.RegisteredName))
+	Expect(proto.Equal(actual.Xcon, expected.Xcon)).To(BeTrue())
+	Expect(actual.RemoteNsm == nil).To(Equal(expected.RemoteNsm == nil))
+	Expect(proto.Equal(actual.RemoteNsm, expected.RemoteNsm)).To(BeTrue())
+	Expect(actual.Endpoint == nil).To(Equal(expected.Endpoint == nil))
+	Expect(proto.Equal(actual.Endpoint, expected.Endpoint)).To(BeTrue())
+	Expect(actual.Request).To(BeAssignableToTypeOf(expected.Request))
+	Expect(proto.Equal(actual.Request.(proto.Message), expected.Request.(proto.Message))).To(BeTrue())
+}
+
+func newTestCheckpoint(t *testing.T) (*Checkpoint, string) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	Expect(err).To(BeNil())
+	return New(path.Join(dir, "connections.checkpoint")), dir
+}
+
+func TestCheckpointRestore(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, dir := newTestCheckpoint(t)
+	defer os.RemoveAll(dir)
+	connections, err := c.Load()
+	Expect(err).To(BeNil())
+	Expect(connections).To(BeEmpty())
+
+	local, remote, deleted := localConnection("1"), remoteConnection("2"), localConnection("3")
+	c.ClientConnectionAdded(local)
+	c.ClientConnectionAdded(remote)
+	c.ClientConnectionAdded(deleted)
+	c.ClientConnectionDeleted(deleted)
+	remote.ConnectionState = model.ClientConnection_Ready
+	c.ClientConnectionUpdated(remote)
+	c.Stop()
+
+	restored := New(c.log.File())
+	defer restored.Stop()
+	connections, err = restored.Load()
+	Expect(err).To(BeNil())
+	Expect(len(connections)).To(Equal(2))
+	expectConnection(connections[0], local)
+	expectConnection(connections[1], remote)
+}
+
+func TestCheckpointBrokenTail(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, dir := newTestCheckpoint(t)
+	defer os.RemoveAll(dir)
+	_, _ = c.Load()
+	local := localConnection("1")
+	c.ClientConnectionAdded(local)
+	c.Stop()
+
+	// Torn write of the next record.
+	f, err := os.OpenFile(c.log.File(), os.O_APPEND|os.O_WRONLY, 0600)
+	Expect(err).To(BeNil())
+	_, err = f.Write([]byte{0, 0, 1, 0, 1, 2})
+	Expect(err).To(BeNil())
+	Expect(f.Close()).To(BeNil())
+
+	restored := New(c.log.File())
+	connections, err := restored.Load()
+	Expect(err).NotTo(BeNil())
+	Expect(len(connections)).To(Equal(1))
+	expectConnection(connections[0], local)
+
+	// Broken tail is dropped from the file.
+	restored.Stop()
+	records, err := recordlog.New(c.log.File(), fileMagic, fileVersion).Read()
+	Expect(err).To(BeNil())
+	Expect(len(records)).To(Equal(1))
+}
+
+func TestCheckpointCompaction(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, dir := newTestCheckpoint(t)
+	defer os.RemoveAll(dir)
+	_, _ = c.Load()
+	local := localConnection("1")
+	for i := 0; i < 10*compactMinRecords; i++ {
+		local.Xcon.GetLocalSource().Labels = map[string]string{"update": string(rune('a' + i%20))}
+		c.ClientConnectionUpdated(local)
+	}
+	c.ClientConnectionAdded(remoteConnection("2"))
+	c.Stop()
+
+	records, err := recordlog.New(c.log.File(), fileMagic, fileVersion).Read()
+	Expect(err).To(BeNil())
+	Expect(len(records)).To(BeNumerically("<=", compactMinRecords+1))
+
+	restored := New(c.log.File())
+	defer restored.Stop()
+	connections, err := restored.Load()
+	Expect(err).To(BeNil())
+	Expect(len(connections)).To(Equal(2))
+	expectConnection(connections[0], local)
+}
+
+func FuzzDecodeRecord(f *testing.F) {
+	valid, _ := encodeConnection(localConnection("1"))
+	f.Add(valid)
+	f.Add(valid[:len(valid)-1])
+	f.Add(encodeDelete("1"))
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		id, clientConnection, err := decodeRecord(payload)
+		if err != nil || clientConnection == nil {
+			return
+		}
+		if id == "" || id != clientConnection.GetId() || clientConnection.Xcon == nil || clientConnection.Dataplane == nil {
+			t.Fatalf("record %v is decoded to incomplete connection %v", payload, clientConnection)
+		}
+		// Decoded connection is encoded again.
+		if _, err := encodeConnection(clientConnection); err != nil {
+			t.Fatalf("failed to encode decoded connection %v: %v", clientConnection, err)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package checkpoint
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+)
+
+// Record payload is a record type byte followed by the fields.
+const (
+	fileMagic   = "NSMCHK"
+	fileVersion = uint16(1)
+)
+
+type recordType byte
+
+const (
+	recordConnection recordType = iota + 1
+	recordDeleteConnection
+)
+
+// requestType is a type of the request stored with the connection.
+type requestType byte
+
+const (
+	requestNone requestType = iota
+	requestLocal
+	requestRemote
+)
+
+// Fields of the connection record, optional messages are stored as empty fields if they are nil.
+const (
+	fieldId = iota
+	fieldConnectionState
+	fieldDataplaneState
+	fieldDataplane
+	fieldXcon
+	fieldRemoteNsm
+	fieldEndpoint
+	fieldRequestType
+	fieldRequest
+	connectionFields
+)
+
+func encodeConnection(clientConnection *model.ClientConnection) ([]byte, error) {
+	if clientConnection.GetId() == "" {
+		return nil, fmt.Errorf("connection without id")
+	}
+	fields := make([][]byte, connectionFields)
+	fields[fieldId] = []byte(clientConnection.GetId())
+	fields[fieldConnectionState] = []byte{byte(clientConnection.ConnectionState)}
+	fields[fieldDataplaneState] = []byte{byte(clientConnection.DataplaneState)}
+	if clientConnection.Dataplane != nil {
+		fields[fieldDataplane] = []byte(clientConnection.Dataplane.RegisteredName)
+	}
+
+	messages := map[int]proto.Message{}
+	if clientConnection.Xcon != nil {
+		messages[fieldXcon] = clientConnection.Xcon
+	}
+	if clientConnection.RemoteNsm != nil {
+		messages[fieldRemoteNsm] = clientConnection.RemoteNsm
+	}
+	if clientConnection.Endpoint != nil {
+		messages[fieldEndpoint] = clientConnection.Endpoint
+	}
+	reqType := requestNone
+	switch request := clientConnection.Request.(type) {
+	case *networkservice.NetworkServiceRequest:
+		if request != nil {
+			reqType = requestLocal
+			messages[fieldRequest] = request
+		}
+	case *remote_networkservice.NetworkServiceRequest:
+		if request != nil {
+			reqType = requestRemote
+			messages[fieldRequest] = request
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("connection %s has unknown request type %T", clientConnection.GetId(), request)
+	}
+	fields[fieldRequestType] = []byte{byte(reqType)}
+
+	for _, field := range []int{fieldXcon, fieldRemoteNsm, fieldEndpoint, fieldRequest} {
+		fields[field] = []byte{}
+		if message, ok := messages[field]; ok {
+			data, err := proto.Marshal(message)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize connection %s: %v", clientConnection.GetId(), err)
+			}
+			fields[field] = data
+		}
+	}
+	return append([]byte{byte(recordConnection)}, recordlog.EncodeFields(fields...)...), nil
+}
+
+func encodeDelete(connectionId string) []byte {
+	return append([]byte{byte(recordDeleteConnection)}, recordlog.EncodeFields([]byte(connectionId))...)
+}
+
+// decodeRecord returns the connection id of the record and the connection, the connection is nil for
+// delete records.
+func decodeRecord(payload []byte) (string, *model.ClientConnection, error) {
+	if len(payload) == 0 {
+		return "", nil, fmt.Errorf("empty record")
+	}
+	switch recordType(payload[0]) {
+	case recordDeleteConnection:
+		fields, err := recordlog.DecodeFields(payload[1:], 1)
+		if err != nil {
+			return "", nil, fmt.Errorf("delete record: %v", err)
+		}
+		return string(fields[0]), nil, nil
+	case recordConnection:
+		fields, err := recordlog.DecodeFields(payload[1:], connectionFields)
+		if err != nil {
+			return "", nil, fmt.Errorf("connection record: %v", err)
+		}
+		clientConnection, err := decodeConnection(fields)
+		if err != nil {
+			return "", nil, err
+		}
+		return clientConnection.GetId(), clientConnection, nil
+	}
+	return "", nil, fmt.Errorf("unknown record type %d", payload[0])
+}
+
+func decodeConnection(fields [][]byte) (*model.ClientConnection, error) {
+	id := string(fields[fieldId])
+	if id == "" {
+		return nil, fmt.Errorf("connection without id")
+	}
+	for _, field := range []int{fieldConnectionState, fieldDataplaneState, fieldRequestType} {
+		if len(fields[field]) != 1 {
+			return nil, fmt.Errorf("connection %s: invalid field %d", id, field)
+		}
+	}
+	clientConnection := &model.ClientConnection{
+		ConnectionId:    id,
+		ConnectionState: model.ClientConnectionState(fields[fieldConnectionState][0]),
+		DataplaneState:  model.DataplaneState(fields[fieldDataplaneState][0]),
+		Dataplane:       &model.Dataplane{RegisteredName: string(fields[fieldDataplane])},
+		Xcon:            &crossconnect.CrossConnect{},
+	}
+	messages := map[int]proto.Message{
+		fieldXcon: clientConnection.Xcon,
+	}
+	if len(fields[fieldRemoteNsm]) > 0 {
+		clientConnection.RemoteNsm = &registry.NetworkServiceManager{}
+		messages[fieldRemoteNsm] = clientConnection.RemoteNsm
+	}
+	if len(fields[fieldEndpoint]) > 0 {
+		clientConnection.Endpoint = &registry.NSERegistration{}
+		messages[fieldEndpoint] = clientConnection.Endpoint
+	}
+	switch requestType(fields[fieldRequestType][0]) {
+	case requestNone:
+		if len(fields[fieldRequest]) > 0 {
+			return nil, fmt.Errorf("connection %s: request without type", id)
+		}
+	case requestLocal:
+		request := &networkservice.NetworkServiceRequest{}
+		clientConnection.Request = request
+		messages[fieldRequest] = request
+	case requestRemote:
+		request := &remote_networkservice.NetworkServiceRequest{}
+		clientConnection.Request = request
+		messages[fieldRequest] = request
+	default:
+		return nil, fmt.Errorf("connection %s: unknown request type %d", id, fields[fieldRequestType][0])
+	}
+	for field, message := range messages {
+		if err := proto.Unmarshal(fields[field], message); err != nil {
+			return nil, fmt.Errorf("connection %s: failed to decode field %d: %v", id, field, err)
+		}
+	}
+	return clientConnection, nil
+}
@@ -125,6 +125,7 @@ type Model interface {
 
 	GetDataplane(name string) *Dataplane
 	AddDataplane(dataplane *Dataplane)
+	UpdateDataplane(dataplane *Dataplane)
 	DeleteDataplane(name string)
 	SelectDataplane() (*Dataplane, error)
 
@@ -133,6 +134,10 @@ type Model interface {
 	GetAllClientConnections() []*ClientConnection
 	UpdateClientConnection(clientConnection *ClientConnection)
 	DeleteClientConnection(connectionId string)
+	// Connections are read by listeners and monitors concurrently, so fields of the connection added to the model
+	// are changed with ApplyClientConnectionChanges and read from other goroutines with CopyClientConnection.
+	ApplyClientConnectionChanges(clientConnection *ClientConnection, changeFunc func(*ClientConnection))
+	CopyClientConnection(clientConnection *ClientConnection) *ClientConnection
 
 	ConnectionId() string
 
@@ -192,11 +197,35 @@ func (i *impl) UpdateClientConnection(clientConnection *ClientConnection) {
 	i.clientConnections[clientConnection.ConnectionId] = clientConnection
 	i.Unlock()
 
+	// Listeners read the connection, so it should not be changed meanwhile.
+	i.RLock()
+	defer i.RUnlock()
+
 	for _, listener := range i.listeners {
 		listener.ClientConnectionUpdated(clientConnection)
 	}
 }
 
+// ApplyClientConnectionChanges calls changeFunc under the model lock, changeFunc should not call the model.
+func (i *impl) ApplyClientConnectionChanges(clientConnection *ClientConnection, changeFunc func(*ClientConnection)) {
+	i.Lock()
+	defer i.Unlock()
+
+	changeFunc(clientConnection)
+}
+
+// CopyClientConnection returns a shallow copy of the connection taken under the model lock.
+func (i *impl) CopyClientConnection(clientConnection *ClientConnection) *ClientConnection {
+	if clientConnection == nil {
+		return nil
+	}
+	i.RLock()
+	defer i.RUnlock()
+
+	copied := *clientConnection
+	return &copied
+}
+
 func (i *impl) DeleteClientConnection(connectionId string) {
 	i.Lock()
 	clientConnection := i.clientConnections[connectionId]
@@ -335,13 +364,25 @@ func (i *impl) AddDataplane(dataplane *Dataplane) {
 
 	i.dataplanes[dataplane.RegisteredName] = dataplane
 	logrus.Infof("Dataplane added: %v", dataplane)
+	listeners := append([]ModelListener{}, i.listeners...)
 	i.Unlock()
 
-	for _, l := range i.listeners {
+	for _, l := range listeners {
 		l.DataplaneAdded(dataplane)
 	}
 }
 
+// UpdateDataplane replaces the registered dataplane, dataplanes are read without the model lock, so the dataplane
+// passed to the model should not be changed afterwards.
+func (i *impl) UpdateDataplane(dataplane *Dataplane) {
+	i.Lock()
+	defer i.Unlock()
+
+	if _, ok := i.dataplanes[dataplane.RegisteredName]; ok {
+		i.dataplanes[dataplane.RegisteredName] = dataplane
+	}
+}
+
 func (i *impl) DeleteDataplane(name string) {
 	i.Lock()
 	dataplane, ok := i.dataplanes[name]
@@ -350,9 +391,10 @@ func (i *impl) DeleteDataplane(name string) {
 		return
 	}
 	delete(i.dataplanes, name)
+	listeners := append([]ModelListener{}, i.listeners...)
 	i.Unlock()
 
-	for _, l := range i.listeners {
+	for _, l := range listeners {
 		l.DataplaneDeleted(dataplane)
 	}
 }
@@ -379,8 +421,14 @@ func NewModel() Model {
 func (i *impl) ConnectionId() string {
 	i.Lock()
 	defer i.Unlock()
-	i.lastConnnectionId++
-	return strconv.FormatUint(i.lastConnnectionId, 16)
+	for {
+		i.lastConnnectionId++
+		id := strconv.FormatUint(i.lastConnnectionId, 16)
+		// Connections restored after restart keep their ids, so skip them.
+		if _, exists := i.clientConnections[id]; !exists {
+			return id
+		}
+	}
 }
 
 func (i *impl) GetSelector() selector.Selector {
@@ -22,6 +22,8 @@ func (c *CrossConnectEventConverter) Convert(event monitor.Event) (interface{},
 	return &crossconnect.CrossConnectEvent{
 		Type:          eventType,
 		CrossConnects: xcons,
+		Sequence:      event.Sequence,
+		ServerId:      event.ServerId,
 	}, nil
 }
 
@@ -0,0 +1,75 @@
+package crossconnect_monitor
+
+import "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+
+type monitorCrossConnectFilter struct {
+	crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer
+
+	selector *crossconnect.MonitorRequest
+	// sent are ids of the cross connects sent to the recipient and not deleted yet
+	sent map[string]bool
+	// resumed is true until the full state is transferred, sent ids of the resumed stream are not known
+	resumed bool
+}
+
+func NewMonitorCrossConnectFilter(selector *crossconnect.MonitorRequest, monitor crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer) crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer {
+	return &monitorCrossConnectFilter{
+		selector: selector,
+		sent:     map[string]bool{},
+		resumed:  selector.GetResumeFromSequence() != 0,
+		MonitorCrossConnect_MonitorCrossConnectsServer: monitor,
+	}
+}
+
+// SendMsg is used by the monitor server, so events are filtered here as well
+func (d *monitorCrossConnectFilter) SendMsg(msg interface{}) error {
+	if event, ok := msg.(*crossconnect.CrossConnectEvent); ok {
+		return d.Send(event)
+	}
+	return d.MonitorCrossConnect_MonitorCrossConnectsServer.SendMsg(msg)
+}
+
+// Send passes matching cross connects to the recipient. Cross connects already sent are deleted even if they do not
+// match anymore, an update moving a cross connect out of the selector is sent as a delete.
+func (d *monitorCrossConnectFilter) Send(in *crossconnect.CrossConnectEvent) error {
+	out := &crossconnect.CrossConnectEvent{
+		Type:          in.Type,
+		Sequence:      in.Sequence,
+		ServerId:      in.ServerId,
+		CrossConnects: make(map[string]*crossconnect.CrossConnect),
+	}
+	deleted := &crossconnect.CrossConnectEvent{
+		Type:          crossconnect.CrossConnectEventType_DELETE,
+		Sequence:      in.Sequence,
+		ServerId:      in.ServerId,
+		CrossConnects: make(map[string]*crossconnect.CrossConnect),
+	}
+	if in.Type == crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER {
+		d.sent = map[string]bool{}
+		d.resumed = false
+	}
+	for key, value := range in.GetCrossConnects() {
+		switch {
+		case in.Type == crossconnect.CrossConnectEventType_DELETE:
+			if d.sent[key] || d.resumed || d.selector.Matches(value) {
+				out.CrossConnects[key] = value
+			}
+			delete(d.sent, key)
+		case d.selector.Matches(value):
+			out.CrossConnects[key] = value
+			d.sent[key] = true
+		case d.sent[key]:
+			deleted.CrossConnects[key] = value
+			delete(d.sent, key)
+		}
+	}
+	if len(deleted.CrossConnects) > 0 {
+		if err := d.MonitorCrossConnect_MonitorCrossConnectsServer.Send(deleted); err != nil {
+			return err
+		}
+	}
+	if len(out.CrossConnects) > 0 || out.Type == crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER {
+		return d.MonitorCrossConnect_MonitorCrossConnectsServer.Send(out)
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package crossconnect_monitor
 
 import (
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor"
 )
@@ -18,6 +17,7 @@ func NewCrossConnectMonitor() *CrossConnectMonitor {
 	return rv
 }
 
-func (m *CrossConnectMonitor) MonitorCrossConnects(_ *empty.Empty, recipient crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer) error {
-	return m.MonitorEntities(recipient)
+func (m *CrossConnectMonitor) MonitorCrossConnects(request *crossconnect.MonitorRequest, recipient crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer) error {
+	filtered := NewMonitorCrossConnectFilter(request, recipient)
+	return m.MonitorEntitiesFrom(filtered, request.GetResumeFromServerId(), request.GetResumeFromSequence())
 }
@@ -0,0 +1,75 @@
+package local_connection_monitor
+
+import "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+
+type monitorConnectionFilter struct {
+	connection.MonitorConnection_MonitorConnectionsServer
+
+	selector *connection.MonitorRequest
+	// sent are ids of the connections sent to the recipient and not deleted yet
+	sent map[string]bool
+	// resumed is true until the full state is transferred, sent ids of the resumed stream are not known
+	resumed bool
+}
+
+func NewMonitorConnectionFilter(selector *connection.MonitorRequest, monitor connection.MonitorConnection_MonitorConnectionsServer) connection.MonitorConnection_MonitorConnectionsServer {
+	return &monitorConnectionFilter{
+		selector: selector,
+		sent:     map[string]bool{},
+		resumed:  selector.GetResumeFromSequence() != 0,
+		MonitorConnection_MonitorConnectionsServer: monitor,
+	}
+}
+
+// SendMsg is used by the monitor server, so events are filtered here as well
+func (d *monitorConnectionFilter) SendMsg(msg interface{}) error {
+	if event, ok := msg.(*connection.ConnectionEvent); ok {
+		return d.Send(event)
+	}
+	return d.MonitorConnection_MonitorConnectionsServer.SendMsg(msg)
+}
+
+// Send passes matching connections to the recipient. Connections already sent are deleted even if they do not
+// match anymore, an update moving a connection out of the selector is sent as a delete.
+func (d *monitorConnectionFilter) Send(in *connection.ConnectionEvent) error {
+	out := &connection.ConnectionEvent{
+		Type:        in.Type,
+		Sequence:    in.Sequence,
+		ServerId:    in.ServerId,
+		Connections: make(map[string]*connection.Connection),
+	}
+	deleted := &connection.ConnectionEvent{
+		Type:        connection.ConnectionEventType_DELETE,
+		Sequence:    in.Sequence,
+		ServerId:    in.ServerId,
+		Connections: make(map[string]*connection.Connection),
+	}
+	if in.Type == connection.ConnectionEventType_INITIAL_STATE_TRANSFER {
+		d.sent = map[string]bool{}
+		d.resumed = false
+	}
+	for key, value := range in.GetConnections() {
+		switch {
+		case in.Type == connection.ConnectionEventType_DELETE:
+			if d.sent[key] || d.resumed || d.selector.Matches(value) {
+				out.Connections[key] = value
+			}
+			delete(d.sent, key)
+		case d.selector.Matches(value):
+			out.Connections[key] = value
+			d.sent[key] = true
+		case d.sent[key]:
+			deleted.Connections[key] = value
+			delete(d.sent, key)
+		}
+	}
+	if len(deleted.Connections) > 0 {
+		if err := d.MonitorConnection_MonitorConnectionsServer.Send(deleted); err != nil {
+			return err
+		}
+	}
+	if len(out.Connections) > 0 || out.Type == connection.ConnectionEventType_INITIAL_STATE_TRANSFER {
+		return d.MonitorConnection_MonitorConnectionsServer.Send(out)
+	}
+	return nil
+}
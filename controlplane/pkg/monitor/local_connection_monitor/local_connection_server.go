@@ -1,7 +1,6 @@
 package local_connection_monitor
 
 import (
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor"
 )
@@ -18,6 +17,7 @@ func NewLocalConnectionMonitor() *LocalConnectionMonitor {
 	return rv
 }
 
-func (m *LocalConnectionMonitor) MonitorConnections(_ *empty.Empty, recipient connection.MonitorConnection_MonitorConnectionsServer) error {
-	return m.MonitorEntities(recipient)
+func (m *LocalConnectionMonitor) MonitorConnections(request *connection.MonitorRequest, recipient connection.MonitorConnection_MonitorConnectionsServer) error {
+	filtered := NewMonitorConnectionFilter(request, recipient)
+	return m.MonitorEntitiesFrom(filtered, request.GetResumeFromServerId(), request.GetResumeFromSequence())
 }
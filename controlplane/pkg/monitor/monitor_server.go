@@ -1,12 +1,17 @@
 package monitor
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
 const (
 	defaultSize            = 10
+	defaultQueueSize       = 100
+	defaultHistorySize     = 100
 	UPDATE                 = "UPDATE"
 	DELETE                 = "DELETE"
 	INITIAL_STATE_TRANSFER = "INITIAL_STATE_TRANSFER"
@@ -16,9 +21,14 @@ type Entity interface {
 	GetId() string
 }
 
+// Event is a monitor event, Sequence and ServerId are assigned by the monitor server. For INITIAL_STATE_TRANSFER events
+// Sequence is the sequence number of the last event included into the state. ServerId identifies the monitor server
+// instance, sequence numbers of different instances are not comparable.
 type Event struct {
 	EventType string
 	Entities  map[string]Entity
+	Sequence  uint64
+	ServerId  string
 }
 
 type EventConverter interface {
@@ -34,30 +44,83 @@ type MonitorServer interface {
 	Delete(entity Entity)
 
 	AddRecipient(recipient Recipient)
+	AddRecipientFrom(recipient Recipient, serverId string, sequence uint64)
 	DeleteRecipient(recipient Recipient)
 	MonitorEntities(stream grpc.ServerStream) error
+	MonitorEntitiesFrom(stream grpc.ServerStream, serverId string, sequence uint64) error
 
 	Serve()
 }
 
+// monitorRecipient owns a bounded queue of events for a single recipient, the events are sent
+// by a dedicated goroutine so a slow recipient does not block the others.
+type monitorRecipient struct {
+	recipient Recipient
+	serverId  string
+	sequence  uint64
+	queue     chan Event
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// recipientUpdate either adds or deletes a recipient, both are sent through the same channel so a recipient is
+// never deleted before it is added.
+type recipientUpdate struct {
+	added   *monitorRecipient
+	deleted Recipient
+}
+
 type monitorServerImpl struct {
-	eventConverter           EventConverter
-	eventCh                  chan Event
-	newMonitorRecipientCh    chan Recipient
-	closedMonitorRecipientCh chan Recipient
-	entities                 map[string]Entity
-	recipients               []Recipient
+	id                string
+	eventConverter    EventConverter
+	eventCh           chan Event
+	recipientUpdateCh chan recipientUpdate
+	entities          map[string]Entity
+	recipients        []*monitorRecipient
+	sequence          uint64
+	history           []Event
+	queueSize         int
+	historySize       int
 }
 
 func NewMonitorServer(eventConverter EventConverter) MonitorServer {
+	return newMonitorServer(eventConverter, defaultQueueSize, defaultHistorySize)
+}
+
+// NewMonitorServerWithLimits creates a monitor server with the given per recipient queue size and
+// the number of events kept to resume the streams.
+func NewMonitorServerWithLimits(eventConverter EventConverter, queueSize, historySize int) MonitorServer {
+	return newMonitorServer(eventConverter, queueSize, historySize)
+}
+
+func newMonitorServer(eventConverter EventConverter, queueSize, historySize int) *monitorServerImpl {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	if historySize < 0 {
+		historySize = 0
+	}
 	return &monitorServerImpl{
-		eventConverter:           eventConverter,
-		eventCh:                  make(chan Event, defaultSize),
-		newMonitorRecipientCh:    make(chan Recipient, defaultSize),
-		closedMonitorRecipientCh: make(chan Recipient, defaultSize),
-		entities:                 make(map[string]Entity),
-		recipients:               make([]Recipient, 0, defaultSize),
+		id:                newServerId(),
+		eventConverter:    eventConverter,
+		eventCh:           make(chan Event, defaultSize),
+		recipientUpdateCh: make(chan recipientUpdate, defaultSize),
+		entities:          make(map[string]Entity),
+		recipients:        make([]*monitorRecipient, 0, defaultSize),
+		history:           make([]Event, 0, historySize),
+		queueSize:         queueSize,
+		historySize:       historySize,
+	}
+}
+
+// newServerId generates a random id, so a restarted monitor server does not resume the streams of its
+// previous instance.
+func newServerId() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		logrus.Errorf("Failed to generate monitor server id: %v", err)
 	}
+	return hex.EncodeToString(id)
 }
 
 func (m *monitorServerImpl) Update(entity Entity) {
@@ -75,47 +138,73 @@ func (m *monitorServerImpl) Delete(entity Entity) {
 }
 
 func (m *monitorServerImpl) AddRecipient(recipient Recipient) {
-	logrus.Infof("MonitorServerImpl.AddRecipient: %v", recipient)
-	m.newMonitorRecipientCh <- recipient
+	m.AddRecipientFrom(recipient, "", 0)
+}
+
+// AddRecipientFrom adds a recipient resuming from the server id and the sequence number of the last event it has
+// received, zero sequence number or an id of another server instance means the recipient has no state and receives
+// INITIAL_STATE_TRANSFER.
+func (m *monitorServerImpl) AddRecipientFrom(recipient Recipient, serverId string, sequence uint64) {
+	m.addRecipient(recipient, serverId, sequence)
+}
+
+func (m *monitorServerImpl) addRecipient(recipient Recipient, serverId string, sequence uint64) *monitorRecipient {
+	logrus.Infof("MonitorServerImpl.AddRecipient: %p, resume from: %s %d", recipient, serverId, sequence)
+	r := &monitorRecipient{
+		recipient: recipient,
+		serverId:  serverId,
+		sequence:  sequence,
+		queue:     make(chan Event, m.queueSize),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	m.recipientUpdateCh <- recipientUpdate{added: r}
+	return r
 }
 
 func (m *monitorServerImpl) DeleteRecipient(recipient Recipient) {
-	logrus.Infof("MonitorServerImpl.DeleteRecipient: %v", recipient)
-	m.closedMonitorRecipientCh <- recipient
+	logrus.Infof("MonitorServerImpl.DeleteRecipient: %p", recipient)
+	m.recipientUpdateCh <- recipientUpdate{deleted: recipient}
 }
 
 func (m *monitorServerImpl) MonitorEntities(stream grpc.ServerStream) error {
-	m.AddRecipient(stream)
+	return m.MonitorEntitiesFrom(stream, "", 0)
+}
+
+func (m *monitorServerImpl) MonitorEntitiesFrom(stream grpc.ServerStream, serverId string, sequence uint64) error {
+	r := m.addRecipient(stream, serverId, sequence)
 
 	// We need to wait until it will be done and do not exit
-	for {
-		select {
-		case <-stream.Context().Done():
-			m.DeleteRecipient(stream)
-			return nil
-		}
-	}
+	<-stream.Context().Done()
+	m.DeleteRecipient(stream)
+
+	// The stream must not be used after the handler returns
+	<-r.stopped
+	return nil
 }
 
 func (m *monitorServerImpl) Serve() {
 	logrus.Infof("Serve starting...")
 	for {
 		select {
-		case newRecipient := <-m.newMonitorRecipientCh:
-			initialStateTransferEvent := Event{
-				EventType: INITIAL_STATE_TRANSFER,
-				Entities:  m.entities,
+		case update := <-m.recipientUpdateCh:
+			if update.added != nil {
+				go m.sendLoop(update.added)
+				m.resume(update.added)
+				m.recipients = append(m.recipients, update.added)
+				continue
 			}
-			m.send(initialStateTransferEvent, newRecipient)
-			m.recipients = append(m.recipients, newRecipient)
-		case closedRecipient := <-m.closedMonitorRecipientCh:
 			for j, r := range m.recipients {
-				if r == closedRecipient {
+				if r.recipient == update.deleted {
+					close(r.done)
 					m.recipients = append(m.recipients[:j], m.recipients[j+1:]...)
 					break
 				}
 			}
 		case event := <-m.eventCh:
+			m.sequence++
+			event.Sequence = m.sequence
+			event.ServerId = m.id
 			logrus.Infof("New event: %v", event)
 			for _, entity := range event.Entities {
 				if event.EventType == UPDATE {
@@ -125,7 +214,85 @@ func (m *monitorServerImpl) Serve() {
 					delete(m.entities, entity.GetId())
 				}
 			}
-			m.send(event, m.recipients...)
+			m.remember(event)
+			for _, r := range m.recipients {
+				m.enqueue(r, event)
+			}
+		}
+	}
+}
+
+// resume replays the events missed by the recipient if they are still in the history,
+// otherwise the recipient receives the current state.
+func (m *monitorServerImpl) resume(r *monitorRecipient) {
+	if r.sequence == 0 || r.serverId != m.id || r.sequence > m.sequence || !m.inHistory(r.sequence) {
+		m.enqueue(r, m.initialStateTransfer())
+		return
+	}
+	for _, event := range m.history {
+		if event.Sequence > r.sequence {
+			m.enqueue(r, event)
+		}
+	}
+}
+
+func (m *monitorServerImpl) inHistory(sequence uint64) bool {
+	if sequence == m.sequence {
+		return true
+	}
+	return len(m.history) > 0 && m.history[0].Sequence <= sequence+1
+}
+
+func (m *monitorServerImpl) remember(event Event) {
+	if m.historySize <= 0 {
+		return
+	}
+	if len(m.history) == m.historySize {
+		m.history = append(m.history[:0], m.history[1:]...)
+	}
+	m.history = append(m.history, event)
+}
+
+func (m *monitorServerImpl) initialStateTransfer() Event {
+	entities := make(map[string]Entity, len(m.entities))
+	for k, v := range m.entities {
+		entities[k] = v
+	}
+	return Event{
+		EventType: INITIAL_STATE_TRANSFER,
+		Entities:  entities,
+		Sequence:  m.sequence,
+		ServerId:  m.id,
+	}
+}
+
+// enqueue never blocks, if the recipient queue is full the pending events are dropped
+// and replaced with INITIAL_STATE_TRANSFER, so the recipient resyncs its state.
+func (m *monitorServerImpl) enqueue(r *monitorRecipient, event Event) {
+	select {
+	case r.queue <- event:
+		return
+	default:
+	}
+	logrus.Warnf("Recipient %v is too slow, dropping %d events and resending the state", r.recipient, len(r.queue))
+	for drained := false; !drained; {
+		select {
+		case <-r.queue:
+		default:
+			drained = true
+		}
+	}
+	r.queue <- m.initialStateTransfer()
+}
+
+func (m *monitorServerImpl) sendLoop(r *monitorRecipient) {
+	defer close(r.stopped)
+	for {
+		select {
+		case <-r.done:
+			return
+		case event := <-r.queue:
+			m.send(event, r.recipient)
 		}
 	}
 }
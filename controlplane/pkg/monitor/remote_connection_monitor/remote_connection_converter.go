@@ -22,6 +22,8 @@ func (c *RemoteConnectionEventConverter) Convert(event monitor.Event) (interface
 	return &connection.ConnectionEvent{
 		Type:        eventType,
 		Connections: connections,
+		Sequence:    event.Sequence,
+		ServerId:    event.ServerId,
 	}, nil
 }
 
@@ -22,7 +22,7 @@ func NewRemoteConnectionMonitor(manager *services.ClientConnectionManager) *Remo
 
 func (m *RemoteConnectionMonitor) MonitorConnections(selector *connection.MonitorScopeSelector, recipient connection.MonitorConnection_MonitorConnectionsServer) error {
 	filtered := NewMonitorConnectionFilter(selector, recipient)
-	result := m.MonitorEntities(filtered)
+	result := m.MonitorEntitiesFrom(filtered, selector.GetResumeFromServerId(), selector.GetResumeFromSequence())
 	m.manager.UpdateRemoteMonitorDone(selector.NetworkServiceManagerName)
 	return result
 }
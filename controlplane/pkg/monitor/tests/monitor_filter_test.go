@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	local "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	remote "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+func testCrossConnect(id, networkService string) *crossconnect.CrossConnect {
+	return &crossconnect.CrossConnect{
+		Id: id,
+		Source: &crossconnect.CrossConnect_LocalSource{
+			LocalSource: &local.Connection{
+				Id:             id + "-src",
+				NetworkService: networkService,
+				Labels:         map[string]string{"app": "web"},
+			},
+		},
+		Destination: &crossconnect.CrossConnect_RemoteDestination{
+			RemoteDestination: &remote.Connection{
+				Id:                         id + "-dst",
+				NetworkService:             networkService,
+				NetworkServiceEndpointName: "nse-" + id,
+				State:                      remote.State_DOWN,
+			},
+		},
+	}
+}
+
+func TestCrossConnectSelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	xcon := testCrossConnect("1", "icmp")
+	for _, testCase := range []struct {
+		selector *crossconnect.MonitorRequest
+		matches  bool
+	}{
+		{&crossconnect.MonitorRequest{}, true},
+		{&crossconnect.MonitorRequest{NetworkService: "icmp"}, true},
+		{&crossconnect.MonitorRequest{NetworkService: "vpn"}, false},
+		{&crossconnect.MonitorRequest{ConnectionIds: []string{"1"}}, true},
+		{&crossconnect.MonitorRequest{ConnectionIds: []string{"1-dst"}}, true},
+		{&crossconnect.MonitorRequest{ConnectionIds: []string{"2"}}, false},
+		{&crossconnect.MonitorRequest{NetworkServiceEndpointName: "nse-1"}, true},
+		{&crossconnect.MonitorRequest{NetworkServiceEndpointName: "nse-2"}, false},
+		{&crossconnect.MonitorRequest{Labels: map[string]string{"app": "web"}}, true},
+		{&crossconnect.MonitorRequest{Labels: map[string]string{"app": "db"}}, false},
+		{&crossconnect.MonitorRequest{States: []local.State{local.State_DOWN}}, true},
+		// Both selectors match the source connection
+		{&crossconnect.MonitorRequest{NetworkService: "icmp", States: []local.State{local.State_UP}}, true},
+		// Selectors match different connections
+		{&crossconnect.MonitorRequest{Labels: map[string]string{"app": "web"}, NetworkServiceEndpointName: "nse-1"}, false},
+	} {
+		Expect(testCase.selector.Matches(xcon)).To(Equal(testCase.matches), "%v", testCase.selector)
+	}
+}
+
+func TestConnectionSelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	conn := &local.Connection{
+		Id:             "1",
+		NetworkService: "icmp",
+		Mechanism: &local.Mechanism{
+			Parameters: map[string]string{local.WorkspaceNSEName: "nse-1"},
+		},
+		Labels: map[string]string{"app": "web", "zone": "a"},
+		State:  local.State_UP,
+	}
+	Expect((&local.MonitorRequest{}).Matches(conn)).To(BeTrue())
+	Expect((&local.MonitorRequest{NetworkService: "icmp", ConnectionIds: []string{"1", "2"}}).Matches(conn)).To(BeTrue())
+	Expect((&local.MonitorRequest{NetworkServiceEndpointName: "nse-1", Labels: map[string]string{"app": "web"}}).Matches(conn)).To(BeTrue())
+	Expect((&local.MonitorRequest{NetworkServiceEndpointName: "nse-2"}).Matches(conn)).To(BeFalse())
+	Expect((&local.MonitorRequest{Labels: map[string]string{"app": "web", "zone": "b"}}).Matches(conn)).To(BeFalse())
+	Expect((&local.MonitorRequest{States: []local.State{local.State_DOWN}}).Matches(conn)).To(BeFalse())
+
+	remoteConn := &remote.Connection{
+		Id:                                   "1",
+		NetworkService:                       "icmp",
+		SourceNetworkServiceManagerName:      "nsm-1",
+		DestinationNetworkServiceManagerName: "nsm-2",
+	}
+	// Empty manager name does not select connections of other managers
+	Expect((&remote.MonitorScopeSelector{}).Matches(remoteConn)).To(BeFalse())
+	Expect((&remote.MonitorScopeSelector{NetworkServiceManagerName: "nsm-2"}).Matches(remoteConn)).To(BeTrue())
+	Expect((&remote.MonitorScopeSelector{NetworkServiceManagerName: "nsm-3"}).Matches(remoteConn)).To(BeFalse())
+	Expect((&remote.MonitorScopeSelector{NetworkServiceManagerName: "nsm-1", NetworkService: "vpn"}).Matches(remoteConn)).To(BeFalse())
+}
+
+func TestMonitorCrossConnectsFiltered(t *testing.T) {
+	RegisterTestingT(t)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	Expect(err).To(BeNil())
+	defer listener.Close()
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	monitor := crossconnect_monitor.NewCrossConnectMonitor()
+	crossconnect.RegisterMonitorCrossConnectServer(grpcServer, monitor)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(listenerAddress(listener), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(ctx, &crossconnect.MonitorRequest{
+		NetworkService: "icmp",
+	})
+	Expect(err).To(BeNil())
+
+	event, err := stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+
+	monitor.Update(testCrossConnect("1", "vpn"))
+	monitor.Update(testCrossConnect("2", "icmp"))
+
+	event, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.Sequence).To(Equal(uint64(2)))
+	Expect(event.CrossConnects).To(HaveLen(1))
+	Expect(event.CrossConnects).To(HaveKey("2"))
+}
+
+func TestMonitorCrossConnectsLeavingSelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	Expect(err).To(BeNil())
+	defer listener.Close()
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	monitor := crossconnect_monitor.NewCrossConnectMonitor()
+	crossconnect.RegisterMonitorCrossConnectServer(grpcServer, monitor)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(listenerAddress(listener), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(ctx, &crossconnect.MonitorRequest{
+		NetworkService: "icmp",
+	})
+	Expect(err).To(BeNil())
+
+	event, err := stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+
+	monitor.Update(testCrossConnect("1", "icmp"))
+	event, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.CrossConnects).To(HaveKey("1"))
+
+	// Cross connect is moved out of the selector, recipient should forget it
+	monitor.Update(testCrossConnect("1", "vpn"))
+	event, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_DELETE))
+	Expect(event.CrossConnects).To(HaveKey("1"))
+
+	// Not sent cross connects are not deleted, sent ones are deleted even if they do not match
+	monitor.Update(testCrossConnect("2", "icmp"))
+	monitor.Delete(testCrossConnect("1", "vpn"))
+	monitor.Delete(testCrossConnect("2", "vpn"))
+
+	event, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.CrossConnects).To(HaveKey("2"))
+
+	event, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_DELETE))
+	Expect(event.Sequence).To(Equal(uint64(5)))
+	Expect(event.CrossConnects).To(HaveKey("2"))
+}
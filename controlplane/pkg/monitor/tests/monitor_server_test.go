@@ -3,8 +3,8 @@ package tests
 import (
 	"context"
 	"fmt"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
@@ -12,6 +12,7 @@ import (
 	"net"
 	"sync"
 	"testing"
+	"time"
 )
 
 func startClient(target string) {
@@ -20,7 +21,7 @@ func startClient(target string) {
 
 	Expect(err).To(BeNil())
 	monitorClient := crossconnect.NewMonitorCrossConnectClient(conn)
-	stream, err := monitorClient.MonitorCrossConnects(context.Background(), &empty.Empty{})
+	stream, err := monitorClient.MonitorCrossConnects(context.Background(), &crossconnect.MonitorRequest{})
 	Expect(err).To(BeNil())
 
 	event, err := stream.Recv()
@@ -89,3 +90,226 @@ func TestSeveralRecipient(t *testing.T) {
 	wg.Wait()
 	logrus.Infof("######END")
 }
+
+type testRecipient struct {
+	events  chan *crossconnect.CrossConnectEvent
+	entered chan struct{}
+	block   chan struct{}
+}
+
+func newTestRecipient() *testRecipient {
+	return &testRecipient{
+		events: make(chan *crossconnect.CrossConnectEvent, 100),
+	}
+}
+
+func (r *testRecipient) SendMsg(msg interface{}) error {
+	if r.block != nil {
+		r.entered <- struct{}{}
+		<-r.block
+	}
+	r.events <- msg.(*crossconnect.CrossConnectEvent)
+	return nil
+}
+
+func (r *testRecipient) receive() *crossconnect.CrossConnectEvent {
+	select {
+	case event := <-r.events:
+		return event
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func (r *testRecipient) receiveUntil(sequence uint64) *crossconnect.CrossConnectEvent {
+	for {
+		event := r.receive()
+		Expect(event).NotTo(BeNil())
+		if event.Sequence == sequence {
+			return event
+		}
+	}
+}
+
+func startMonitorServer(queueSize, historySize int) (monitor.MonitorServer, *testRecipient) {
+	server := monitor.NewMonitorServerWithLimits(&crossconnect_monitor.CrossConnectEventConverter{}, queueSize, historySize)
+	go server.Serve()
+
+	// Events and recipients are served by different channels, observer is used to wait until events are applied
+	observer := newTestRecipient()
+	server.AddRecipient(observer)
+	event := observer.receive()
+	Expect(event).NotTo(BeNil())
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+	Expect(event.Sequence).To(Equal(uint64(0)))
+	return server, observer
+}
+
+func TestSequence(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, observer := startMonitorServer(10, 10)
+	server.Update(&crossconnect.CrossConnect{Id: "1"})
+	server.Update(&crossconnect.CrossConnect{Id: "2"})
+	server.Delete(&crossconnect.CrossConnect{Id: "1"})
+
+	for i, eventType := range []crossconnect.CrossConnectEventType{
+		crossconnect.CrossConnectEventType_UPDATE,
+		crossconnect.CrossConnectEventType_UPDATE,
+		crossconnect.CrossConnectEventType_DELETE,
+	} {
+		event := observer.receive()
+		Expect(event.Type).To(Equal(eventType))
+		Expect(event.Sequence).To(Equal(uint64(i + 1)))
+	}
+
+	recipient := newTestRecipient()
+	server.AddRecipient(recipient)
+	event := recipient.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+	Expect(event.Sequence).To(Equal(uint64(3)))
+	Expect(event.CrossConnects).To(HaveLen(1))
+	Expect(event.CrossConnects).To(HaveKey("2"))
+}
+
+func TestResume(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, observer := startMonitorServer(10, 10)
+	server.Update(&crossconnect.CrossConnect{Id: "1"})
+	server.Update(&crossconnect.CrossConnect{Id: "2"})
+	server.Delete(&crossconnect.CrossConnect{Id: "1"})
+	serverId := observer.receiveUntil(3).ServerId
+	Expect(serverId).NotTo(BeEmpty())
+
+	recipient := newTestRecipient()
+	server.AddRecipientFrom(recipient, serverId, 1)
+	event := recipient.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.Sequence).To(Equal(uint64(2)))
+	Expect(event.ServerId).To(Equal(serverId))
+	Expect(event.CrossConnects).To(HaveKey("2"))
+	event = recipient.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_DELETE))
+	Expect(event.Sequence).To(Equal(uint64(3)))
+
+	// Up to date recipient receives only new events
+	upToDate := newTestRecipient()
+	server.AddRecipientFrom(upToDate, serverId, 3)
+	server.Update(&crossconnect.CrossConnect{Id: "3"})
+	event = upToDate.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.Sequence).To(Equal(uint64(4)))
+}
+
+func TestResumeOutOfHistory(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, observer := startMonitorServer(10, 2)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		server.Update(&crossconnect.CrossConnect{Id: id})
+	}
+	serverId := observer.receiveUntil(4).ServerId
+
+	for _, sequence := range []uint64{1, 10} {
+		recipient := newTestRecipient()
+		server.AddRecipientFrom(recipient, serverId, sequence)
+		event := recipient.receive()
+		Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+		Expect(event.Sequence).To(Equal(uint64(4)))
+		Expect(event.CrossConnects).To(HaveLen(4))
+	}
+}
+
+func TestResumeOtherServer(t *testing.T) {
+	RegisterTestingT(t)
+
+	previous, observer := startMonitorServer(10, 10)
+	previous.Update(&crossconnect.CrossConnect{Id: "1"})
+	previousId := observer.receiveUntil(1).ServerId
+
+	// Restarted server has the same sequence numbers, but the recipient state is not valid for it
+	server, observer := startMonitorServer(10, 10)
+	server.Update(&crossconnect.CrossConnect{Id: "2"})
+	server.Update(&crossconnect.CrossConnect{Id: "3"})
+	serverId := observer.receiveUntil(2).ServerId
+	Expect(serverId).NotTo(Equal(previousId))
+
+	recipient := newTestRecipient()
+	server.AddRecipientFrom(recipient, previousId, 1)
+	event := recipient.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+	Expect(event.Sequence).To(Equal(uint64(2)))
+	Expect(event.ServerId).To(Equal(serverId))
+	Expect(event.CrossConnects).To(HaveLen(2))
+}
+
+func TestSlowRecipient(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, observer := startMonitorServer(2, 10)
+
+	slow := newTestRecipient()
+	slow.entered = make(chan struct{}, 10)
+	slow.block = make(chan struct{})
+	server.AddRecipient(slow)
+	<-slow.entered
+
+	// Slow recipient does not block the others
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		server.Update(&crossconnect.CrossConnect{Id: id})
+	}
+	observer.receiveUntil(5)
+
+	close(slow.block)
+	event := slow.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+	Expect(event.Sequence).To(Equal(uint64(0)))
+
+	// Queued events are dropped and replaced with the current state
+	event = slow.receive()
+	Expect(event.Type).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+	Expect(event.Sequence).To(Equal(uint64(5)))
+	Expect(event.CrossConnects).To(HaveLen(5))
+	Expect(slow.events).To(BeEmpty())
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	recipient *testRecipient
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) SendMsg(msg interface{}) error {
+	return s.recipient.SendMsg(msg)
+}
+
+func TestMonitorEntitiesClosedBeforeServed(t *testing.T) {
+	RegisterTestingT(t)
+
+	for i := 0; i < 10; i++ {
+		server := monitor.NewMonitorServer(&crossconnect_monitor.CrossConnectEventConverter{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		stream := &testServerStream{ctx: ctx, recipient: newTestRecipient()}
+
+		// Stream is added and deleted before the server starts, so both are pending together
+		done := make(chan error, 1)
+		go func() {
+			done <- server.MonitorEntities(stream)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		go server.Serve()
+
+		select {
+		case err := <-done:
+			Expect(err).To(BeNil())
+		case <-time.After(5 * time.Second):
+			t.Fatal("MonitorEntities does not return after the stream is closed")
+		}
+	}
+}
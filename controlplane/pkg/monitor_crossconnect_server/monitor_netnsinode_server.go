@@ -51,6 +51,8 @@ func copyEvent(event *crossconnect.CrossConnectEvent) *crossconnect.CrossConnect
 	return &crossconnect.CrossConnectEvent{
 		Type:          event.Type,
 		CrossConnects: crossConnectsCopy,
+		Sequence:      event.Sequence,
+		ServerId:      event.ServerId,
 	}
 }
 
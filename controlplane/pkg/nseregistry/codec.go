@@ -0,0 +1,61 @@
+package nseregistry
+
+import (
+	"fmt"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+)
+
+// The registry file is a record log of version 2, version 1 is the legacy tab separated file.
+// Record payload is a record type byte followed by the fields.
+const (
+	fileMagic   = "NSEREG"
+	fileVersion = uint16(2)
+)
+
+type recordType byte
+
+const (
+	recordClient recordType = iota + 1
+	recordNSE
+	recordDeleteClient
+	recordDeleteNSE
+)
+
+// record is a single registry change.
+type record struct {
+	Type   recordType
+	Fields [][]byte
+}
+
+// fieldCount is a number of fields of every record type.
+var fieldCount = map[recordType]int{
+	recordClient:       1, // workspace
+	recordNSE:          3, // endpoint name, workspace, NSERegistration
+	recordDeleteClient: 1, // workspace
+	recordDeleteNSE:    1, // endpoint name
+}
+
+func encodeRecord(r *record) ([]byte, error) {
+	if count, ok := fieldCount[r.Type]; !ok || count != len(r.Fields) {
+		return nil, fmt.Errorf("invalid record type %d with %d fields", r.Type, len(r.Fields))
+	}
+	return append([]byte{byte(r.Type)}, recordlog.EncodeFields(r.Fields...)...), nil
+}
+
+func decodeRecord(payload []byte) (*record, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("empty record")
+	}
+	r := &record{Type: recordType(payload[0])}
+	count, ok := fieldCount[r.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown record type %d", r.Type)
+	}
+	fields, err := recordlog.DecodeFields(payload[1:], count)
+	if err != nil {
+		return nil, fmt.Errorf("record type %d: %v", r.Type, err)
+	}
+	r.Fields = fields
+	return r, nil
+}
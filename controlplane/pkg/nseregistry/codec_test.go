@@ -0,0 +1,72 @@
+package nseregistry
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzRecordRoundTrip(f *testing.F) {
+	f.Add(byte(recordClient), []byte("nsm-1"), []byte{}, []byte{})
+	f.Add(byte(recordNSE), []byte("endpoint1"), []byte("nsm-1"), []byte{0x0a, 0x02, 'A', '\n'})
+	f.Add(byte(recordDeleteNSE), []byte("end\tpoint\n"), []byte{}, []byte{})
+	f.Fuzz(func(t *testing.T, recType byte, field1, field2, field3 []byte) {
+		r := &record{Type: recordType(recType)}
+		count, ok := fieldCount[r.Type]
+		if !ok {
+			if _, err := encodeRecord(r); err == nil {
+				t.Fatalf("record of unknown type %d is encoded", recType)
+			}
+			return
+		}
+		r.Fields = [][]byte{field1, field2, field3}[:count]
+
+		payload, err := encodeRecord(r)
+		if err != nil {
+			t.Fatalf("failed to encode %v: %v", r, err)
+		}
+		decoded, err := decodeRecord(payload)
+		if err != nil {
+			t.Fatalf("failed to decode %v: %v", r, err)
+		}
+		if decoded.Type != r.Type || len(decoded.Fields) != count {
+			t.Fatalf("decoded %v, expected %v", decoded, r)
+		}
+		for i := range r.Fields {
+			if !bytes.Equal(decoded.Fields[i], r.Fields[i]) {
+				t.Fatalf("field %d decoded as %q, expected %q", i, decoded.Fields[i], r.Fields[i])
+			}
+		}
+	})
+}
+
+func FuzzDecodeRecord(f *testing.F) {
+	valid, _ := encodeRecord(&record{Type: recordNSE, Fields: [][]byte{[]byte("endpoint1"), []byte("nsm-1"), {}}})
+	f.Add(valid)
+	f.Add(valid[:len(valid)-1])
+	f.Add([]byte{byte(recordClient), 0xff})
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		r, err := decodeRecord(payload)
+		if err != nil {
+			return
+		}
+		// Decoded record is encoded back to the same bytes.
+		encoded, err := encodeRecord(r)
+		if err != nil {
+			t.Fatalf("failed to encode decoded record %v: %v", r, err)
+		}
+		if !bytes.Equal(encoded, payload) {
+			t.Fatalf("record %v is encoded as %v, decoded from %v", r, encoded, payload)
+		}
+	})
+}
+
+func FuzzLegacyDecode(f *testing.F) {
+	f.Add([]byte("CLE\tnsm-1\nNSE\tendpoint1\tnsm-1\tCgA=\n"))
+	f.Add([]byte("CLE\tnsm\\r,1\n\n\t\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		state := decodeLegacy(data)
+		if state == nil || state.nses == nil {
+			t.Fatalf("legacy file is decoded to nil state")
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package nseregistry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+	"github.com/sirupsen/logrus"
+)
+
+// Operations of the legacy tab separated registry file, it is only read to migrate it to the current format.
+const (
+	ClientRegistered = "CLE"
+	NSERegistered    = "NSE"
+)
+
+// legacyUnescape reverts escaping of the legacy format. The legacy writer used to escape only '\r' as "\\r,"
+// because of the bug, but the other sequences are accepted as well.
+func legacyUnescape(s string) string {
+	return strings.NewReplacer(
+		"\\t,", "\t", "\\n,", "\n", "\\r,", "\r",
+		"\\t", "\t", "\\n", "\n", "\\r", "\r",
+	).Replace(s)
+}
+
+// decodeLegacy reads the legacy registry file, broken lines are skipped.
+func decodeLegacy(data []byte) *registryState {
+	state := newRegistryState()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, recordlog.MaxRecordSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		values := strings.Split(line, "\t")
+		for idx, value := range values {
+			values[idx] = legacyUnescape(value)
+		}
+
+		switch {
+		case values[0] == ClientRegistered && len(values) == 2:
+			state.addClient(values[1])
+		case values[0] == NSERegistered && len(values) == 4:
+			raw, err := base64.StdEncoding.DecodeString(values[3])
+			if err != nil {
+				logrus.Errorf("Failed to decode legacy NSE registration of %s: %v", values[1], err)
+				continue
+			}
+			nseReg := &registry.NSERegistration{}
+			if err := proto.Unmarshal(raw, nseReg); err != nil {
+				logrus.Errorf("Failed to decode legacy NSE registration message of %s: %v", values[1], err)
+				continue
+			}
+			state.nses[values[1]] = NSEEntry{
+				Workspace: values[2],
+				NseReg:    nseReg,
+			}
+		default:
+			logrus.Errorf("Unknown legacy registry file line: %v", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		logrus.Errorf("Failed to read legacy registry file: %v", err)
+	}
+	return state
+}
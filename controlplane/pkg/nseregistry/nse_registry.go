@@ -1,225 +1,282 @@
 package nseregistry
 
 import (
-	"bufio"
-	"encoding/base64"
-	"github.com/gogo/protobuf/proto"
-	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
-	"github.com/sirupsen/logrus"
-	"io"
+	"fmt"
 	"os"
-	"strings"
 	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/recordlog"
+	"github.com/sirupsen/logrus"
 )
-const(
-	ClientRegistered = "CLE"
-	NSERegistered = "NSE"
+
+const (
+	// compactMinRecords is a number of records the file could have before it is compacted
+	compactMinRecords = 64
 )
+
 type NSERegistry struct {
-	lock sync.Mutex
-	file string
+	lock  sync.Mutex
+	log   *recordlog.Log
+	state *registryState
+	// records is a number of records in the file
+	records int
 }
 
 type NSEEntry struct {
 	Workspace string
-	NseReg *registry.NSERegistration
+	NseReg    *registry.NSERegistration
 }
 
-func NewNSERegistry(file string) *NSERegistry {
-	return &NSERegistry{file: file}
+// registryState is the registry content, the file is a log of its changes.
+type registryState struct {
+	clients []string
+	nses    map[string]NSEEntry
 }
 
-func store(values ... string) string {
-	res := ""
-	for _, s := range(values) {
-		if len(res) > 0 {
-			res += "\t"
-		}
-		ss := strings.Replace(s, "\t", "\\t,", -1)
-		ss = strings.Replace(s, "\n", "\\n,", -1) // Just in case
-		ss = strings.Replace(s, "\r", "\\r,", -1) // Just in case
-		res += ss
+func newRegistryState() *registryState {
+	return &registryState{
+		nses: map[string]NSEEntry{},
 	}
-	return res
 }
-func unescape(s string) string {
-	s = strings.Replace(s, "\\t", "\t", -1)
-	s = strings.Replace(s, "\\n", "\n", -1)
-	s = strings.Replace(s, "\\r", "\r", -1)
-	return s
-}
-func restore(inputS string) []string {
-	ts := strings.TrimSpace(inputS)
-	segments := strings.SplitN(ts, "\t", -1)
-	for idx, sm := range(segments) {
-		segments[idx] = unescape(sm)
+
+func (s *registryState) addClient(workspace string) {
+	for _, ws := range s.clients {
+		if ws == workspace {
+			return
+		}
 	}
-	return segments
+	s.clients = append(s.clients, workspace)
 }
 
-/**
-	We adding a registration for client.
- */
-func (reg *NSERegistry) writeLine(op ...string) error {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	f, err := os.OpenFile(reg.file, os.O_APPEND|os.O_WRONLY|os.O_SYNC|os.O_CREATE, 0600)
-	if err != nil {
-		logrus.Errorf("Failed to store Client information")
-		return err
+func (s *registryState) deleteClient(workspace string) {
+	clients := s.clients[:0]
+	for _, ws := range s.clients {
+		if ws != workspace {
+			clients = append(clients, ws)
+		}
 	}
+	s.clients = clients
+	for endpointId, entry := range s.nses {
+		if entry.Workspace == workspace {
+			delete(s.nses, endpointId)
+		}
+	}
+}
 
-	defer f.Close()
-
-	if _, err = f.WriteString(store(op...) + "\n"); err != nil {
-		return err
+func (s *registryState) apply(r *record) error {
+	switch r.Type {
+	case recordClient:
+		s.addClient(string(r.Fields[0]))
+	case recordNSE:
+		nseReg := &registry.NSERegistration{}
+		if err := proto.Unmarshal(r.Fields[2], nseReg); err != nil {
+			return fmt.Errorf("failed to decode NSE registration of %s: %v", r.Fields[0], err)
+		}
+		s.nses[string(r.Fields[0])] = NSEEntry{
+			Workspace: string(r.Fields[1]),
+			NseReg:    nseReg,
+		}
+	case recordDeleteClient:
+		s.deleteClient(string(r.Fields[0]))
+	case recordDeleteNSE:
+		delete(s.nses, string(r.Fields[0]))
 	}
-	_ = f.Sync()
 	return nil
 }
-func (reg *NSERegistry) AppendClientRequest(workspace string) error {
-	return reg.writeLine(ClientRegistered, workspace)
+
+// snapshot returns records recreating the state.
+func (s *registryState) snapshot() ([]*record, error) {
+	var records []*record
+	for _, workspace := range s.clients {
+		records = append(records, clientRecord(workspace))
+	}
+	for endpointId, entry := range s.nses {
+		r, err := nseRecord(endpointId, entry.Workspace, entry.NseReg)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
 }
 
-func (reg *NSERegistry) AppendNSERegRequest(workspace string, nseReg *registry.NSERegistration) error {
-	return reg.writeLine(NSERegistered, nseReg.NetworkserviceEndpoint.EndpointName, workspace, reg.storeNSEBase64(nseReg)) // Few workspaces could contain few NSEs
+func (s *registryState) copy() ([]string, map[string]NSEEntry) {
+	clients := append([]string{}, s.clients...)
+	nses := map[string]NSEEntry{}
+	for endpointId, entry := range s.nses {
+		nses[endpointId] = NSEEntry{
+			Workspace: entry.Workspace,
+			NseReg:    proto.Clone(entry.NseReg).(*registry.NSERegistration),
+		}
+	}
+	return clients, nses
 }
 
-func (reg *NSERegistry) storeNSEBase64(nseReg *registry.NSERegistration) string {
-	bytes, err := proto.Marshal(nseReg)
+func clientRecord(workspace string) *record {
+	return &record{Type: recordClient, Fields: [][]byte{[]byte(workspace)}}
+}
+
+func nseRecord(endpointId, workspace string, nseReg *registry.NSERegistration) (*record, error) {
+	data, err := proto.Marshal(nseReg)
 	if err != nil {
-		logrus.Errorf("Failed to serialize NSE passed %v", err)
+		return nil, fmt.Errorf("failed to serialize NSE registration of %s: %v", endpointId, err)
 	}
-	strData := base64.StdEncoding.EncodeToString(bytes)
-	return strData
+	return &record{Type: recordNSE, Fields: [][]byte{[]byte(endpointId), []byte(workspace), data}}, nil
 }
 
-func (reg *NSERegistry) DeleteNSE(endpointid string) error {
+func NewNSERegistry(file string) *NSERegistry {
+	return &NSERegistry{log: recordlog.New(file, fileMagic, fileVersion)}
+}
+
+func (reg *NSERegistry) AppendClientRequest(workspace string) error {
 	reg.lock.Lock()
 	defer reg.lock.Unlock()
-	clients, nses, err := reg.loadRegistry()
+	return reg.append(clientRecord(workspace))
+}
+
+func (reg *NSERegistry) AppendNSERegRequest(workspace string, nseReg *registry.NSERegistration) error {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+	r, err := nseRecord(nseReg.NetworkserviceEndpoint.EndpointName, workspace, nseReg) // Few workspaces could contain few NSEs
 	if err != nil {
 		return err
 	}
-	delete(nses, endpointid)
+	return reg.append(r)
+}
 
-	return reg.Save(clients, nses)
+func (reg *NSERegistry) DeleteNSE(endpointid string) error {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+	return reg.append(&record{Type: recordDeleteNSE, Fields: [][]byte{[]byte(endpointid)}})
 }
 
-/**
-	Delete client workspace and all NSEs registered.
- */
+// DeleteClient deletes client workspace and all NSEs registered.
 func (reg *NSERegistry) DeleteClient(workspace string) error {
 	reg.lock.Lock()
 	defer reg.lock.Unlock()
-	clients, nses, err := reg.loadRegistry()
-	if err != nil {
-		return err
-	}
-	for idx, ws := range(clients) {
-		if ws == workspace {
-			clients = append(clients[:idx], clients[idx+1:]...)
-		}
-	}
+	return reg.append(&record{Type: recordDeleteClient, Fields: [][]byte{[]byte(workspace)}})
+}
 
-	for endpointId, entry := range(nses) {
-		if entry.Workspace == workspace {
-			delete(nses, endpointId)
-		}
+func (reg *NSERegistry) LoadRegistry() (clients []string, nses map[string]NSEEntry, err error) {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+	if err := reg.load(); err != nil {
+		return nil, map[string]NSEEntry{}, err
 	}
-
-
-	return reg.Save(clients, nses)
+	clients, nses = reg.state.copy()
+	logrus.Infof("Clients: %v", clients)
+	logrus.Infof("NSEs: %v", nses)
+	return clients, nses, nil
 }
 
-func (reg *NSERegistry) LoadRegistry() (clients []string, nses map[string]NSEEntry, err error ) {
+// Save atomically replaces the registry file with the passed workspaces and NSEs.
+func (reg *NSERegistry) Save(clients []string, nses map[string]NSEEntry) error {
 	reg.lock.Lock()
 	defer reg.lock.Unlock()
-	return reg.loadRegistry()
+	state := newRegistryState()
+	for _, workspace := range clients {
+		state.addClient(workspace)
+	}
+	for endpointId, entry := range nses {
+		state.nses[endpointId] = entry
+	}
+	return reg.compact(state)
 }
 
-func (reg *NSERegistry) loadRegistry() (clients []string, nses map[string]NSEEntry, res_err error ) {
-	nses = map[string]NSEEntry{}
+func (reg *NSERegistry) Delete() {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+	reg.state = nil
+	reg.records = 0
+	reg.log.Delete()
+}
 
-	f, err := os.OpenFile(reg.file, os.O_RDONLY, 0600)
-	if err != nil {
+// load reads the file once, legacy, missing or broken files are rewritten in the current format,
+// so records could be appended to it.
+func (reg *NSERegistry) load() error {
+	if reg.state != nil {
+		return nil
+	}
+	payloads, err := reg.log.Read()
+	if os.IsNotExist(err) {
 		logrus.Infof("No stored registry file exists")
-		return
+		return reg.compact(newRegistryState())
+	}
+	if noHeader, ok := err.(*recordlog.NoHeaderError); ok {
+		logrus.Infof("Migrating legacy registry file %s", reg.log.File())
+		return reg.compact(decodeLegacy(noHeader.Data))
+	}
+	if _, broken := err.(*recordlog.BrokenError); err != nil && !broken {
+		return err
 	}
-	defer f.Close()
 
-	reader := bufio.NewReader(f)
-	for {
-		r, err := reader.ReadString('\n');
-		if err != nil {
-			if err != io.EOF {
-				res_err = err
-				return
-			}
-			// End of file
-			break
+	state := newRegistryState()
+	for idx, payload := range payloads {
+		r, decodeErr := decodeRecord(payload)
+		if decodeErr == nil {
+			decodeErr = state.apply(r)
 		}
-		values := restore(r)
-		logrus.Printf("Values: %v", values)
-		if len(values) > 0 {
-			if values[0] == ClientRegistered && len(values) == 2 {
-				clients = append(clients, values[1])
-			} else if values[0] == NSERegistered && len(values) == 4 {
-				entry := NSEEntry{}
-				entry.Workspace = values[2]
-				strData := values[3]
-				bytes, err := base64.StdEncoding.DecodeString(strData)
-				if err != nil {
-					logrus.Errorf("Failed to decode NSE registration %v", err)
-				}
-				nseReg := &registry.NSERegistration{}
-				err = proto.Unmarshal(bytes, nseReg)
-				if err != nil {
-					logrus.Errorf("Failed to decode nse registration message %v", err)
-				}
-				entry.NseReg = nseReg
-				nses[values[1]] = entry
-			} else {
-				logrus.Errorf("Unknown registry file line: %v", r)
-			}
+		if decodeErr != nil {
+			payloads, err = payloads[:idx], decodeErr
+			break
 		}
 	}
-	logrus.Infof("Clients: %v", clients)
-	logrus.Infof("NSEs: %v", nses)
-
-	return
+	if err != nil {
+		// Only the records written before the broken one are trusted, drop the rest.
+		logrus.Errorf("Registry file %s is broken, %d records are restored: %v", reg.log.File(), len(payloads), err)
+		return reg.compact(state)
+	}
+	reg.state = state
+	reg.records = len(payloads)
+	return nil
 }
 
-/**
-	Saves memory model info file
- */
-func (reg *NSERegistry) Save(clients []string, nses map[string]NSEEntry) error {
-	tmpFile := reg.file + "_tmp"
-	f, err := os.OpenFile(tmpFile, os.O_APPEND|os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+func (reg *NSERegistry) append(r *record) error {
+	if err := reg.load(); err != nil {
+		return err
+	}
+	payload, err := encodeRecord(r)
 	if err != nil {
-		logrus.Errorf("Failed to store Client information")
 		return err
 	}
-
-	for _, workspace := range clients {
-		if _, err = f.WriteString(store(ClientRegistered, workspace) + "\n"); err != nil {
-			return err
-		}
+	if err := reg.state.apply(r); err != nil {
+		return err
+	}
+	reg.records++
+	if reg.records > compactMinRecords && reg.records > 2*(len(reg.state.clients)+len(reg.state.nses)) {
+		err = reg.compact(reg.state)
+	} else {
+		err = reg.log.Append(payload)
 	}
+	if err != nil {
+		// The file content is unknown, read it again on the next access.
+		logrus.Errorf("Failed to store registry record: %v", err)
+		reg.state = nil
+	}
+	return err
+}
 
-	for endpointId, entry := range nses {
-		if _, err = f.WriteString(store(NSERegistered, endpointId, entry.Workspace, reg.storeNSEBase64(entry.NseReg)) + "\n"); err != nil {
+// compact atomically replaces the file with the state snapshot.
+func (reg *NSERegistry) compact(state *registryState) error {
+	records, err := state.snapshot()
+	if err != nil {
+		return err
+	}
+	var payloads [][]byte
+	for _, r := range records {
+		payload, err := encodeRecord(r)
+		if err != nil {
 			return err
 		}
+		payloads = append(payloads, payload)
 	}
-	if err = f.Close(); err != nil {
+	if err := reg.log.Rewrite(payloads); err != nil {
+		logrus.Errorf("Failed to store registry: %v", err)
 		return err
 	}
-	// Now we need to replace existing file with new one.
-	return os.Rename(tmpFile, reg.file)
-}
-
-func (reg *NSERegistry) Delete() {
-	_ = os.Remove(reg.file)
+	reg.state = state
+	reg.records = len(records)
+	return nil
 }
-
@@ -0,0 +1,95 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+)
+
+// findChainHop resolves a chain hop requested by connection labels, connection labels are updated to request
+// the next hop by the selected endpoint. Returned response contains endpoints of the hop matching hop labels.
+func (srv *networkServiceManager) findChainHop(ctx context.Context, discoveryClient registry.NetworkServiceDiscoveryClient, requestConnection nsm.NSMConnection, response *registry.FindNetworkServiceResponse) (*registry.FindNetworkServiceResponse, error) {
+	networkService := response.GetNetworkService()
+	index, hop, err := networkService.GetChainHop(requestConnection.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("NSM: Network service %s chain hop %d: %v", networkService.GetName(), index, hop)
+
+	hopResponse := response
+	if hop.GetNetworkService() != networkService.GetName() {
+		hopResponse, err = discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+			NetworkServiceName: hop.GetNetworkService(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find network service %s of chain %s hop %d: %v", hop.GetNetworkService(), networkService.GetName(), index, err)
+		}
+	}
+
+	endpoints := []*registry.NetworkServiceEndpoint{}
+	for _, endpoint := range hopResponse.GetNetworkServiceEndpoints() {
+		if hop.IsMatching(endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range requestConnection.GetLabels() {
+		if k != registry.ChainServiceLabel && k != registry.ChainHopLabel {
+			labels[k] = v
+		}
+	}
+	for k, v := range networkService.NextChainHopLabels(index) {
+		labels[k] = v
+	}
+	setConnectionLabels(requestConnection, labels)
+
+	return &registry.FindNetworkServiceResponse{
+		Payload:                 hopResponse.GetPayload(),
+		NetworkService:          hopResponse.GetNetworkService(),
+		NetworkServiceManagers:  hopResponse.GetNetworkServiceManagers(),
+		NetworkServiceEndpoints: endpoints,
+	}, nil
+}
+
+func setConnectionLabels(requestConnection nsm.NSMConnection, labels map[string]string) {
+	switch conn := requestConnection.(type) {
+	case *connection.Connection:
+		conn.Labels = labels
+	case *remote_connection.Connection:
+		conn.Labels = labels
+	}
+}
+
+// destinationLabels returns labels passed to the endpoint of the cross connect, false is returned if cross connect
+// has no destination.
+func destinationLabels(xcon *crossconnect.CrossConnect) (map[string]string, bool) {
+	if dst := xcon.GetLocalDestination(); dst != nil {
+		return dst.GetLabels(), true
+	}
+	if dst := xcon.GetRemoteDestination(); dst != nil {
+		return dst.GetLabels(), true
+	}
+	return nil, false
+}
@@ -14,7 +14,6 @@
 package nsm
 
 import (
-	"crypto/rand"
 	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
@@ -26,9 +25,14 @@ import (
 	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"sync"
 	"time"
 )
 
@@ -45,6 +49,12 @@ type networkServiceManager struct {
 	excludedPrefixes []string
 	properties       *nsm.HealTimeouts
 	stateRestored    chan bool
+
+	restoreLock sync.Mutex
+	// restored contains ids of checkpointed connections waiting for the dataplane state
+	restored map[string]bool
+	// awaitingRequest contains ids of checkpointed connections waiting for the request from remote NSM
+	awaitingRequest map[string]bool
 }
 
 func (srv *networkServiceManager) GetHealProperties() *nsm.HealTimeouts {
@@ -66,29 +76,22 @@ func (srv *networkServiceManager) Request(ctx context.Context, request nsm.NSMRe
 	return srv.request(ctx, request, srv.model.GetClientConnection(request.GetConnectionId()))
 }
 
-func create_logid() (uuid string) {
-	b := make([]byte, 4)
-	_, err := rand.Read(b)
-	if err != nil {
-		logrus.Errorf("Error: %v", err)
-		return
-	}
+func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRequest, existingConnection *model.ClientConnection) (_ nsm.NSMConnection, err error) {
+	ctx, requestID := tools.EnsureRequestID(ctx)
+	span, ctx := startSpan(ctx, spanRequest)
+	defer func() { finishSpan(span, err) }()
+	span.SetTag(tools.LogFieldRequestID, requestID)
 
-	uuid = fmt.Sprintf("%X", b[0:4])
-	return
-}
-
-func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRequest, existingConnection *model.ClientConnection) (nsm.NSMConnection, error) {
-	requestId := create_logid()
-	logrus.Infof("NSM:(%v) request: %v", requestId, request)
+	logger := tools.Log(ctx)
+	logger.Infof("NSM: request: %v", request)
 	if existingConnection != nil {
-		logrus.Infof("NSM:(%v) Called with existing connection passed: %v", requestId, existingConnection)
+		logger.Infof("NSM: Called with existing connection passed: %v", existingConnection)
 	}
 
 	// 0. Make sure its a valid request
-	err := request.IsValid()
+	err = request.IsValid()
 	if err != nil {
-		logrus.Error(err)
+		logger.Error(err)
 		return nil, err
 	}
 
@@ -103,12 +106,22 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 		// 2.1 we have connection updata/heal no need for new connection id
 		nsmConnection.SetId(existingConnection.GetId())
 	}
+	logger = logger.WithFields(logrus.Fields{
+		tools.LogFieldConnectionID:   nsmConnection.GetId(),
+		tools.LogFieldNetworkService: nsmConnection.GetNetworkService(),
+	})
+	span.SetTag(tools.LogFieldConnectionID, nsmConnection.GetId())
+	span.SetTag(tools.LogFieldNetworkService, nsmConnection.GetNetworkService())
 
 	// 3. get dataplane
+	dpSpan, _ := startSpan(ctx, spanSelectDataplane)
 	dp, err := srv.model.SelectDataplane()
+	finishSpan(dpSpan, err)
 	if err != nil {
+		logger.Errorf("NSM:(3) Failed to select dataplane: %v", err)
 		return nil, err
 	}
+	logger = logger.WithField(tools.LogFieldDataplane, dp.RegisteredName)
 
 	// A flag if we heal to close Dataplane in case of no NSE is found or failed to establish new connection.
 	closeDataplaneOnNSEFailed := false
@@ -123,28 +136,28 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 			closeDataplaneOnNSEFailed = true
 			// Network service is closing, we need to close remote NSM and re-programm local one.
 			if err := srv.close(ctx, existingConnection, false, false); err != nil {
-				logrus.Errorf("NSM:(4.1-%v) Error during close of NSE during Request.Upgrade %v Existing connection: %v error %v", requestId, request, existingConnection, err)
+				logger.Errorf("NSM:(4.1) Error during close of NSE during Request.Upgrade %v Existing connection: %v error %v", request, existingConnection, err)
 			}
 		} else {
 			// 4.2 Check if NSE is still required, if some more context requests are different.
-			requestNSEOnUpdate = srv.checkNeedNSERequest(requestId, nsmConnection, existingConnection, dp)
+			requestNSEOnUpdate = srv.checkNeedNSERequest(logger, nsmConnection, existingConnection, dp)
 		}
 	}
 
 	// 5. Select a local dataplane and put it into nsmConnection object
-	err = srv.updateMechanism(requestId, nsmConnection, request, dp)
+	err = srv.updateMechanism(logger, nsmConnection, request, dp)
 	if err != nil {
 		// 5.1 Close Datplane connection, if had existing one and NSE is closed.
 		if closeDataplaneOnNSEFailed {
 			if dp_err := srv.closeDataplane(existingConnection); dp_err != nil {
-				logrus.Errorf("NSM:(5.1-%v) Failed to close local Dataplane for connection %v", requestId, existingConnection)
+				logger.Errorf("NSM:(5.1) Failed to close local Dataplane for connection %v", existingConnection)
 			}
 		}
 		return nil, err
 	}
 
 	// 6. Prepare dataplane connection is fine.
-	logrus.Infof("NSM:(6-%v) Preparing to program dataplane: %v...", requestId, dp)
+	logger.Infof("NSM:(6) Preparing to program dataplane: %v...", dp)
 	dataplaneClient, dataplaneConn, err := srv.serviceRegistry.DataplaneConnection(dp)
 	if err != nil {
 		return nil, err
@@ -153,7 +166,7 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 		defer func() {
 			err := dataplaneConn.Close()
 			if err != nil {
-				logrus.Errorf("NSM:(6.1-%v) Error during close Dataplane connection: %v", requestId, err)
+				logger.Errorf("NSM:(6.1) Error during close Dataplane connection: %v", err)
 			}
 		}()
 	}
@@ -165,12 +178,14 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	// 7. do a Request() on NSE and select it.
 	if existingConnection == nil || requestNSEOnUpdate {
 		//7.1 try find NSE and do a Request to it.
-		clientConnection, err = srv.findConnectNSE(requestId, ctx, ignore_endpoints, request, nsmConnection, existingConnection, dp)
+		findSpan, findCtx := startSpan(ctx, spanFindConnectNSE)
+		clientConnection, err = srv.findConnectNSE(logger, findCtx, ignore_endpoints, request, nsmConnection, existingConnection, dp)
+		finishSpan(findSpan, err)
 		if err != nil {
 			if closeDataplaneOnNSEFailed {
 				// 7.1.x We are failed to find NSE, and we need to close local dataplane in case of recovery.
 				if dp_err := srv.closeDataplane(existingConnection); dp_err != nil {
-					logrus.Errorf("NSM:(7.1-%v) Failed to close local Dataplane for connection %v", requestId, existingConnection)
+					logger.Errorf("NSM:(7.1) Failed to close local Dataplane for connection %v", existingConnection)
 				}
 			}
 			if existingConnection != nil {
@@ -180,21 +195,28 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 		}
 	} else if existingConnection != nil {
 		// 7.2 We do not need to access NSE, since all parameters are same.
-		if request.IsRemote() {
-			// 7.2.1 We are called from remote NSM so just copy.
-			rs := clientConnection.Xcon.GetRemoteSource()
-			rs.Mechanism = nsmConnection.(*remote_connection.Connection).Mechanism
-			rs.State = remote_connection.State_UP
-		} else {
-			// 7.2.2 It is local connection from NSC, so just copy values.
-			ls := clientConnection.Xcon.GetLocalSource()
-			ls.Mechanism = nsmConnection.(*connection.Connection).Mechanism
-			ls.State = connection.State_UP
-		}
+		srv.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+			if request.IsRemote() {
+				// 7.2.1 We are called from remote NSM so just copy.
+				rs := xcon.GetRemoteSource()
+				rs.Mechanism = nsmConnection.(*remote_connection.Connection).Mechanism
+				rs.State = remote_connection.State_UP
+			} else {
+				// 7.2.2 It is local connection from NSC, so just copy values.
+				ls := xcon.GetLocalSource()
+				ls.Mechanism = nsmConnection.(*connection.Connection).Mechanism
+				ls.State = connection.State_UP
+			}
+		})
 	}
 
 	// 8. Remember original Request for Heal cases.
-	clientConnection.Request = request
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.Request = request
+	})
+	if existingConnection != nil {
+		srv.requestReceived(existingConnection.GetId())
+	}
 
 	// 9. We need Add connection to model, or update it in case of Healing.
 	if existingConnection == nil {
@@ -204,82 +226,110 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	// 10. We need to programm dataplane with our values.
 	// 10.1 TODO: Close current dataplane local configuration, since currently Dataplane doesn't support upgrade.
 	if existingConnection != nil {
-		logrus.Errorf("NSM:(10.0-%v) Closing Dataplane because of existing connection passed...", requestId)
+		logger.Errorf("NSM:(10.0) Closing Dataplane because of existing connection passed...")
 		if err := srv.closeDataplane(existingConnection); err != nil {
-			logrus.Errorf("NSM:(10.1-%v) Closing Dataplane error for local connection: %v", requestId, err)
+			logger.Errorf("NSM:(10.1) Closing Dataplane error for local connection: %v", err)
 		}
 	}
+	// 10.2 Sending updated request to dataplane.
+	if err = srv.programDataplane(logger, ctx, dataplaneClient, clientConnection); err != nil {
+		return nil, err
+	}
+
+	// 11. Send update for client connection
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.ConnectionState = model.ClientConnection_Ready
+		clientConnection.DataplaneState = model.DataplaneState_Ready
+	})
+	if existingConnection != nil {
+		srv.model.UpdateClientConnection(clientConnection)
+	}
+
+	// 11. We are done with configuration here.
+	if request.IsRemote() {
+		nsmConnection = clientConnection.Xcon.GetSource().(*crossconnect.CrossConnect_RemoteSource).RemoteSource
+	} else {
+		nsmConnection = clientConnection.Xcon.GetSource().(*crossconnect.CrossConnect_LocalSource).LocalSource
+	}
+	logger.Infof("NSM:(11) Request done...")
+	return nsmConnection, nil
+}
+
+// changeXcon changes a copy of the connection cross connect, since the cross connect is shared with monitors.
+func (srv *networkServiceManager) changeXcon(clientConnection *model.ClientConnection, changeFunc func(*crossconnect.CrossConnect)) {
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		xcon := proto.Clone(clientConnection.Xcon).(*crossconnect.CrossConnect)
+		changeFunc(xcon)
+		clientConnection.Xcon = xcon
+	})
+}
+
+// programDataplane sends cross connect request to dataplane, retrying DataplaneRetryCount times.
+func (srv *networkServiceManager) programDataplane(logger *logrus.Entry, ctx context.Context, dataplaneClient dataplaneapi.DataplaneClient, clientConnection *model.ClientConnection) (err error) {
+	span, ctx := startSpan(ctx, spanProgramDataplane)
+	defer func() { finishSpan(span, err) }()
+
 	// 10.2 Sending updated request to dataplane.
 	for dpRetry := 0; dpRetry < DataplaneRetryCount; dpRetry++ {
 		if err := ctx.Err(); err != nil {
-			srv.handleDataplaneContextTimeout(requestId, err, clientConnection)
-			return nil, ctx.Err()
+			srv.handleDataplaneContextTimeout(logger, err, clientConnection)
+			return ctx.Err()
 		}
 
-		logrus.Infof("NSM:(10.2-%v) Sending request to dataplane: %v retry: %v", requestId, clientConnection.Xcon, dpRetry)
-		dpCtx, cancel := context.WithTimeout(context.Background(), DataplaneTimeout)
+		logger.Infof("NSM:(10.2) Sending request to dataplane: %v retry: %v", clientConnection.Xcon, dpRetry)
+		retrySpan, _ := startSpan(ctx, spanDataplaneRequest)
+		retrySpan.SetTag("retry", dpRetry)
+		dpCtx, cancel := context.WithTimeout(tools.WithRequestID(context.Background(), tools.RequestID(ctx)), DataplaneTimeout)
 		defer cancel()
-		newXcon, err := dataplaneClient.Request(dpCtx, clientConnection.Xcon)
+		newXcon, err := dataplaneClient.Request(opentracing.ContextWithSpan(dpCtx, retrySpan), clientConnection.Xcon)
+		finishSpan(retrySpan, err)
 		if err != nil {
-			logrus.Errorf("NSM:(10.2.1-%v) Dataplane request failed: %v retry: %v", requestId, err, dpRetry)
+			logger.Errorf("NSM:(10.2.1) Dataplane request failed: %v retry: %v", err, dpRetry)
 
 			// Let's try again with a short delay
 			if dpRetry < DataplaneRetryCount-1 {
 				<-time.Tick(DataplaneRetryDelay)
 
 				if dp_err := srv.closeDataplane(clientConnection); dp_err != nil {
-					logrus.Errorf("NSM:(10.2.4-%v) Failed to NSE.Close() caused by local dataplane configuration failure: %v", requestId, dp_err)
+					logger.Errorf("NSM:(10.2.4) Failed to NSE.Close() caused by local dataplane configuration failure: %v", dp_err)
 				}
 				continue
 			}
-			logrus.Errorf("NSM:(10.2.2-%v) Dataplane request  all retry attempts failed: %v", requestId, clientConnection.Xcon)
+			logger.Errorf("NSM:(10.2.2) Dataplane request  all retry attempts failed: %v", clientConnection.Xcon)
 			// 10.3 If datplane configuration are failed, we need to close remore NSE actually.
 			if dp_err := srv.close(context.Background(), clientConnection, false, false); dp_err != nil {
-				logrus.Errorf("NSM:(10.2.4-%v) Failed to NSE.Close() caused by local dataplane configuration failure: %v", requestId, dp_err)
+				logger.Errorf("NSM:(10.2.4) Failed to NSE.Close() caused by local dataplane configuration failure: %v", dp_err)
 			}
 			// 10.4 We need to remove local connection we just added already.
 			srv.model.DeleteClientConnection(clientConnection.ConnectionId)
-			return nil, err
+			return err
 		}
-		clientConnection.Xcon = newXcon
+		srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+			clientConnection.Xcon = newXcon
+		})
 
 		// In case of context deadline, we need to close NSE and dataplane.
 		if err := ctx.Err(); err != nil {
-			srv.handleDataplaneContextTimeout(requestId, err, clientConnection)
-			return nil, ctx.Err()
+			srv.handleDataplaneContextTimeout(logger, err, clientConnection)
+			return ctx.Err()
 		}
 
-		logrus.Infof("NSM:(10.3-%v) Dataplane configuration successful %v", requestId, clientConnection.Xcon)
+		logger.Infof("NSM:(10.3) Dataplane configuration successful %v", clientConnection.Xcon)
 		break
 	}
-
-	// 11. Send update for client connection
-	clientConnection.ConnectionState = model.ClientConnection_Ready
-	clientConnection.DataplaneState = model.DataplaneState_Ready
-	if existingConnection != nil {
-		srv.model.UpdateClientConnection(clientConnection)
-	}
-
-	// 11. We are done with configuration here.
-	if request.IsRemote() {
-		nsmConnection = clientConnection.Xcon.GetSource().(*crossconnect.CrossConnect_RemoteSource).RemoteSource
-	} else {
-		nsmConnection = clientConnection.Xcon.GetSource().(*crossconnect.CrossConnect_LocalSource).LocalSource
-	}
-	logrus.Infof("NSM:(11-%v) Request done...", requestId)
-	return nsmConnection, nil
+	return nil
 }
 
-func (srv *networkServiceManager) handleDataplaneContextTimeout(requestId string, err error, clientConnection *model.ClientConnection) {
-	logrus.Errorf("NSM:(10.2.0-%v) Context timeout, during programming Dataplane... %v", requestId, err)
+func (srv *networkServiceManager) handleDataplaneContextTimeout(logger *logrus.Entry, err error, clientConnection *model.ClientConnection) {
+	logger.Errorf("NSM:(10.2.0) Context timeout, during programming Dataplane... %v", err)
 	// If context is exceed
 	if ep_err := srv.closeEndpoint(context.Background(), clientConnection, ); ep_err != nil {
-		logrus.Errorf("NSM:(10.2.0-%v) Context timeout, closing NSE: %v", requestId, ep_err)
+		logger.Errorf("NSM:(10.2.0) Context timeout, closing NSE: %v", ep_err)
 	}
 	srv.model.DeleteClientConnection(clientConnection.ConnectionId)
 }
 
-func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.Context, ignore_endpoints map[string]*registry.NSERegistration, request nsm.NSMRequest, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane) (*model.ClientConnection, error) {
+func (srv *networkServiceManager) findConnectNSE(logger *logrus.Entry, ctx context.Context, ignore_endpoints map[string]*registry.NSERegistration, request nsm.NSMRequest, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane) (*model.ClientConnection, error) {
 	// 7.x
 	var endpoint *registry.NSERegistration
 	var err error
@@ -287,7 +337,7 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 	var clientConnection *model.ClientConnection
 	for {
 		if err := ctx.Err(); err != nil {
-			logrus.Errorf("NSM:(7.1.0-%v) Context timeout, during find/call NSE... %v", requestId, err)
+			logger.Errorf("NSM:(7.1.0) Context timeout, during find/call NSE... %v", err)
 			return nil, err
 		}
 		endpoint = nil
@@ -298,6 +348,10 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 			// 7.1.2 Check previous endpoint, and it we will be able to contact it, it should be fine.
 			if existingConnection.Endpoint != nil && ignore_endpoints[existingConnection.Endpoint.NetworkserviceEndpoint.EndpointName] == nil {
 				endpoint = existingConnection.Endpoint
+				// Keep labels passed to endpoint before, they could contain service chain labels.
+				if labels, ok := destinationLabels(existingConnection.Xcon); ok {
+					setConnectionLabels(nseConnection, labels)
+				}
 			}
 		}
 		// 7.1.3 Check if endpoint is not ignored yet
@@ -309,7 +363,7 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 		if err != nil {
 			// 7.1.5 No endpoints found, we need to return error, including last error for previous NSE
 			if last_error != nil {
-				return nil, fmt.Errorf("NSM:(7.1.5-%v) %v. Last NSE Error: %v", requestId, err, last_error)
+				return nil, fmt.Errorf("NSM:(7.1.5) %v. Last NSE Error: %v", err, last_error)
 			} else {
 				return nil, err
 			}
@@ -318,11 +372,14 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 		srv.updateExcludePrefixes(nseConnection)
 
 		// 7.1.7 perform request to NSE/remote NSMD/NSE
-		clientConnection, err = srv.performNSERequest(requestId, ctx, endpoint, nseConnection, request, dp, existingConnection)
+		nseSpan, nseCtx := startSpan(ctx, spanNSERequest)
+		nseSpan.SetTag(tools.LogFieldEndpoint, endpoint.GetNetworkserviceEndpoint().GetEndpointName())
+		clientConnection, err = srv.performNSERequest(logger, nseCtx, endpoint, nseConnection, request, dp, existingConnection)
+		finishSpan(nseSpan, err)
 
 		// 7.1.8 in case of error we put NSE into ignored list to check another one.
 		if err != nil {
-			logrus.Errorf("NSM:(7.1.8-%v) NSE respond with error: %v ", requestId, err)
+			logger.Errorf("NSM:(7.1.8) NSE respond with error: %v ", err)
 			last_error = err
 			ignore_endpoints[endpoint.NetworkserviceEndpoint.EndpointName] = endpoint
 			continue
@@ -357,10 +414,14 @@ func (srv *networkServiceManager) Close(ctx context.Context, connection nsm.NSMC
 
 func (srv *networkServiceManager) close(ctx context.Context, clientConnection *model.ClientConnection, closeDataplane bool, modelRemove bool) error {
 	logrus.Infof("NSM: Closing connection %v", clientConnection)
-	if clientConnection.ConnectionState == model.ClientConnection_Closing {
+	closing := false
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		closing = clientConnection.ConnectionState == model.ClientConnection_Closing
+		clientConnection.ConnectionState = model.ClientConnection_Closing
+	})
+	if closing {
 		return nil
 	}
-	clientConnection.ConnectionState = model.ClientConnection_Closing
 	var nseClientError error
 	var nseCloseError error
 
@@ -372,7 +433,7 @@ func (srv *networkServiceManager) close(ctx context.Context, clientConnection *m
 		// TODO: We need to be sure Dataplane is respond well so we could delete connection.
 		if modelRemove {
 			srv.model.DeleteClientConnection(clientConnection.ConnectionId)
-			clientConnection.ConnectionState = model.ClientConnection_Closed
+			srv.setConnectionState(clientConnection, model.ClientConnection_Closed)
 		}
 	}
 
@@ -412,8 +473,9 @@ func (srv *networkServiceManager) createNSEClient(ctx context.Context, endpoint
 	}
 }
 
-func (srv *networkServiceManager) performNSERequest(requestId string, ctx context.Context, endpoint *registry.NSERegistration, requestConnection nsm.NSMConnection, request nsm.NSMRequest, dp *model.Dataplane, existingConnection *model.ClientConnection) (*model.ClientConnection, error) {
+func (srv *networkServiceManager) performNSERequest(logger *logrus.Entry, ctx context.Context, endpoint *registry.NSERegistration, requestConnection nsm.NSMConnection, request nsm.NSMRequest, dp *model.Dataplane, existingConnection *model.ClientConnection) (*model.ClientConnection, error) {
 	// 7.2.6.x
+	logger = logger.WithField(tools.LogFieldEndpoint, endpoint.GetNetworkserviceEndpoint().GetEndpointName())
 	client, err := srv.createNSEClient(ctx, endpoint)
 	if err != nil {
 		// 7.2.6.1
@@ -422,7 +484,7 @@ func (srv *networkServiceManager) performNSERequest(requestId string, ctx contex
 	defer func() {
 		err := client.Cleanup()
 		if err != nil {
-			logrus.Errorf("NSM:(7.2.6.2-%v) Error during Cleanup: %v", requestId, err)
+			logger.Errorf("NSM:(7.2.6.2) Error during Cleanup: %v", err)
 		}
 	}()
 
@@ -432,16 +494,16 @@ func (srv *networkServiceManager) performNSERequest(requestId string, ctx contex
 	} else {
 		message = srv.createRemoteNSMRequest(endpoint, requestConnection, dp, existingConnection)
 	}
-	logrus.Infof("NSM:(7.2.6.2-%v) Requesting NSE with request %v", requestId, message)
+	logger.Infof("NSM:(7.2.6.2) Requesting NSE with request %v", message)
 	nseConnection, e := client.Request(ctx, message)
 
 	if e != nil {
-		logrus.Errorf("NSM:(7.2.6.2.1-%v) error requesting networkservice from %+v with message %#v error: %s", requestId, endpoint, message, e)
+		logger.Errorf("NSM:(7.2.6.2.1) error requesting networkservice from %+v with message %#v error: %s", endpoint, message, e)
 		return nil, e
 	}
 
 	// 7.2.6.2.2
-	err = srv.validateNSEConnection(requestId, nseConnection)
+	err = srv.validateNSEConnection(logger, nseConnection)
 	if err != nil {
 		return nil, err
 	}
@@ -449,11 +511,11 @@ func (srv *networkServiceManager) performNSERequest(requestId string, ctx contex
 	// 7.2.6.2.3
 	err = requestConnection.UpdateContext(nseConnection.GetContext())
 	if err != nil {
-		err = fmt.Errorf("NSM:(7.2.6.2.3-%v) failure Validating NSE Connection: %s", requestId, err)
+		err = fmt.Errorf("NSM:(7.2.6.2.3) failure Validating NSE Connection: %s", err)
 		return nil, err
 	}
 	// 7.2.6.2.4 update connection parameters, add workspace if local nse
-	srv.updateConnectionParameters(requestId, nseConnection, endpoint)
+	srv.updateConnectionParameters(logger, nseConnection, endpoint)
 
 	// 7.2.6.2.5 create cross connection
 	dpApiConnection := srv.createCrossConnect(requestConnection, endpoint, request, nseConnection)
@@ -501,10 +563,10 @@ func (srv *networkServiceManager) createCrossConnect(requestConnection nsm.NSMCo
 	}
 	return dpApiConnection
 }
-func (srv *networkServiceManager) validateNSEConnection(requestId string, nseConnection nsm.NSMConnection) error {
+func (srv *networkServiceManager) validateNSEConnection(logger *logrus.Entry, nseConnection nsm.NSMConnection) error {
 	err := nseConnection.IsComplete()
 	if err != nil {
-		err = fmt.Errorf("NSM:(7.2.6.2.2-%v) failure Validating NSE Connection: %s", requestId, err)
+		err = fmt.Errorf("NSM:(7.2.6.2.2) failure Validating NSE Connection: %s", err)
 		return err
 	}
 	return nil
@@ -533,7 +595,9 @@ func (srv *networkServiceManager) closeDataplane(clientConnection *model.ClientC
 		return err
 	}
 	logrus.Info("NSM.Dataplane: Cross connection successfully closed on dataplane")
-	clientConnection.DataplaneState = model.DataplaneState_None
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.DataplaneState = model.DataplaneState_None
+	})
 	return nil
 }
 
@@ -541,14 +605,14 @@ func (srv *networkServiceManager) getNetworkServiceManagerName() string {
 	return srv.model.GetNsm().GetName()
 }
 
-func (srv *networkServiceManager) updateConnectionParameters(requestId string, nseConnection nsm.NSMConnection, endpoint *registry.NSERegistration) {
+func (srv *networkServiceManager) updateConnectionParameters(logger *logrus.Entry, nseConnection nsm.NSMConnection, endpoint *registry.NSERegistration) {
 	if srv.isLocalEndpoint(endpoint) {
 		modelEp := srv.model.GetEndpoint(endpoint.GetNetworkserviceEndpoint().GetEndpointName())
 		if modelEp != nil { // In case of tests this could be empty
 			nseConnection.(*connection.Connection).GetMechanism().GetParameters()[connection.Workspace] = modelEp.Workspace
 			nseConnection.(*connection.Connection).GetMechanism().GetParameters()[connection.WorkspaceNSEName] = modelEp.Endpoint.NetworkserviceEndpoint.EndpointName
 		}
-		logrus.Infof("NSM:(7.2.6.2.4-%v) Update Local NSE connection parameters: %v", requestId, nseConnection.(*connection.Connection).GetMechanism())
+		logger.Infof("NSM:(7.2.6.2.4) Update Local NSE connection parameters: %v", nseConnection.(*connection.Connection).GetMechanism())
 	}
 }
 
@@ -591,6 +655,14 @@ func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnec
 		logrus.Error(err)
 		return nil, err
 	}
+	if len(endpointResponse.GetNetworkService().GetChain()) > 0 {
+		// Network service defines a chain, so we need to select an endpoint of requested chain hop.
+		endpointResponse, err = srv.findChainHop(ctx, discoveryClient, requestConnection, endpointResponse)
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+	}
 	endpoints := srv.filterEndpoints(endpointResponse.GetNetworkServiceEndpoints(), ignore_endpoints)
 
 	if len(endpoints) == 0 {
@@ -598,9 +670,14 @@ func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnec
 			requestConnection.GetNetworkService(), len(ignore_endpoints), len(endpoints))
 	}
 
+	if len(selector.AvailableEndpoints(endpoints)) == 0 {
+		return nil, fmt.Errorf("All NSEs for NetworkService %s have reached their capacity. Total NSEs: %d",
+			requestConnection.GetNetworkService(), len(endpoints))
+	}
+
 	endpoint := srv.model.GetSelector().SelectEndpoint(requestConnection.(*connection.Connection), endpointResponse.GetNetworkService(), endpoints)
 	if endpoint == nil {
-		return nil, err
+		return nil, fmt.Errorf("Failed to select NSE for NetworkService %s", requestConnection.GetNetworkService())
 	}
 	return &registry.NSERegistration{
 		NetworkServiceManager:  endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()],
@@ -634,7 +711,7 @@ func (srv *networkServiceManager) filterRegEndpoints(endpoints []*registry.NSERe
 /**
 check if we need to do a NSE/Remote NSM request in case of our connection Upgrade/Healing procedure.
 */
-func (srv *networkServiceManager) checkNeedNSERequest(requestId string, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane) bool {
+func (srv *networkServiceManager) checkNeedNSERequest(logger *logrus.Entry, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane) bool {
 	// 4.2.x
 	// 4.2.1 Check if context is changed, if changed we need to
 	if !proto.Equal(nsmConnection.GetContext(), existingConnection.GetConnectionSource().GetContext()) {
@@ -649,16 +726,16 @@ func (srv *networkServiceManager) checkNeedNSERequest(requestId string, nsmConne
 			for k, v := range dpM.Parameters {
 				rmV := remoteDestination.Mechanism.Parameters[k]
 				if v != rmV {
-					logrus.Infof("NSM:(4.2.3-%v) Remote mechanism parameter %s was different with previous one : %v  %v", requestId, k, rmV, v)
+					logger.Infof("NSM:(4.2.3) Remote mechanism parameter %s was different with previous one : %v  %v", k, rmV, v)
 					return true
 				}
 			}
 			if !proto.Equal(dpM, remoteDestination.Mechanism) {
-				logrus.Infof("NSM:(4.2.4-%v)  Remote mechanism was different with previous selected one : %v  %v", requestId, remoteDestination.Mechanism, dpM)
+				logger.Infof("NSM:(4.2.4)  Remote mechanism was different with previous selected one : %v  %v", remoteDestination.Mechanism, dpM)
 				return true
 			}
 		} else {
-			logrus.Infof("NSM:(4.2.5-%v) Remote mechanism previously selected was not found: %v  in dataplane %v", requestId, remoteDestination.Mechanism, dp.RemoteMechanisms)
+			logger.Infof("NSM:(4.2.5) Remote mechanism previously selected was not found: %v  in dataplane %v", remoteDestination.Mechanism, dp.RemoteMechanisms)
 			return true
 		}
 	}
@@ -687,7 +764,17 @@ func (srv *networkServiceManager) WaitForDataplane(timeout time.Duration) error
 }
 
 func (srv *networkServiceManager) RestoreConnections(xcons []*crossconnect.CrossConnect, dataplane string) {
+	srv.restoreLock.Lock()
+	restored := srv.restored
+	srv.restored = nil
+	srv.restoreLock.Unlock()
+
 	for _, xcon := range xcons {
+		if restored[xcon.GetId()] {
+			delete(restored, xcon.GetId())
+			srv.reconcileConnection(xcon, dataplane)
+			continue
+		}
 		existing := srv.model.GetClientConnection(xcon.GetId())
 		if existing == nil {
 			logrus.Infof("Restoring state of active connection %v", xcon)
@@ -765,19 +852,19 @@ func (srv *networkServiceManager) RestoreConnections(xcons []*crossconnect.Cross
 				ConnectionState: connectionState,
 				DataplaneState:  model.DataplaneState_Ready, // It is configured already.
 			}
+			if src := xcon.GetLocalSource(); src != nil {
+				// Update request to match source connection
+				clientConnection.Request = &networkservice.NetworkServiceRequest{
+					Connection:           src,
+					MechanismPreferences: []*connection.Mechanism{src.GetMechanism()},
+				}
+			}
 			srv.model.AddClientConnection(clientConnection)
 
 			// Add healing timer, for connection to be headled from source side.
 			if src := xcon.GetRemoteSource(); src != nil {
 				srv.RemoteConnectionLost(clientConnection)
 			} else if src := xcon.GetLocalSource(); src != nil {
-				// Update request to match source connection
-				request := &networkservice.NetworkServiceRequest{
-					Connection:           src,
-					MechanismPreferences: []*connection.Mechanism{src.GetMechanism()},
-				}
-				clientConnection.Request = request
-
 				if dst := xcon.GetRemoteDestination(); dst != nil {
 					srv.Heal(clientConnection, nsm.HealState_DstDown)
 				}
@@ -794,19 +881,195 @@ func (srv *networkServiceManager) RestoreConnections(xcons []*crossconnect.Cross
 			logrus.Infof("Active connection state %v is Restored", xcon)
 		}
 	}
+	for connectionId := range restored {
+		srv.reconcileLostConnection(connectionId, dataplane)
+	}
 	logrus.Infof("All connections are recovered...")
-	// Notify state is restored
-	srv.stateRestored <- true
+	// Notify state is restored, initial state is received on every dataplane (re)connect,
+	// so do not block if nobody is waiting for it.
+	select {
+	case srv.stateRestored <- true:
+	default:
+	}
+}
+
+// RestoreCheckpoint adds connections stored before NSMD restart to the model. Dataplane state of the connections
+// is unknown, so they are reconciled with cross connects passed to RestoreConnections.
+func (srv *networkServiceManager) RestoreCheckpoint(connections []nsm.NSMClientConnection) {
+	srv.restoreLock.Lock()
+	defer srv.restoreLock.Unlock()
+
+	if srv.restored == nil {
+		srv.restored = map[string]bool{}
+	}
+	if srv.awaitingRequest == nil {
+		srv.awaitingRequest = map[string]bool{}
+	}
+	// Connections are matched with remote NSM by pointer, so connections to the same NSM share it.
+	remoteNsms := map[string]*registry.NetworkServiceManager{}
+	for _, c := range connections {
+		clientConnection := c.(*model.ClientConnection)
+		// New connections are checkpointed in Requesting state, since they are not updated in the model
+		// when the request is completed.
+		if state := clientConnection.ConnectionState; state == model.ClientConnection_Closing || state == model.ClientConnection_Closed {
+			continue
+		}
+		if clientConnection.Request == nil || clientConnection.Xcon.GetSource() == nil || clientConnection.Endpoint == nil {
+			logrus.Errorf("NSM: Checkpointed connection %s is not complete, skipping", clientConnection.GetId())
+			continue
+		}
+		if srv.model.GetClientConnection(clientConnection.GetId()) != nil {
+			continue
+		}
+		if remoteNsm := clientConnection.RemoteNsm; remoteNsm != nil {
+			if shared, ok := remoteNsms[remoteNsm.GetName()]; ok {
+				clientConnection.RemoteNsm = shared
+			} else {
+				remoteNsms[remoteNsm.GetName()] = remoteNsm
+			}
+		}
+		clientConnection.ConnectionState = model.ClientConnection_Ready
+		clientConnection.DataplaneState = model.DataplaneState_None
+		srv.restored[clientConnection.GetId()] = true
+		srv.model.AddClientConnection(clientConnection)
+
+		if clientConnection.Xcon.GetRemoteSource() != nil {
+			srv.awaitingRequest[clientConnection.GetId()] = true
+			srv.waitRemoteRequest(clientConnection)
+		}
+		logrus.Infof("NSM: Connection %s is restored from checkpoint", clientConnection.GetId())
+	}
+}
+
+// reconcileConnection updates the checkpointed connection with the cross connect found in the dataplane.
+func (srv *networkServiceManager) reconcileConnection(xcon *crossconnect.CrossConnect, dataplane string) {
+	dp := srv.model.GetDataplane(dataplane)
+	clientConnection := srv.model.GetClientConnection(xcon.GetId())
+	if clientConnection == nil {
+		// Connection is closed before the dataplane is connected, so the cross connect is left.
+		logrus.Infof("NSM: Closing cross connect %s of the closed connection", xcon.GetId())
+		if err := srv.closeDataplane(&model.ClientConnection{
+			ConnectionId:   xcon.GetId(),
+			Xcon:           xcon,
+			Dataplane:      dp,
+			DataplaneState: model.DataplaneState_Ready,
+		}); err != nil {
+			logrus.Errorf("NSM: Failed to close cross connect %s: %v", xcon.GetId(), err)
+		}
+		return
+	}
+	if clientConnection.DataplaneState == model.DataplaneState_Ready {
+		// Connection is requested again and the dataplane is programmed already.
+		return
+	}
+	logrus.Infof("NSM: Checkpointed connection %s is active in dataplane", xcon.GetId())
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.Xcon = xcon
+		clientConnection.Dataplane = dp
+		clientConnection.DataplaneState = model.DataplaneState_Ready
+	})
+	srv.model.UpdateClientConnection(clientConnection)
+
+	if src := xcon.GetLocalSource(); src != nil && src.State == connection.State_DOWN {
+		// if source is down, we need to close connection properly.
+		_ = srv.Close(context.Background(), clientConnection)
+		return
+	}
+	if xcon.GetRemoteDestination() != nil {
+		go srv.refreshRemoteDestination(clientConnection, dp)
+	}
+}
+
+// reconcileLostConnection heals the checkpointed connection missing in the dataplane.
+func (srv *networkServiceManager) reconcileLostConnection(connectionId, dataplane string) {
+	clientConnection := srv.model.GetClientConnection(connectionId)
+	if clientConnection == nil || clientConnection.DataplaneState == model.DataplaneState_Ready {
+		return
+	}
+	logrus.Infof("NSM: Checkpointed connection %s is missing in dataplane, healing", connectionId)
+	dp := srv.model.GetDataplane(dataplane)
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.Dataplane = dp
+	})
+	go srv.Heal(clientConnection, nsm.HealState_DataplaneDown)
+}
+
+// refreshRemoteDestination requests the remote destination again, since remote NSM puts connections into
+// Healing state when it loses monitoring by this NSM and closes them if they are not requested again.
+func (srv *networkServiceManager) refreshRemoteDestination(clientConnection *model.ClientConnection, dp *model.Dataplane) {
+	ctx, cancel := context.WithTimeout(context.Background(), srv.properties.HealRequestTimeout)
+	defer cancel()
+
+	remoteDst := clientConnection.Xcon.GetRemoteDestination()
+	client, err := srv.createNSEClient(ctx, clientConnection.Endpoint)
+	if err != nil {
+		logrus.Errorf("NSM: Failed to connect remote NSM of connection %s: %v", clientConnection.GetId(), err)
+		srv.Heal(clientConnection, nsm.HealState_DstDown)
+		return
+	}
+	defer func() {
+		if err := client.Cleanup(); err != nil {
+			logrus.Errorf("NSM: Error during Cleanup: %v", err)
+		}
+	}()
+
+	message := srv.createRemoteNSMRequest(clientConnection.Endpoint, clientConnection.GetConnectionSource(), dp, clientConnection)
+	response, err := client.Request(ctx, message)
+	if err != nil {
+		logrus.Errorf("NSM: Failed to refresh remote destination of connection %s: %v", clientConnection.GetId(), err)
+		srv.Heal(clientConnection, nsm.HealState_DstDown)
+		return
+	}
+	dst := response.(*remote_connection.Connection)
+	if dst.GetId() == remoteDst.GetId() && proto.Equal(dst.GetMechanism(), remoteDst.GetMechanism()) {
+		logrus.Infof("NSM: Remote destination of connection %s is refreshed", clientConnection.GetId())
+		return
+	}
+	// Remote NSM has changed the connection, the dataplane should be programmed again.
+	srv.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+		xcon.Destination = &crossconnect.CrossConnect_RemoteDestination{
+			RemoteDestination: dst,
+		}
+	})
+	srv.Heal(clientConnection, nsm.HealState_DstUpdate)
+}
+
+// waitRemoteRequest closes the restored connection requested by remote NSM if it is not requested again in heal
+// timeout. Remote NSM heals its connections on restart of this NSM, so the connection is abandoned otherwise.
+func (srv *networkServiceManager) waitRemoteRequest(clientConnection *model.ClientConnection) {
+	go func() {
+		<-time.After(srv.properties.HealTimeout)
+
+		srv.restoreLock.Lock()
+		awaiting := srv.awaitingRequest[clientConnection.GetId()]
+		delete(srv.awaitingRequest, clientConnection.GetId())
+		srv.restoreLock.Unlock()
+
+		if !awaiting || srv.model.GetClientConnection(clientConnection.GetId()) != clientConnection {
+			return
+		}
+		logrus.Errorf("NSM: Restored connection %s is not requested by remote NSM, closing", clientConnection.GetId())
+		if err := srv.Close(context.Background(), clientConnection); err != nil {
+			logrus.Errorf("NSM: Error closing connection %v", err)
+		}
+	}()
+}
+
+// requestReceived marks the restored connection as requested again, so it is not closed by waitRemoteRequest.
+func (srv *networkServiceManager) requestReceived(connectionId string) {
+	srv.restoreLock.Lock()
+	defer srv.restoreLock.Unlock()
+	delete(srv.awaitingRequest, connectionId)
 }
 
 func (srv *networkServiceManager) RemoteConnectionLost(clientConnection nsm.NSMClientConnection) {
 	connection := clientConnection.(*model.ClientConnection)
-	connection.ConnectionState = model.ClientConnection_Healing
+	srv.setConnectionState(connection, model.ClientConnection_Healing)
 	logrus.Infof("NSM: Remote opened connection is not monitored and put into Healing state %v", clientConnection)
 	go func() {
 		<-time.Tick(srv.properties.HealTimeout)
 
-		if connection.ConnectionState == model.ClientConnection_Healing {
+		if srv.model.CopyClientConnection(connection).ConnectionState == model.ClientConnection_Healing {
 			logrus.Errorf("NSM: Timeout happened for checking connection status from Healing.. %v. Closing connection...", clientConnection)
 			// Nobody was healed connection from Remote side.
 			if err := srv.Close(context.Background(), clientConnection); err != nil {
@@ -1,41 +1,53 @@
 package nsm
 
 import (
-	"fmt"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"time"
 )
 
 func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healState nsm.HealState) {
-	healId := create_logid()
-	logrus.Infof("NSM_Heal(1-%v) %v", healId, connection)
+	healCtx, requestID := tools.EnsureRequestID(context.Background())
+	span, healCtx := startSpan(healCtx, spanHeal)
+	defer span.Finish()
+	span.SetTag(tools.LogFieldRequestID, requestID)
+	span.SetTag(tools.LogFieldConnectionID, connection.GetId())
+	span.SetTag(tools.LogFieldNetworkService, connection.GetNetworkService())
+	span.SetTag("heal_state", int32(healState))
+
+	logger := tools.Log(healCtx).WithFields(logrus.Fields{
+		tools.LogFieldConnectionID:   connection.GetId(),
+		tools.LogFieldNetworkService: connection.GetNetworkService(),
+	})
+	logger.Infof("NSM_Heal(1) %v", connection)
 
 	clientConnection := connection.(*model.ClientConnection)
-	if clientConnection.ConnectionState != model.ClientConnection_Ready {
+	if srv.model.CopyClientConnection(clientConnection).ConnectionState != model.ClientConnection_Ready {
 		//means that we already closing/healing
 		return
 	}
 
 	if !srv.properties.HealEnabled {
-		logrus.Infof("NSM_Heal Is Disabled/Closing connection %v", connection)
+		logger.Infof("NSM_Heal Is Disabled/Closing connection %v", connection)
 
 		err := srv.Close(context.Background(), clientConnection)
 		if err != nil {
-			logrus.Errorf("NSM_Heal Error in Close: %v", err)
+			logger.Errorf("NSM_Heal Error in Close: %v", err)
 		}
 		return
 	}
 
 	defer func() {
-		logrus.Infof("NSM_Heal(1.1-%v) Connection %v healing state is finished...", healId, clientConnection.GetId())
-		clientConnection.ConnectionState = model.ClientConnection_Ready
+		logger.Infof("NSM_Heal(1.1) Connection %v healing state is finished...", clientConnection.GetId())
+		srv.setConnectionState(clientConnection, model.ClientConnection_Ready)
 	}()
 
-	clientConnection.ConnectionState = model.ClientConnection_Healing
+	srv.setConnectionState(clientConnection, model.ClientConnection_Healing)
 
 	// 2 Choose heal style
 	switch healState {
@@ -43,15 +55,16 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		// Destination is down, we need to find it again.
 		if clientConnection.Xcon.GetRemoteSource() != nil {
 			// NSMd id remote one, we just need to close and return.
-			logrus.Infof("NSM_Heal(2.1-%v) Remote NSE heal is done on source side", healId)
+			logger.Infof("NSM_Heal(2.1) Remote NSE heal is done on source side")
 			break
 		} else {
-			ctx, cancel := context.WithTimeout(context.Background(), srv.properties.HealTimeout*3)
+			ctx, cancel := context.WithTimeout(healCtx, srv.properties.HealTimeout*3)
 			defer cancel()
 
-			logrus.Infof("NSM_Heal(2.2-%v) Starting DST Heal...", healId)
+			logger.Infof("NSM_Heal(2.2) Starting DST Heal...")
 			// We are client NSMd, we need to try recover our connection srv.
 
+			waitSpan, ctx := startSpan(ctx, spanHealWaitNSE)
 			if srv.isLocalEndpoint(clientConnection.Endpoint) {
 				// if NSE is DIE, on recovery it would be different NSE with different ID, so lets's just wait for any NSE with required name available
 				// And filter same NSE as we had, since information about it could be outdated.
@@ -61,46 +74,52 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 				// Get endpoints, do it every time since we do not know if list are changed or not.
 				if !srv.waitRemoteNSE(ctx, clientConnection) {
 					// Not remote NSE found, we need to update connection
-					if dst := clientConnection.Xcon.GetRemoteDestination(); dst != nil {
-						dst.SetId("-") // We need to mark this as new connection.
-					}
-					if dst := clientConnection.Xcon.GetLocalDestination(); dst != nil {
-						dst.SetId("-") // We need to mark this as new connection.
-					}
+					srv.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+						if dst := xcon.GetRemoteDestination(); dst != nil {
+							dst.SetId("-") // We need to mark this as new connection.
+						}
+						if dst := xcon.GetLocalDestination(); dst != nil {
+							dst.SetId("-") // We need to mark this as new connection.
+						}
+					})
 				}
 			}
+			waitSpan.Finish()
 			// Fallback to heal with choose of new NSE.
-			requestCtx, requestCancel := context.WithTimeout(context.Background(), srv.properties.HealRequestTimeout)
+			requestCtx, requestCancel := context.WithTimeout(healCtx, srv.properties.HealRequestTimeout)
 			defer requestCancel()
 			recoveredConnection, err := srv.request(requestCtx, clientConnection.Request, clientConnection)
 			if err != nil {
-				logrus.Errorf("NSM_Heal(2.3.1-%v) Failed to heal connection: %v", healId, err)
+				logger.Errorf("NSM_Heal(2.3.1) Failed to heal connection: %v", err)
 				// We need to delete connection, since we are not able to Heal it
 				srv.model.DeleteClientConnection(clientConnection.ConnectionId)
 				if err != nil {
-					logrus.Errorf("NSM_Heal(2.3.2-%v) Error in Recovery Close: %v", healId, err)
+					logger.Errorf("NSM_Heal(2.3.2) Error in Recovery Close: %v", err)
 				}
-				clientConnection.ConnectionState = model.ClientConnection_Closed
+				srv.setConnectionState(clientConnection, model.ClientConnection_Closed)
 
 			} else {
-				logrus.Infof("NSM_Heal(2.4-%v) Heal: Connection recovered: %v", healId, recoveredConnection)
+				logger.Infof("NSM_Heal(2.4) Heal: Connection recovered: %v", recoveredConnection)
 				return
 			}
 		}
 
 		// Let's Close remote connection and re-create new one.
 	case nsm.HealState_DataplaneDown:
-		ctx, cancel := context.WithTimeout(context.Background(), srv.properties.HealTimeout)
+		ctx, cancel := context.WithTimeout(healCtx, srv.properties.HealTimeout)
 		defer cancel()
 
 		// Dataplane is down, we only need to re-programm dataplane.
 		// 1. Wait for dataplane to appear.
-		logrus.Infof("NSM_Heal(3.1-%v) Waiting for Dataplane to recovery...", healId)
-		if err := srv.serviceRegistry.WaitForDataplaneAvailable(srv.model, srv.properties.HealDataplaneTimeout); err != nil {
-			logrus.Errorf("NSM_Heal(3.1-%v) Dataplane is not available on recovery for timeout %v: %v", srv.properties.HealDataplaneTimeout, healId, err)
+		logger.Infof("NSM_Heal(3.1) Waiting for Dataplane to recovery...")
+		waitSpan, _ := startSpan(ctx, spanHealWaitDataplane)
+		err := srv.serviceRegistry.WaitForDataplaneAvailable(srv.model, srv.properties.HealDataplaneTimeout)
+		finishSpan(waitSpan, err)
+		if err != nil {
+			logger.Errorf("NSM_Heal(3.1) Dataplane is not available on recovery for timeout %v: %v", srv.properties.HealDataplaneTimeout, err)
 			break
 		}
-		logrus.Infof("NSM_Heal(3.2-%v) Dataplane is now available...", healId)
+		logger.Infof("NSM_Heal(3.2) Dataplane is now available...")
 
 		// We could send connection is down now.
 		srv.model.UpdateClientConnection(clientConnection)
@@ -108,7 +127,7 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		if clientConnection.Xcon.GetRemoteSource() != nil {
 			// NSMd id remote one, we just need to close and return.
 			// Recovery will be performed by NSM client side.
-			logrus.Infof("NSM_Heal(3.3-%v)  Healing will be continued on source side...", healId)
+			logger.Infof("NSM_Heal(3.3)  Healing will be continued on source side...")
 			return
 		}
 
@@ -116,20 +135,20 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		// Update request to contain a proper connection object from previous attempt.
 		request := clientConnection.Request.Clone()
 		request.SetConnection(clientConnection.GetConnectionSource())
-		srv.requestOrClose(fmt.Sprintf("NSM_Heal(3.4-%v) ", healId), ctx, request, clientConnection)
+		srv.requestOrClose(logger, "NSM_Heal(3.4) ", ctx, request, clientConnection)
 		return
 	case nsm.HealState_DstUpdate:
-		ctx, cancel := context.WithTimeout(context.Background(), srv.properties.HealTimeout)
+		ctx, cancel := context.WithTimeout(healCtx, srv.properties.HealTimeout)
 		defer cancel()
 
 		// Remote DST is updated.
 		// Update request to contain a proper connection object from previous attempt.
-		logrus.Infof("NSM_Heal(5.1-%v) Healing Src Update... %v", healId, clientConnection)
+		logger.Infof("NSM_Heal(5.1) Healing Src Update... %v", clientConnection)
 		if clientConnection.Request != nil {
 			request := clientConnection.Request.Clone()
 			request.SetConnection(clientConnection.GetConnectionSource())
 
-			srv.requestOrClose(fmt.Sprintf("NSM_Heal(5.2-%v) ", healId), ctx, request, clientConnection)
+			srv.requestOrClose(logger, "NSM_Heal(5.2) ", ctx, request, clientConnection)
 			return
 		}
 	}
@@ -138,21 +157,28 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 	// Close both connection and dataplane
 	err := srv.Close(context.Background(), clientConnection)
 	if err != nil {
-		logrus.Errorf("NSM_Heal(4-%v) Error in Recovery: %v", healId, err)
+		logger.Errorf("NSM_Heal(4) Error in Recovery: %v", err)
 	}
 
 }
 
-func (srv *networkServiceManager) requestOrClose(logPrefix string, ctx context.Context, request nsm.NSMRequest, clientConnection *model.ClientConnection) {
-	logrus.Infof("%v delegate to Request %v", logPrefix, request)
+// setConnectionState changes the connection state under the model lock, since it is read by other goroutines.
+func (srv *networkServiceManager) setConnectionState(clientConnection *model.ClientConnection, state model.ClientConnectionState) {
+	srv.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		clientConnection.ConnectionState = state
+	})
+}
+
+func (srv *networkServiceManager) requestOrClose(logger *logrus.Entry, logPrefix string, ctx context.Context, request nsm.NSMRequest, clientConnection *model.ClientConnection) {
+	logger.Infof("%v delegate to Request %v", logPrefix, request)
 	connection, err := srv.request(ctx, request, clientConnection)
 	if err != nil {
-		logrus.Errorf("%v Failed to heal connection: %v", logPrefix, err)
+		logger.Errorf("%v Failed to heal connection: %v", logPrefix, err)
 		// Close in case of any errors in recovery.
 		err = srv.Close(context.Background(), clientConnection)
-		logrus.Errorf("%v Error in Recovery Close: %v", logPrefix, err)
+		logger.Errorf("%v Error in Recovery Close: %v", logPrefix, err)
 	} else {
-		logrus.Infof("%v Heal: Connection recovered: %v", logPrefix, connection)
+		logger.Infof("%v Heal: Connection recovered: %v", logPrefix, connection)
 	}
 }
 func (srv *networkServiceManager) waitAnyNSE(clientConnection *model.ClientConnection) {
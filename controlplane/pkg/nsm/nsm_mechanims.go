@@ -13,11 +13,11 @@ import (
 	"strconv"
 )
 
-func (srv *networkServiceManager) updateMechanism(requestId string, nsmConnection nsm.NSMConnection, request nsm.NSMRequest, dataplane *model.Dataplane) error {
+func (srv *networkServiceManager) updateMechanism(logger *logrus.Entry, nsmConnection nsm.NSMConnection, request nsm.NSMRequest, dataplane *model.Dataplane) error {
 	// 5.x
 	if request.IsRemote() {
 		//5.1 Select appropriate remote mechanism
-		mechanism, err := srv.selectRemoteMechanism(requestId, request.(*remote_networkservice.NetworkServiceRequest), dataplane)
+		mechanism, err := srv.selectRemoteMechanism(logger, request.(*remote_networkservice.NetworkServiceRequest), dataplane)
 		if err != nil {
 			return err
 		}
@@ -52,7 +52,7 @@ func (srv *networkServiceManager) updateMechanism(requestId string, nsmConnectio
 	return nil
 }
 
-func (srv *networkServiceManager) selectRemoteMechanism(requestId string, request *remote_networkservice.NetworkServiceRequest, dp *model.Dataplane) (*remote_connection.Mechanism, error) {
+func (srv *networkServiceManager) selectRemoteMechanism(logger *logrus.Entry, request *remote_networkservice.NetworkServiceRequest, dp *model.Dataplane) (*remote_connection.Mechanism, error) {
 	for _, mechanism := range request.MechanismPreferences {
 		dp_mechanism := findRemoteMechanism(dp.RemoteMechanisms, remote_connection.MechanismType_VXLAN)
 		if dp_mechanism == nil {
@@ -66,10 +66,10 @@ func (srv *networkServiceManager) selectRemoteMechanism(requestId string, reques
 			mechanism.Parameters[remote_connection.VXLANDstIP] = dp_mechanism.Parameters[remote_connection.VXLANSrcIP]
 			mechanism.Parameters[remote_connection.VXLANVNI] = strconv.FormatUint(srv.serviceRegistry.VniAllocator().Vni(dp_mechanism.Parameters[remote_connection.VXLANSrcIP], remoteSrc), 10)
 		}
-		logrus.Infof("NSM:(4.1-%v) Remote mechanism selected %v", requestId, mechanism)
+		logger.Infof("NSM:(4.1) Remote mechanism selected %v", mechanism)
 		return mechanism, nil
 	}
-	return nil, fmt.Errorf("NSM:(5.1-%v) Failed to select mechanism. No matched mechanisms found...", requestId)
+	return nil, fmt.Errorf("NSM:(5.1) Failed to select mechanism. No matched mechanisms found...")
 }
 
 func findRemoteMechanism(MechanismPreferences []*remote_connection.Mechanism, mechanismType remote_connection.MechanismType) *remote_connection.Mechanism {
@@ -0,0 +1,49 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	"golang.org/x/net/context"
+)
+
+// Span operation names used by network service manager.
+const (
+	spanRequest           = "NetworkServiceManager.Request"
+	spanSelectDataplane   = "NetworkServiceManager.SelectDataplane"
+	spanFindConnectNSE    = "NetworkServiceManager.FindConnectNSE"
+	spanNSERequest        = "NetworkServiceManager.NSERequest"
+	spanProgramDataplane  = "NetworkServiceManager.ProgramDataplane"
+	spanDataplaneRequest  = "NetworkServiceManager.DataplaneRequest"
+	spanHeal              = "NetworkServiceManager.Heal"
+	spanHealWaitNSE       = "NetworkServiceManager.HealWaitNSE"
+	spanHealWaitDataplane = "NetworkServiceManager.HealWaitDataplane"
+)
+
+// startSpan starts a child span of a span stored in ctx, if any.
+func startSpan(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
+	return opentracing.StartSpanFromContext(ctx, operationName)
+}
+
+// finishSpan marks span as failed if err is not nil and finishes it.
+func finishSpan(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+	span.Finish()
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestRateEnv is a global limit of Request calls per second, 0 means unlimited
+	RequestRateEnv = "NSMD_REQUEST_RATE"
+	// RequestBurstEnv is a global burst of Request calls, defaults to rounded up rate
+	RequestBurstEnv = "NSMD_REQUEST_BURST"
+	// WorkspaceRequestRateEnv is a per workspace limit of Request calls per second, 0 means unlimited
+	WorkspaceRequestRateEnv = "NSMD_WORKSPACE_REQUEST_RATE"
+	// WorkspaceRequestBurstEnv is a per workspace burst of Request calls, defaults to rounded up rate
+	WorkspaceRequestBurstEnv = "NSMD_WORKSPACE_REQUEST_BURST"
+	// MaxConcurrentRequestsEnv is a limit of Request calls processed at the same time, 0 means unlimited
+	MaxConcurrentRequestsEnv = "NSMD_MAX_CONCURRENT_REQUESTS"
+)
+
+// AdmissionProperties are limits applied to incoming Request calls.
+type AdmissionProperties struct {
+	RequestRate           float64
+	RequestBurst          int
+	WorkspaceRequestRate  float64
+	WorkspaceRequestBurst int
+	MaxConcurrentRequests int
+}
+
+// NewAdmissionPropertiesFromEnv reads admission properties from environment variables.
+func NewAdmissionPropertiesFromEnv() *AdmissionProperties {
+	return &AdmissionProperties{
+		RequestRate:           getEnvFloat(RequestRateEnv),
+		RequestBurst:          getEnvInt(RequestBurstEnv),
+		WorkspaceRequestRate:  getEnvFloat(WorkspaceRequestRateEnv),
+		WorkspaceRequestBurst: getEnvInt(WorkspaceRequestBurstEnv),
+		MaxConcurrentRequests: getEnvInt(MaxConcurrentRequestsEnv),
+	}
+}
+
+// RequestMetrics is a snapshot of admitted and rejected Request calls counters.
+type RequestMetrics struct {
+	Admitted              uint64
+	RejectedGlobalRate    uint64
+	RejectedWorkspaceRate uint64
+	RejectedConcurrency   uint64
+	InFlight              int64
+}
+
+// AdmissionMetrics counts Request calls passed through admission controllers it is given to.
+type AdmissionMetrics struct {
+	admitted              uint64
+	rejectedGlobalRate    uint64
+	rejectedWorkspaceRate uint64
+	rejectedConcurrency   uint64
+	inFlight              int64
+}
+
+// NewAdmissionMetrics creates zeroed Request admission metrics.
+func NewAdmissionMetrics() *AdmissionMetrics {
+	return &AdmissionMetrics{}
+}
+
+// Get returns a snapshot of Request admission metrics.
+func (m *AdmissionMetrics) Get() RequestMetrics {
+	return RequestMetrics{
+		Admitted:              atomic.LoadUint64(&m.admitted),
+		RejectedGlobalRate:    atomic.LoadUint64(&m.rejectedGlobalRate),
+		RejectedWorkspaceRate: atomic.LoadUint64(&m.rejectedWorkspaceRate),
+		RejectedConcurrency:   atomic.LoadUint64(&m.rejectedConcurrency),
+		InFlight:              atomic.LoadInt64(&m.inFlight),
+	}
+}
+
+// AdmissionController decides if an incoming Request call could be processed now.
+type AdmissionController struct {
+	sync.Mutex
+	properties *AdmissionProperties
+	metrics    *AdmissionMetrics
+	global     *rate.Limiter
+	workspaces map[string]*rate.Limiter
+	inFlight   chan struct{}
+}
+
+// NewAdmissionController creates an admission controller with passed limits, admission results are counted in metrics.
+func NewAdmissionController(properties *AdmissionProperties, metrics *AdmissionMetrics) *AdmissionController {
+	ac := &AdmissionController{
+		properties: properties,
+		metrics:    metrics,
+		global:     newLimiter(properties.RequestRate, properties.RequestBurst),
+		workspaces: map[string]*rate.Limiter{},
+	}
+	if properties.MaxConcurrentRequests > 0 {
+		ac.inFlight = make(chan struct{}, properties.MaxConcurrentRequests)
+	}
+	return ac
+}
+
+// Admit checks limits for a Request from workspace, returned release function should be called once request is processed.
+// ResourceExhausted error is returned if request is rejected. Rate limit tokens are taken only if all the checks pass,
+// so a rejected request does not use the rate of the workspace or of other workspaces.
+func (ac *AdmissionController) Admit(workspace string) (func(), error) {
+	if ac.inFlight != nil {
+		select {
+		case ac.inFlight <- struct{}{}:
+		default:
+			atomic.AddUint64(&ac.metrics.rejectedConcurrency, 1)
+			return nil, status.Errorf(codes.ResourceExhausted, "max concurrent requests %d exceeded", ac.properties.MaxConcurrentRequests)
+		}
+	}
+	releaseSlot := func() {
+		if ac.inFlight != nil {
+			<-ac.inFlight
+		}
+	}
+
+	now := time.Now()
+	workspaceToken := ac.workspaceLimiter(workspace).ReserveN(now, 1)
+	if !workspaceToken.OK() || workspaceToken.DelayFrom(now) > 0 {
+		workspaceToken.CancelAt(now)
+		releaseSlot()
+		atomic.AddUint64(&ac.metrics.rejectedWorkspaceRate, 1)
+		return nil, status.Errorf(codes.ResourceExhausted, "request rate limit exceeded for workspace %s", workspace)
+	}
+	globalToken := ac.global.ReserveN(now, 1)
+	if !globalToken.OK() || globalToken.DelayFrom(now) > 0 {
+		globalToken.CancelAt(now)
+		// Workspace token is returned back, since the request is not processed.
+		workspaceToken.CancelAt(now)
+		releaseSlot()
+		atomic.AddUint64(&ac.metrics.rejectedGlobalRate, 1)
+		return nil, status.Errorf(codes.ResourceExhausted, "global request rate limit exceeded")
+	}
+	atomic.AddUint64(&ac.metrics.admitted, 1)
+	atomic.AddInt64(&ac.metrics.inFlight, 1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			atomic.AddInt64(&ac.metrics.inFlight, -1)
+			releaseSlot()
+		})
+	}, nil
+}
+
+// RemoveWorkspace forgets rate limiter state of a closed workspace.
+func (ac *AdmissionController) RemoveWorkspace(workspace string) {
+	ac.Lock()
+	defer ac.Unlock()
+	delete(ac.workspaces, workspace)
+}
+
+func (ac *AdmissionController) workspaceLimiter(workspace string) *rate.Limiter {
+	ac.Lock()
+	defer ac.Unlock()
+	limiter, ok := ac.workspaces[workspace]
+	if !ok {
+		limiter = newLimiter(ac.properties.WorkspaceRequestRate, ac.properties.WorkspaceRequestBurst)
+		ac.workspaces[workspace] = limiter
+	}
+	return limiter
+}
+
+func newLimiter(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(limit))
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+func getEnvFloat(name string) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logrus.Errorf("Failed to parse %s=%s: %v, limit is disabled", name, value, err)
+		return 0
+	}
+	return result
+}
+
+func getEnvInt(name string) int {
+	return int(getEnvFloat(name))
+}
@@ -22,12 +22,10 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
 	dataplaneregistrarapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplaneregistrar"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
-	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
@@ -86,9 +84,11 @@ func dataplaneMonitor(model model.Model, dataplaneName string) {
 			return
 		}
 		logrus.Infof("Dataplane %s informed of its parameters changes, applying new parameters %+v", dataplaneName, updates.RemoteMechanisms)
-		// TODO: this is not good -- direct model changes
-		dataplane.RemoteMechanisms = updates.RemoteMechanisms
-		dataplane.LocalMechanisms = updates.LocalMechanisms
+		// Dataplane is read concurrently, so it is replaced in the model instead of being changed.
+		updated := *dataplane
+		updated.RemoteMechanisms = updates.RemoteMechanisms
+		updated.LocalMechanisms = updates.LocalMechanisms
+		model.UpdateDataplane(&updated)
 	}
 }
 
@@ -186,16 +186,16 @@ func (dataplaneRegistrarServer *dataplaneRegistrarServer) Stop() {
 // StartDataplaneRegistrarServer registers and starts gRPC server which is listening for
 // Network Service Dataplane Registrar requests.
 func StartDataplaneRegistrarServer(model model.Model) (*dataplaneRegistrarServer, error) {
-	tracer := opentracing.GlobalTracer()
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+	return StartDataplaneRegistrarServerAt(model, path.Join(DataplaneRegistrarSocketBaseDir, DataplaneRegistrarSocket))
+}
+
+// StartDataplaneRegistrarServerAt starts Network Service Dataplane Registrar gRPC server listening on the passed socket.
+func StartDataplaneRegistrarServerAt(model model.Model, socketPath string) (*dataplaneRegistrarServer, error) {
+	server := tools.NewServer()
 
 	dataplaneRegistrarServer := &dataplaneRegistrarServer{
 		grpcServer:                   server,
-		dataplaneRegistrarSocketPath: path.Join(DataplaneRegistrarSocketBaseDir, DataplaneRegistrarSocket),
+		dataplaneRegistrarSocketPath: socketPath,
 		model:                        model,
 	}
 
@@ -0,0 +1,124 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// endpointLoadReporter counts client connections served by local endpoints, and reports changed load
+// to the registry. Load is reported regardless of capacity, it is used for network service status as well.
+type endpointLoadReporter struct {
+	model.ModelListenerImpl
+	model           model.Model
+	serviceRegistry serviceregistry.ServiceRegistry
+	reported        map[string]uint32
+	updates         chan struct{}
+	done            chan struct{}
+}
+
+func newEndpointLoadReporter(model model.Model, serviceRegistry serviceregistry.ServiceRegistry) *endpointLoadReporter {
+	return &endpointLoadReporter{
+		model:           model,
+		serviceRegistry: serviceRegistry,
+		reported:        map[string]uint32{},
+		updates:         make(chan struct{}, 1),
+		done:            make(chan struct{}),
+	}
+}
+
+func (r *endpointLoadReporter) ClientConnectionAdded(clientConnection *model.ClientConnection) {
+	r.notify()
+}
+
+func (r *endpointLoadReporter) ClientConnectionUpdated(clientConnection *model.ClientConnection) {
+	r.notify()
+}
+
+func (r *endpointLoadReporter) ClientConnectionDeleted(clientConnection *model.ClientConnection) {
+	r.notify()
+}
+
+// notify schedules a report, several notifications are coalesced into one report.
+func (r *endpointLoadReporter) notify() {
+	select {
+	case r.updates <- struct{}{}:
+	default:
+	}
+}
+
+func (r *endpointLoadReporter) run() {
+	for {
+		select {
+		case <-r.updates:
+			r.report()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *endpointLoadReporter) stop() {
+	close(r.done)
+}
+
+func (r *endpointLoadReporter) report() {
+	loads := map[string]uint32{}
+	for name := range r.reported {
+		loads[name] = 0
+	}
+	// Connection state is not checked since it is changed concurrently by NSM, closed connections
+	// are counted until they are removed from model, and we are notified about it.
+	for _, clientConnection := range r.model.GetAllClientConnections() {
+		endpointName := clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()
+		endpoint := r.model.GetEndpoint(endpointName)
+		if endpoint == nil {
+			// Not a local endpoint
+			continue
+		}
+		loads[endpointName]++
+	}
+
+	var client registry.NetworkServiceRegistryClient
+	for name, load := range loads {
+		if reported, ok := r.reported[name]; ok && reported == load {
+			continue
+		}
+		if client == nil {
+			var err error
+			if client, err = r.serviceRegistry.NseRegistryClient(); err != nil {
+				logrus.Errorf("Failed to report NSE load, registry is not available: %v", err)
+				return
+			}
+		}
+		if _, err := client.UpdateNSELoad(context.Background(), &registry.UpdateNSELoadRequest{
+			EndpointName: name,
+			Load:         load,
+		}); err != nil {
+			logrus.Errorf("Failed to report load %d of NSE %s: %v", load, name, err)
+			continue
+		}
+		logrus.Infof("Reported load %d of NSE %s", load, name)
+		if load == 0 {
+			delete(r.reported, name)
+		} else {
+			r.reported[name] = load
+		}
+	}
+}
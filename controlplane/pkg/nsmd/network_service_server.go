@@ -10,6 +10,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 )
@@ -24,24 +25,36 @@ type networkServiceServer struct {
 	workspace       *Workspace
 	serviceRegistry serviceregistry.ServiceRegistry
 	manager         nsm.NetworkServiceManager
+	admission       *AdmissionController
 }
 
-func NewNetworkServiceServer(model model.Model, workspace *Workspace, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry) networkservice.NetworkServiceServer {
+func NewNetworkServiceServer(model model.Model, workspace *Workspace, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, admission *AdmissionController) networkservice.NetworkServiceServer {
 	rv := &networkServiceServer{
 		model:           model,
 		workspace:       workspace,
 		serviceRegistry: serviceRegistry,
 		manager:         manager,
+		admission:       admission,
 	}
 	return rv
 }
 
 func (srv *networkServiceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
-	logrus.Infof("Received request from client to connect to NetworkService: %v", request)
+	logger := tools.Log(ctx).WithField(tools.LogFieldNetworkService, request.GetConnection().GetNetworkService())
+	logger.Infof("Received request from client to connect to NetworkService: %v", request)
+	if srv.admission != nil {
+		release, err := srv.admission.Admit(srv.workspace.Name())
+		if err != nil {
+			logger.Errorf("Request to NetworkService rejected: %v", err)
+			return nil, err
+		}
+		defer release()
+	}
 	srv.updateMechanisms(request)
 
 	conn, err := srv.manager.Request(ctx, request)
 	if err != nil {
+		logger.Errorf("Request to NetworkService failed: %v", err)
 		return nil, err
 	}
 	result := conn.(*connection.Connection)
@@ -60,7 +73,7 @@ func (srv *networkServiceServer) updateMechanisms(request *networkservice.Networ
 }
 
 func (srv *networkServiceServer) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
-	logrus.Infof("Closing connection: %v", *connection)
+	tools.Log(ctx).WithField(tools.LogFieldConnectionID, connection.GetId()).Infof("Closing connection: %v", *connection)
 	clientConnection := srv.model.GetClientConnection(connection.GetId())
 	if clientConnection == nil {
 		return nil, fmt.Errorf("There is no such client connection %v", connection)
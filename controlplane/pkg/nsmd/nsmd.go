@@ -3,13 +3,13 @@ package nsmd
 import (
 	"fmt"
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/checkpoint"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
@@ -18,7 +18,6 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
-	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -53,9 +52,12 @@ type nsmServer struct {
 	manager          nsm.NetworkServiceManager
 	locationProvider serviceregistry.WorkspaceLocationProvider
 	localRegistry    *nseregistry.NSERegistry
+	checkpoint       *checkpoint.Checkpoint
 	registerServer   *grpc.Server
 	registerSock     net.Listener
 	regServer        *dataplaneRegistrarServer
+	admission        *AdmissionController
+	loadReporter     *endpointLoadReporter
 
 	xconManager               *services.ClientConnectionManager
 	monitorCrossConnectServer *crossconnect_monitor.CrossConnectMonitor
@@ -144,6 +146,9 @@ func (nsm *nsmServer) DeleteClientConnection(context context.Context, request *n
 	}
 
 	workspace.Close()
+	if nsm.admission != nil {
+		nsm.admission.RemoveWorkspace(workspace.Name())
+	}
 	nsm.Lock()
 	delete(nsm.workspaces, socket)
 	nsm.Unlock()
@@ -288,15 +293,21 @@ func (nsm *nsmServer) Stop() {
 	if nsm.regServer != nil {
 		nsm.regServer.Stop()
 	}
+	if nsm.loadReporter != nil {
+		nsm.model.RemoveListener(nsm.loadReporter)
+		nsm.loadReporter.stop()
+	}
+	nsm.model.RemoveListener(nsm.checkpoint)
+	nsm.checkpoint.Stop()
 }
 
-func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, apiRegistry serviceregistry.ApiRegistry) (NSMServer, error) {
+// StartNSMServer starts NSMD server, Request calls of workspaces are limited by admission controller, if it is passed.
+func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, apiRegistry serviceregistry.ApiRegistry, admission *AdmissionController) (NSMServer, error) {
 	var err error
 	if err = tools.SocketCleanup(ServerSock); err != nil {
 		return nil, err
 	}
 
-	tracer := opentracing.GlobalTracer()
 	locationProvider := serviceRegistry.NewWorkspaceProvider()
 
 	nsm := &nsmServer{
@@ -306,16 +317,31 @@ func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, servic
 		manager:          manager,
 		locationProvider: locationProvider,
 		localRegistry:    nseregistry.NewNSERegistry(locationProvider.NsmNSERegistryFile()),
+		checkpoint:       checkpoint.New(locationProvider.NsmConnectionCheckpointFile()),
+		admission:        admission,
 	}
 
-	nsm.registerServer = grpc.NewServer(
-		grpc.UnaryInterceptor(
-			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+	nsm.registerServer = tools.NewServer()
 
 	nsmdapi.RegisterNSMDServer(nsm.registerServer, nsm)
 
+	endpoints, err := setLocalNSM(model, serviceRegistry)
+	if err != nil {
+		logrus.Errorf("failed to set local NSM %+v", err)
+		nsm.Stop()
+		return nil, err
+	}
+
+	nsm.initMonitorServers()
+
+	// Restore client connections before any request is served, so new connections do not take their ids.
+	// They are reconciled with the dataplane state when it is connected.
+	connections := nsm.restoreConnections()
+
+	// Restore existing clients in case of NSMd restart.
+	nsm.restoreClients(endpoints)
+	nsm.monitorRestoredConnections(connections)
+
 	nsm.registerSock, err = apiRegistry.NewNSMServerListener()
 	if err != nil {
 		logrus.Errorf("failed to start device plugin grpc server %+v", err)
@@ -327,11 +353,6 @@ func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, servic
 			logrus.Error("failed to start device plugin grpc server")
 		}
 	}()
-	endpoints, err := setLocalNSM(model, serviceRegistry)
-	if err != nil {
-		logrus.Errorf("failed to set local NSM %+v", err)
-		return nil, err
-	}
 
 	// Check if the socket of NSM server is operation
 	_, conn, err := serviceRegistry.NSMDApiClient()
@@ -341,12 +362,60 @@ func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, servic
 	}
 	_ = conn.Close()
 	logrus.Infof("NSM gRPC socket: %s is operational", nsm.registerSock.Addr().String())
+	return nsm, nil
+}
 
-	// Restore existing clients in case of NSMd restart.
-	nsm.restoreClients(endpoints)
+// restoreConnections restores checkpointed client connections into the model, it returns the restored ones.
+func (nsm *nsmServer) restoreConnections() []*model.ClientConnection {
+	if "true" == os.Getenv(NsmdDeleteLocalRegistry) {
+		logrus.Errorf("Delete of connection checkpoint... by ENV VAR: %s", NsmdDeleteLocalRegistry)
+		nsm.checkpoint.Delete()
+	}
+	connections, err := nsm.checkpoint.Load()
+	if err != nil {
+		logrus.Errorf("NSMServer: Error Loading connection checkpoint: %v", err)
+	}
+	// Checkpoint should know about all the changes of restored connections.
+	nsm.model.AddListener(nsm.checkpoint)
 
-	nsm.initMonitorServers()
-	return nsm, nil
+	nsm.manager.RestoreCheckpoint(nsmClientConnections(connections))
+
+	restored := []*model.ClientConnection{}
+	for _, clientConnection := range connections {
+		if nsm.model.GetClientConnection(clientConnection.GetId()) != clientConnection {
+			// Connection is not restored.
+			nsm.checkpoint.ClientConnectionDeleted(clientConnection)
+			continue
+		}
+		restored = append(restored, clientConnection)
+	}
+	logrus.Infof("NSMD: Restore of %d of %d connections from checkpoint Complete...", len(restored), len(connections))
+	return restored
+}
+
+// monitorRestoredConnections passes restored local connections to monitors of the restored workspaces.
+func (nsm *nsmServer) monitorRestoredConnections(connections []*model.ClientConnection) {
+	nsm.Lock()
+	defer nsm.Unlock()
+	for _, clientConnection := range connections {
+		src := clientConnection.Xcon.GetLocalSource()
+		if src == nil {
+			continue
+		}
+		if ws, ok := nsm.workspaces[src.GetMechanism().GetWorkspace()]; ok {
+			ws.MonitorConnectionServer().Update(src)
+		}
+	}
+	// Endpoints of the restored connections are known now, so their load could be reported.
+	nsm.loadReporter.notify()
+}
+
+func nsmClientConnections(connections []*model.ClientConnection) []nsm.NSMClientConnection {
+	rv := []nsm.NSMClientConnection{}
+	for _, clientConnection := range connections {
+		rv = append(rv, clientConnection)
+	}
+	return rv
 }
 
 func (nsm *nsmServer) initMonitorServers() {
@@ -358,6 +427,10 @@ func (nsm *nsmServer) initMonitorServers() {
 	// Register CrossConnect monitorCrossConnectServer client as ModelListener
 	monitorCrossConnectClient := NewMonitorCrossConnectClient(nsm.monitorCrossConnectServer, nsm.monitorConnectionServer, nsm.xconManager)
 	nsm.model.AddListener(monitorCrossConnectClient)
+	// Report load of local endpoints to registry
+	nsm.loadReporter = newEndpointLoadReporter(nsm.model, nsm.serviceRegistry)
+	go nsm.loadReporter.run()
+	nsm.model.AddListener(nsm.loadReporter)
 }
 
 func (nsm *nsmServer) StartDataplaneRegistratorServer() error {
@@ -394,12 +467,7 @@ func setLocalNSM(model model.Model, serviceRegistry serviceregistry.ServiceRegis
 }
 
 func StartAPIServerAt(server NSMServer, sock net.Listener) error {
-	tracer := opentracing.GlobalTracer()
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+	grpcServer := tools.NewServer()
 
 	crossconnect.RegisterMonitorCrossConnectServer(grpcServer, server.MonitorCrossConnectServer())
 	connection.RegisterMonitorConnectionServer(grpcServer, server.MonitorConnectionServer())
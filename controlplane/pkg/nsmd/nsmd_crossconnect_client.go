@@ -16,15 +16,13 @@ package nsmd
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
-	opentracing "github.com/opentracing/opentracing-go"
 
-	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	local_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
@@ -32,10 +30,16 @@ import (
 	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
+const (
+	// dataplaneMonitorRetryDelay is a delay before re-establishing of the broken dataplane crossconnect monitor stream
+	dataplaneMonitorRetryDelay = 1 * time.Second
+)
+
 type NsmMonitorCrossConnectClient struct {
 	crossConnectMonitor *crossconnect_monitor.CrossConnectMonitor
 	connectionMonitor   *remote_connection_monitor.RemoteConnectionMonitor
@@ -63,15 +67,10 @@ func NewMonitorCrossConnectClient(crossConnectMonitor *crossconnect_monitor.Cros
 }
 
 func dial(ctx context.Context, network string, address string) (*grpc.ClientConn, error) {
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(),
+	conn, err := grpc.DialContext(ctx, address, tools.DialOptions(grpc.WithInsecure(), grpc.WithBlock(),
 		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
 			return net.Dial(network, addr)
-		}),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer)),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+		}))...)
 
 	return conn, err
 }
@@ -86,8 +85,10 @@ func (client *NsmMonitorCrossConnectClient) DataplaneAdded(dataplane *model.Data
 func (client *NsmMonitorCrossConnectClient) DataplaneDeleted(dataplane *model.Dataplane) {
 	clientConnections := client.xconManager.GetClientConnectionsByDataplane(dataplane.RegisteredName)
 	client.xconManager.UpdateClientConnectionDataplaneStateDown(clientConnections)
-	client.dataplanes[dataplane.RegisteredName]()
-	delete(client.dataplanes, dataplane.RegisteredName)
+	if cancel, ok := client.dataplanes[dataplane.RegisteredName]; ok {
+		cancel()
+		delete(client.dataplanes, dataplane.RegisteredName)
+	}
 }
 
 func (client *NsmMonitorCrossConnectClient) ClientConnectionAdded(clientConnection *model.ClientConnection) {
@@ -136,34 +137,49 @@ func (client *NsmMonitorCrossConnectClient) ClientConnectionDeleted(clientConnec
 
 // dataplaneCrossConnectMonitor is per registered dataplane crossconnect monitoring routine.
 // It creates a grpc client for the socket advertsied by the dataplane and listens for a stream of Cross Connect Events.
-// If it detects a failure of the connection, it will indicate that dataplane is no longer operational. In this case
-// monitor will remove all dataplane connections and will terminate itself.
+// If the stream is broken, it is re-established and resumed from the last received event, so the events missed
+// meanwhile are replayed by the dataplane. Monitor terminates itself once the dataplane is deleted.
 func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(dataplane *model.Dataplane, ctx context.Context) {
+	// The last received event, the stream is resumed from it
+	resume := &crossconnect.MonitorRequest{}
+	for {
+		err := client.monitorDataplaneCrossConnects(dataplane, resume, ctx)
+		if ctx.Err() != nil {
+			logrus.Info("Context timeout exceeded...")
+			return
+		}
+		logrus.Errorf("Dataplane %s crossconnect monitor failed: %v, re-establishing in %v", dataplane.RegisteredName, err, dataplaneMonitorRetryDelay)
+		select {
+		case <-ctx.Done():
+			logrus.Info("Context timeout exceeded...")
+			return
+		case <-time.After(dataplaneMonitorRetryDelay):
+		}
+	}
+}
+
+func (client *NsmMonitorCrossConnectClient) monitorDataplaneCrossConnects(dataplane *model.Dataplane, resume *crossconnect.MonitorRequest, ctx context.Context) error {
 	logrus.Infof("Connecting to Dataplane %s %s", dataplane.RegisteredName, dataplane.SocketLocation)
-	conn, err := dial(context.Background(), "unix", dataplane.SocketLocation)
+	conn, err := dial(ctx, "unix", dataplane.SocketLocation)
 	if err != nil {
-		logrus.Errorf("failure to communicate with the socket %s with error: %+v", dataplane.SocketLocation, err)
-		return
+		return fmt.Errorf("failure to communicate with the socket %s with error: %+v", dataplane.SocketLocation, err)
 	}
 	defer conn.Close()
 
 	monitorClient := crossconnect.NewMonitorCrossConnectClient(conn)
-	stream, err := monitorClient.MonitorCrossConnects(ctx, &empty.Empty{})
+	stream, err := monitorClient.MonitorCrossConnects(ctx, resume)
 	if err != nil {
-		logrus.Error(err)
-		return
+		return err
 	}
-	logrus.Infof("Monitoring %v CrossConnections...", dataplane.RegisteredName)
+	logrus.Infof("Monitoring %v CrossConnections from %s %d...", dataplane.RegisteredName, resume.ResumeFromServerId, resume.ResumeFromSequence)
 	for {
 		select {
 		case <-ctx.Done():
-			logrus.Info("Context timeout exceeded...")
-			return
+			return ctx.Err()
 		default:
 			event, err := stream.Recv()
 			if err != nil {
-				logrus.Error(err)
-				return
+				return err
 			}
 			logrus.Infof("Receive event from dataplane %s: %s %s", dataplane.RegisteredName, event.Type, event.CrossConnects)
 
@@ -175,15 +191,18 @@ func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(datapla
 
 				switch event.GetType() {
 				case crossconnect.CrossConnectEventType_UPDATE:
+					// Model should be updated before close and heal: close removes the connection from the model and heal
+					// replaces it, so this event must not add the stale connection back. Closed connection is not healed.
+					client.xconManager.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+						clientConnection.Xcon = xcon
+					})
+					client.xconManager.UpdateClientConnection(clientConnection)
+					client.crossConnectMonitor.Update(xcon)
 					if src := xcon.GetLocalSource(); src != nil && src.State == local_connection.State_DOWN {
 						client.xconManager.UpdateClientConnectionSrcStateDown(clientConnection)
-					}
-					if dst := xcon.GetLocalDestination(); dst != nil && dst.State == local_connection.State_DOWN {
+					} else if dst := xcon.GetLocalDestination(); dst != nil && dst.State == local_connection.State_DOWN {
 						client.xconManager.UpdateClientConnectionDstStateDown(clientConnection)
 					}
-					clientConnection.Xcon = xcon
-					client.xconManager.UpdateClientConnection(clientConnection)
-					client.crossConnectMonitor.Update(xcon)
 				case crossconnect.CrossConnectEventType_DELETE:
 					client.crossConnectMonitor.Delete(xcon)
 				case crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER:
@@ -197,13 +216,15 @@ func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(datapla
 				}
 				client.xconManager.UpdateFromInitialState(connects, dataplane)
 			}
+			resume.ResumeFromServerId = event.GetServerId()
+			resume.ResumeFromSequence = event.GetSequence()
 		}
 	}
 }
 
 func (client *NsmMonitorCrossConnectClient) remotePeerConnectionMonitor(remotePeer *registry.NetworkServiceManager, ctx context.Context) {
 	logrus.Infof("NSM-PeerMonitor(%v): Connecting...", remotePeer.Name)
-	conn, err := grpc.Dial(remotePeer.Url, grpc.WithInsecure())
+	conn, err := grpc.Dial(remotePeer.Url, tools.DialOptions(grpc.WithInsecure())...)
 	if err != nil {
 		logrus.Errorf("NSM-PeerMonitor(%v): Failed to dial Network Service Registry at %s: %s", remotePeer.GetName(), remotePeer.Url, err)
 		return
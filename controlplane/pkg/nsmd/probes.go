@@ -57,9 +57,24 @@ func liveness(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("OK"))
 }
 
-func BeginHealthCheck() {
+func metricsHandler(metrics *AdmissionMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m := metrics.Get()
+		fmt.Fprintf(w, "nsmd_requests_admitted_total %d\n", m.Admitted)
+		fmt.Fprintf(w, "nsmd_requests_rejected_total{reason=\"global_rate\"} %d\n", m.RejectedGlobalRate)
+		fmt.Fprintf(w, "nsmd_requests_rejected_total{reason=\"workspace_rate\"} %d\n", m.RejectedWorkspaceRate)
+		fmt.Fprintf(w, "nsmd_requests_rejected_total{reason=\"concurrency\"} %d\n", m.RejectedConcurrency)
+		fmt.Fprintf(w, "nsmd_requests_in_flight %d\n", m.InFlight)
+	}
+}
+
+// BeginHealthCheck serves liveness/readiness probes and Request admission metrics, if they are passed.
+func BeginHealthCheck(metrics *AdmissionMetrics) {
 	logrus.Debug("Starting NSMD liveness/readiness healthcheck")
 	http.HandleFunc("/liveness", liveness)
 	http.HandleFunc("/readiness", readiness)
+	if metrics != nil {
+		http.HandleFunc("/metrics", metricsHandler(metrics))
+	}
 	http.ListenAndServe(healthcheckProbesPort, nil)
 }
@@ -119,6 +119,22 @@ func (es *registryServer) RemoveNSE(ctx context.Context, request *registry.Remov
 	return &empty.Empty{}, nil
 }
 
+func (es *registryServer) UpdateNSELoad(ctx context.Context, request *registry.UpdateNSELoadRequest) (*empty.Empty, error) {
+	logrus.Infof("Received Endpoint load update request: %+v", request)
+	client, err := es.nsm.serviceRegistry.NseRegistryClient()
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	if _, err = client.UpdateNSELoad(context.Background(), request); err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 func (es *registryServer) Close() {
 
 }
@@ -9,7 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
@@ -19,7 +18,6 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
-	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -76,12 +74,7 @@ func (impl *nsmdServiceRegistry) RemoteNetworkServiceClient(ctx context.Context,
 	}
 
 	logrus.Infof("Remote Network Service %s is available at %s, attempting to connect...", nsm.GetName(), nsm.GetUrl())
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.DialContext(ctx, nsm.Url, grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	conn, err := grpc.DialContext(ctx, nsm.Url, tools.DialOptions(grpc.WithInsecure())...)
 	if err != nil {
 		logrus.Errorf("Failed to dial Remote Network Service Manager %s at %s: %s", nsm.GetName(), nsm.Url, err)
 		return nil, nil, err
@@ -188,12 +181,7 @@ func (impl *nsmdServiceRegistry) initRegistryClient() {
 	for impl.stopRedial {
 		tools.WaitForPortAvailable(context.Background(), "tcp", impl.registryAddress, 1*time.Second)
 		logrus.Println("Registry port now available, attempting to connect...")
-		tracer := opentracing.GlobalTracer()
-		conn, err := grpc.Dial(impl.registryAddress, grpc.WithInsecure(),
-			grpc.WithUnaryInterceptor(
-				otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-			grpc.WithStreamInterceptor(
-				otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+		conn, err := grpc.Dial(impl.registryAddress, tools.DialOptions(grpc.WithInsecure())...)
 		if err != nil {
 			logrus.Errorf("Failed to dial Network Service Registry at %s: %s", impl.registryAddress, err)
 			continue
@@ -261,6 +249,7 @@ type defaultWorkspaceProvider struct {
 	nsmServerSocket string
 	nsmClientSocket string
 	nseRegistryFile string
+	checkpointFile  string
 }
 
 func NewDefaultWorkspaceProvider() serviceregistry.WorkspaceLocationProvider {
@@ -278,6 +267,7 @@ func NewWorkspaceProvider(rootDir string) serviceregistry.WorkspaceLocationProvi
 		nsmServerSocket: "nsm.server.io.sock",
 		nsmClientSocket: "nsm.client.io.sock",
 		nseRegistryFile: "nse.registry",
+		checkpointFile:  "connections.checkpoint",
 	}
 }
 
@@ -293,6 +283,10 @@ func (w *defaultWorkspaceProvider) NsmNSERegistryFile() string {
 	return w.nsmBaseDir + w.nseRegistryFile
 }
 
+func (w *defaultWorkspaceProvider) NsmConnectionCheckpointFile() string {
+	return w.nsmBaseDir + w.checkpointFile
+}
+
 func (w *defaultWorkspaceProvider) ClientBaseDir() string {
 	return w.clientBaseDir
 }
@@ -0,0 +1,240 @@
+// Package recordlog implements an append only file of checksummed records used by NSMD to persist its state
+// between restarts.
+//
+// File format:
+//
+//	header: magic | uint16 version
+//	frame: uint32 payload length | uint32 CRC-32C of payload | payload
+//
+// Every frame is written with a single write followed by fsync, so a crash could leave only the last frame torn.
+// Torn or corrupted frames are detected by the length and checksum. The file is compacted by atomic replacement
+// with a snapshot of the state.
+package recordlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	frameHeaderSize = 8
+	// MaxRecordSize protects from allocating huge buffers for garbage lengths
+	MaxRecordSize = 16 * 1024 * 1024
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// NoHeaderError is returned by Read if the file does not start with the log header, e.g. it has a legacy format.
+type NoHeaderError struct {
+	Data []byte
+}
+
+func (e *NoHeaderError) Error() string {
+	return "no record log header"
+}
+
+// BrokenError is returned by Read along with the records preceding the broken one.
+type BrokenError struct {
+	Offset int
+	Err    error
+}
+
+func (e *BrokenError) Error() string {
+	return fmt.Sprintf("broken record at offset %d: %v", e.Offset, e.Err)
+}
+
+// Log is a record log file.
+type Log struct {
+	file    string
+	magic   string
+	version uint16
+}
+
+// New creates a log of the file, the file starts with magic and version header.
+func New(file, magic string, version uint16) *Log {
+	return &Log{
+		file:    file,
+		magic:   magic,
+		version: version,
+	}
+}
+
+// File returns the log file path.
+func (l *Log) File() string {
+	return l.file
+}
+
+// Read returns records of the file. Errors returned are the file system errors, *NoHeaderError,
+// *BrokenError if some record could not be decoded, or version mismatch error.
+func (l *Log) Read() ([][]byte, error) {
+	data, err := ioutil.ReadFile(l.file)
+	if err != nil {
+		return nil, err
+	}
+	headerSize := len(l.magic) + 2
+	if len(data) < headerSize || !bytes.HasPrefix(data, []byte(l.magic)) {
+		return nil, &NoHeaderError{Data: data}
+	}
+	if version := binary.BigEndian.Uint16(data[len(l.magic):]); version != l.version {
+		return nil, fmt.Errorf("unsupported %s version %d, expected %d", l.file, version, l.version)
+	}
+	records, err := DecodeFrames(data[headerSize:])
+	if broken, ok := err.(*BrokenError); ok {
+		broken.Offset += headerSize
+	}
+	return records, err
+}
+
+// Append writes the records at the end of the file with a single write. The file should be created by Rewrite.
+func (l *Log) Append(records ...[]byte) error {
+	data, err := encodeFrames(records)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// Rewrite atomically replaces the file with the records.
+func (l *Log) Rewrite(records [][]byte) error {
+	data, err := encodeFrames(records)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, len(l.magic)+2)
+	copy(header, l.magic)
+	binary.BigEndian.PutUint16(header[len(l.magic):], l.version)
+
+	tmpFile := l.file + "_tmp"
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(header, data...)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Now we need to replace existing file with new one.
+	if err := os.Rename(tmpFile, l.file); err != nil {
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(l.file)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+	return nil
+}
+
+// Delete removes the file.
+func (l *Log) Delete() {
+	_ = os.Remove(l.file)
+}
+
+func encodeFrames(records [][]byte) ([]byte, error) {
+	var data []byte
+	for _, r := range records {
+		frame, err := EncodeFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, frame...)
+	}
+	return data, nil
+}
+
+// EncodeFrame returns the payload prefixed with its length and checksum.
+func EncodeFrame(payload []byte) ([]byte, error) {
+	if len(payload) == 0 || len(payload) > MaxRecordSize {
+		return nil, fmt.Errorf("invalid record size %d", len(payload))
+	}
+	rv := make([]byte, frameHeaderSize, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(rv, uint32(len(payload)))
+	binary.BigEndian.PutUint32(rv[4:], crc32.Checksum(payload, crcTable))
+	return append(rv, payload...), nil
+}
+
+// DecodeFrame decodes the frame at the start of data and returns its payload and size.
+// io.ErrUnexpectedEOF is returned if data ends in the middle of the frame.
+func DecodeFrame(data []byte) ([]byte, int, error) {
+	if len(data) < frameHeaderSize {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	size := binary.BigEndian.Uint32(data)
+	if size == 0 || size > MaxRecordSize {
+		return nil, 0, fmt.Errorf("invalid record size %d", size)
+	}
+	if uint64(len(data)-frameHeaderSize) < uint64(size) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	payload := data[frameHeaderSize : frameHeaderSize+int(size)]
+	if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(data[4:]) {
+		return nil, 0, fmt.Errorf("record checksum mismatch")
+	}
+	return payload, frameHeaderSize + int(size), nil
+}
+
+// DecodeFrames decodes consecutive frames. Payloads before the first broken frame are returned
+// along with *BrokenError describing it.
+func DecodeFrames(data []byte) ([][]byte, error) {
+	var records [][]byte
+	for offset := 0; offset < len(data); {
+		payload, size, err := DecodeFrame(data[offset:])
+		if err != nil {
+			return records, &BrokenError{Offset: offset, Err: err}
+		}
+		records = append(records, payload)
+		offset += size
+	}
+	return records, nil
+}
+
+// EncodeFields concatenates the fields prefixing each of them with uvarint length.
+func EncodeFields(fields ...[]byte) []byte {
+	var rv []byte
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	for _, field := range fields {
+		n := binary.PutUvarint(lenBuf, uint64(len(field)))
+		rv = append(rv, lenBuf[:n]...)
+		rv = append(rv, field...)
+	}
+	return rv
+}
+
+// DecodeFields splits data encoded by EncodeFields to exactly count fields.
+func DecodeFields(data []byte, count int) ([][]byte, error) {
+	var fields [][]byte
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	for i := 0; i < count; i++ {
+		length, n := binary.Uvarint(data)
+		// Only the shortest length encoding is valid, so every encoded record has a single representation.
+		if n <= 0 || length > uint64(len(data)-n) || n != binary.PutUvarint(lenBuf, length) {
+			return nil, fmt.Errorf("invalid field %d", i)
+		}
+		fields = append(fields, data[n:n+int(length)])
+		data = data[n+int(length):]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(data))
+	}
+	return fields, nil
+}
@@ -0,0 +1,118 @@
+package recordlog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func FuzzFrameRoundTrip(f *testing.F) {
+	f.Add([]byte("record"))
+	f.Add([]byte{0})
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		frame, err := EncodeFrame(payload)
+		if len(payload) == 0 {
+			if err == nil {
+				t.Fatalf("empty payload is encoded")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to encode %v: %v", payload, err)
+		}
+		decoded, size, err := DecodeFrame(frame)
+		if err != nil || size != len(frame) || !bytes.Equal(decoded, payload) {
+			t.Fatalf("%v of size %d is decoded as %v of size %d: %v", payload, len(frame), decoded, size, err)
+		}
+		// Every truncated frame is reported as torn.
+		for i := 0; i < len(frame); i++ {
+			if _, _, err := DecodeFrame(frame[:i]); err == nil {
+				t.Fatalf("truncated frame of %d bytes is decoded", i)
+			}
+		}
+	})
+}
+
+func FuzzDecodeFrames(f *testing.F) {
+	valid, _ := EncodeFrame([]byte("record"))
+	f.Add(valid)
+	f.Add(append(append([]byte{}, valid...), valid[:5]...))
+	f.Add([]byte{0, 0, 0, 1, 0, 0, 0, 0, 1})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		payloads, err := DecodeFrames(data)
+		// Decoded payloads are encoded back to the same bytes.
+		var encoded []byte
+		for _, payload := range payloads {
+			frame, encodeErr := EncodeFrame(payload)
+			if encodeErr != nil {
+				t.Fatalf("failed to encode decoded payload %v: %v", payload, encodeErr)
+			}
+			encoded = append(encoded, frame...)
+		}
+		if !bytes.HasPrefix(data, encoded) {
+			t.Fatalf("decoded payloads do not match the input")
+		}
+		if err == nil && len(encoded) != len(data) {
+			t.Fatalf("%d bytes left undecoded without error", len(data)-len(encoded))
+		}
+	})
+}
+
+func FuzzFieldsRoundTrip(f *testing.F) {
+	f.Add([]byte("a"), []byte{}, []byte("\t\n"))
+	f.Fuzz(func(t *testing.T, field1, field2, field3 []byte) {
+		fields, err := DecodeFields(EncodeFields(field1, field2, field3), 3)
+		if err != nil {
+			t.Fatalf("failed to decode fields: %v", err)
+		}
+		for i, expected := range [][]byte{field1, field2, field3} {
+			if !bytes.Equal(fields[i], expected) {
+				t.Fatalf("field %d decoded as %q, expected %q", i, fields[i], expected)
+			}
+		}
+	})
+}
+
+func TestLogAppendRewrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	log := New(path.Join(dir, "log"), "TEST", 1)
+
+	if _, err := log.Read(); !os.IsNotExist(err) {
+		t.Fatalf("missing file is read: %v", err)
+	}
+	if err := log.Rewrite([][]byte{[]byte("a")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Append([]byte("b"), []byte("c")); err != nil {
+		t.Fatal(err)
+	}
+	records, err := log.Read()
+	if err != nil || len(records) != 3 || string(records[2]) != "c" {
+		t.Fatalf("read %q: %v", records, err)
+	}
+
+	// Torn last record
+	info, _ := os.Stat(log.File())
+	if err := os.Truncate(log.File(), info.Size()-1); err != nil {
+		t.Fatal(err)
+	}
+	records, err = log.Read()
+	if _, ok := err.(*BrokenError); !ok || len(records) != 2 {
+		t.Fatalf("read %q: %v", records, err)
+	}
+
+	if err := ioutil.WriteFile(log.File(), []byte("legacy"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.Read(); err == nil {
+		t.Fatalf("file without header is read")
+	} else if noHeader, ok := err.(*NoHeaderError); !ok || string(noHeader.Data) != "legacy" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
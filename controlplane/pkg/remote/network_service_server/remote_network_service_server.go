@@ -13,6 +13,7 @@ import (
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 )
 
@@ -39,22 +40,23 @@ func NewRemoteNetworkServiceServer(model model.Model, manager nsm.NetworkService
 }
 
 func (srv *remoteNetworkServiceServer) Request(ctx context.Context, request *remote_networkservice.NetworkServiceRequest) (*remote_connection.Connection, error) {
-	logrus.Infof("RemoteNSMD: Received request from client to connect to NetworkService: %v", request)
+	logger := tools.Log(ctx).WithField(tools.LogFieldNetworkService, request.GetConnection().GetNetworkService())
+	logger.Infof("RemoteNSMD: Received request from client to connect to NetworkService: %v", request)
 	conn, err := srv.manager.Request(ctx, request)
 	if err != nil {
-		logrus.Error(err)
+		logger.Error(err)
 		return nil, err
 	}
 
 	result := conn.(*remote_connection.Connection)
 	srv.monitor.Update(result)
 
-	logrus.Info("RemoteNSMD: Dataplane configuration done...")
+	logger.WithField(tools.LogFieldConnectionID, result.GetId()).Info("RemoteNSMD: Dataplane configuration done...")
 	return result, nil
 }
 
 func (srv *remoteNetworkServiceServer) Close(ctx context.Context, connection *remote_connection.Connection) (*empty.Empty, error) {
-	logrus.Infof("Remote closing connection: %v", *connection)
+	tools.Log(ctx).WithField(tools.LogFieldConnectionID, connection.GetId()).Infof("Remote closing connection: %v", *connection)
 	clientConnection := srv.model.GetClientConnection(connection.GetId())
 	if clientConnection == nil {
 		return nil, fmt.Errorf("There is no such client connection %v", connection)
@@ -0,0 +1,59 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+// IsFull checks if endpoint serves as many connections as its advertised capacity.
+func IsFull(nse *registry.NetworkServiceEndpoint) bool {
+	return nse.GetCapacity() > 0 && nse.GetLoad() >= nse.GetCapacity()
+}
+
+// AvailableEndpoints returns endpoints which are able to accept one more connection.
+func AvailableEndpoints(networkServiceEndpoints []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	result := []*registry.NetworkServiceEndpoint{}
+	for _, nse := range networkServiceEndpoints {
+		if !IsFull(nse) {
+			result = append(result, nse)
+		}
+	}
+	return result
+}
+
+// LeastLoadedEndpoints returns endpoints with the lowest load to capacity ratio.
+// Endpoints without capacity set are never considered loaded.
+func LeastLoadedEndpoints(networkServiceEndpoints []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	result := []*registry.NetworkServiceEndpoint{}
+	var minUtilization float64
+	for _, nse := range networkServiceEndpoints {
+		u := utilization(nse)
+		if len(result) == 0 || u < minUtilization {
+			result = []*registry.NetworkServiceEndpoint{nse}
+			minUtilization = u
+		} else if u == minUtilization {
+			result = append(result, nse)
+		}
+	}
+	return result
+}
+
+func utilization(nse *registry.NetworkServiceEndpoint) float64 {
+	if nse.GetCapacity() == 0 {
+		return 0
+	}
+	return float64(nse.GetLoad()) / float64(nse.GetCapacity())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+func endpointNames(endpoints []*registry.NetworkServiceEndpoint) []string {
+	names := []string{}
+	for _, nse := range endpoints {
+		names = append(names, nse.GetEndpointName())
+	}
+	return names
+}
+
+func TestLoadSelection(t *testing.T) {
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{EndpointName: "full", Capacity: 10, Load: 10},
+		{EndpointName: "half", Capacity: 10, Load: 5},
+		{EndpointName: "tenth", Capacity: 10, Load: 1},
+		{EndpointName: "tenth-2", Capacity: 20, Load: 2},
+		{EndpointName: "unlimited", Load: 100},
+	}
+	tests := []struct {
+		name string
+		got  []*registry.NetworkServiceEndpoint
+		want []string
+	}{
+		{
+			name: "available",
+			got:  AvailableEndpoints(endpoints),
+			want: []string{"half", "tenth", "tenth-2", "unlimited"},
+		},
+		{
+			name: "least loaded",
+			got:  LeastLoadedEndpoints(endpoints[:4]),
+			want: []string{"tenth", "tenth-2"},
+		},
+		{
+			name: "unlimited is never loaded",
+			got:  LeastLoadedEndpoints(endpoints),
+			want: []string{"unlimited"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := endpointNames(tt.got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundRobinSkipsFullEndpoints(t *testing.T) {
+	rr := NewRoundRobinSelector()
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{EndpointName: "full", Capacity: 1, Load: 1},
+		{EndpointName: "free", Capacity: 2, Load: 1},
+	}
+	for i := 0; i < 3; i++ {
+		if got := rr.SelectEndpoint(nil, ns, endpoints); got.GetEndpointName() != "free" {
+			t.Fatalf("SelectEndpoint() = %v, want free", got.GetEndpointName())
+		}
+	}
+	endpoints[1].Load = 2
+	if got := rr.SelectEndpoint(nil, ns, endpoints); got != nil {
+		t.Fatalf("SelectEndpoint() = %v, want nil", got)
+	}
+}
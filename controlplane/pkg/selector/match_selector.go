@@ -68,6 +68,7 @@ func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.N
 			}
 		}
 
+		nseCandidates = AvailableEndpoints(nseCandidates)
 		if len(nseCandidates) > 0 {
 			// We found candidates. Use RoundRobin to select one
 			return m.roundRobin.SelectEndpoint(nil, ns, nseCandidates)
@@ -23,6 +23,8 @@ func (rr *roundRobinSelector) SelectEndpoint(requestConnection *connection.Conne
 	if rr == nil {
 		return nil
 	}
+	// Skip endpoints without free capacity and prefer least loaded ones.
+	networkServiceEndpoints = LeastLoadedEndpoints(AvailableEndpoints(networkServiceEndpoints))
 	if len(networkServiceEndpoints) == 0 {
 		return nil
 	}
@@ -54,4 +54,6 @@ type WorkspaceLocationProvider interface {
 
 	// A persistent file based NSE <-> Workspace registry.
 	NsmNSERegistryFile() string
+	// A persistent file with client connections.
+	NsmConnectionCheckpointFile() string
 }
@@ -35,9 +35,15 @@ func (m *ClientConnectionManager) UpdateClientConnection(clientConnection *model
 	m.model.UpdateClientConnection(clientConnection)
 }
 
+func (m *ClientConnectionManager) ApplyClientConnectionChanges(clientConnection *model.ClientConnection, changeFunc func(*model.ClientConnection)) {
+	m.model.ApplyClientConnectionChanges(clientConnection, changeFunc)
+}
+
 func (m *ClientConnectionManager) UpdateClientConnectionSrcStateDown(clientConnection *model.ClientConnection) {
 	logrus.Info("ClientConnection src state is down")
-	clientConnection.Xcon.GetLocalSource().State = connection.State_DOWN
+	m.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+		xcon.GetLocalSource().State = connection.State_DOWN
+	})
 	m.model.UpdateClientConnection(clientConnection)
 	_ = m.manager.Close(context.Background(), clientConnection)
 }
@@ -54,11 +60,13 @@ func (m *ClientConnectionManager) UpdateClientConnectionDataplaneStateDown(clien
 
 func (m *ClientConnectionManager) UpdateClientConnectionDstStateDown(clientConnection *model.ClientConnection) {
 	logrus.Info("ClientConnection dst state is down")
-	if clientConnection.Xcon.GetLocalDestination() != nil {
-		clientConnection.Xcon.GetLocalDestination().State = connection.State_DOWN
-	} else if clientConnection.Xcon.GetRemoteDestination() != nil {
-		clientConnection.Xcon.GetRemoteDestination().State = remote_connection.State_DOWN
-	}
+	m.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+		if xcon.GetLocalDestination() != nil {
+			xcon.GetLocalDestination().State = connection.State_DOWN
+		} else if xcon.GetRemoteDestination() != nil {
+			xcon.GetRemoteDestination().State = remote_connection.State_DOWN
+		}
+	})
 	m.model.UpdateClientConnection(clientConnection)
 	m.manager.Heal(clientConnection, nsm.HealState_DstDown)
 }
@@ -77,18 +85,31 @@ func (m *ClientConnectionManager) UpdateClientConnectionDstUpdated(clientConnect
 		// Since they are same, we do not need to do anything.
 		return
 	}
-	clientConnection.Xcon.Destination = &crossconnect.CrossConnect_RemoteDestination{
-		RemoteDestination: remoteConnection,
-	}
+	m.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+		xcon.Destination = &crossconnect.CrossConnect_RemoteDestination{
+			RemoteDestination: remoteConnection,
+		}
+	})
 	m.manager.Heal(clientConnection, nsm.HealState_DstUpdate)
 }
 
 func (m *ClientConnectionManager) markSourceConnectionDown(clientConnection *model.ClientConnection) {
-	if clientConnection.Xcon.GetRemoteSource() != nil {
-		clientConnection.Xcon.GetRemoteSource().State = remote_connection.State_DOWN
-	} else if clientConnection.Xcon.GetLocalSource() != nil {
-		clientConnection.Xcon.GetLocalSource().State = connection.State_DOWN
-	}
+	m.changeXcon(clientConnection, func(xcon *crossconnect.CrossConnect) {
+		if xcon.GetRemoteSource() != nil {
+			xcon.GetRemoteSource().State = remote_connection.State_DOWN
+		} else if xcon.GetLocalSource() != nil {
+			xcon.GetLocalSource().State = connection.State_DOWN
+		}
+	})
+}
+
+// changeXcon changes a copy of the connection cross connect, since the cross connect is shared with monitors.
+func (m *ClientConnectionManager) changeXcon(clientConnection *model.ClientConnection, changeFunc func(*crossconnect.CrossConnect)) {
+	m.model.ApplyClientConnectionChanges(clientConnection, func(clientConnection *model.ClientConnection) {
+		xcon := proto.Clone(clientConnection.Xcon).(*crossconnect.CrossConnect)
+		changeFunc(xcon)
+		clientConnection.Xcon = xcon
+	})
 }
 
 func (m *ClientConnectionManager) GetClientConnectionByXcon(xcon *crossconnect.CrossConnect) *model.ClientConnection {
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func TestHealRemoteDestinationKeepsChainLabels(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	srv2 := newNSMDFullServer(Worker, storage)
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv2.testModel.AddEndpoint(srv2.registerFakeEndpointWithName("golden_network", "test", Worker, "ep1"))
+	storage.services["golden_network"].Chain = []*registry.ChainHop{
+		{NetworkService: "firewall"},
+		{NetworkService: "golden_network"},
+		{NetworkService: "golden_network"},
+	}
+
+	l1 := newTestConnectionModelListener()
+	srv.testModel.AddListener(l1)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Second hop is requested by the first hop endpoint
+	request := createRequest(false)
+	request.Connection.Labels = map[string]string{
+		registry.ChainServiceLabel: "golden_network",
+		registry.ChainHopLabel:     "1",
+	}
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+
+	nextHopLabels := map[string]string{
+		registry.ChainServiceLabel: "golden_network",
+		registry.ChainHopLabel:     "2",
+	}
+	nse := srv2.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient)
+	Expect(nse.req.GetConnection().GetLabels()).To(Equal(nextHopLabels))
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection.Xcon.GetRemoteDestination().GetLabels()).To(Equal(nextHopLabels))
+	l1.WaitAdd(1, time.Second*10, t)
+
+	// Remote NSM of the healed connection is requested for the same chain hop.
+	srv.manager.Heal(clientConnection, nsm.HealState_DstDown)
+	healed := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(healed.ConnectionState).To(Equal(model.ClientConnection_Ready))
+	Expect(healed.Xcon.GetRemoteDestination().GetLabels()).To(Equal(nextHopLabels))
+}
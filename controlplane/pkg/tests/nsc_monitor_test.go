@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+func TestNSCMonitorRestoresDeletedConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", Master))
+
+	response, conn := srv.requestNSM("nsm-1")
+	defer conn.Close()
+	nsc, err := client.NewNSMClient(context.Background(), &common.NSConfiguration{
+		NsmServerSocket: response.HostBasedir + "/" + response.Workspace + "/" + response.NsmServerSocket,
+		NsmClientSocket: response.HostBasedir + "/" + response.Workspace + "/" + response.NsmClientSocket,
+		Workspace:       response.Workspace,
+		OutgoingNscName: "golden_network",
+	})
+	Expect(err).To(BeNil())
+	defer nsc.Destroy()
+
+	outgoing, err := nsc.Connect("nsm", "kernel", "Primary interface")
+	Expect(err).To(BeNil())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := nsc.MonitorEvents(ctx)
+	expectEvent := func(state client.ConnectionState) *client.ConnectionEvent {
+		for {
+			select {
+			case event := <-events:
+				if event.State == state {
+					return event
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("No %v connection event received", state)
+				return nil
+			}
+		}
+	}
+	Expect(expectEvent(client.ConnectionUp).Connection.GetId()).To(Equal(outgoing.GetId()))
+
+	// Close connection bypassing the client, so it is lost for the client.
+	_, err = nsc.NsClient.Close(context.Background(), outgoing)
+	Expect(err).To(BeNil())
+
+	Expect(expectEvent(client.ConnectionLost).Connection.GetId()).To(Equal(outgoing.GetId()))
+	restored := expectEvent(client.ConnectionRestored).Connection
+	Expect(restored.GetNetworkService()).To(Equal("golden_network"))
+	Expect(nsc.OutgoingConnections).To(Equal([]*connection.Connection{restored}))
+	Expect(srv.testModel.GetClientConnection(restored.GetId())).NotTo(BeNil())
+}
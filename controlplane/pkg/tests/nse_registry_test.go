@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"encoding/base64"
+
+	"github.com/gogo/protobuf/proto"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nseregistry"
 	. "github.com/onsi/gomega"
@@ -100,3 +103,74 @@ func tmpFile() (string) {
 	_ = os.Remove(fileName)
 	return fileName
 }
+
+func TestNSELegacyRegistryMigration(t *testing.T) {
+	RegisterTestingT(t)
+	fileName := tmpFile()
+	defer os.Remove(fileName)
+
+	nseReg, err := proto.Marshal(createNSEReg("endpoint1"))
+	Expect(err).To(BeNil())
+	legacy := "CLE\tnsm-1\nCLE\tnsm\\r,2\nNSE\tendpoint1\tnsm-1\t" + base64.StdEncoding.EncodeToString(nseReg) + "\n"
+	Expect(ioutil.WriteFile(fileName, []byte(legacy), 0600)).To(BeNil())
+
+	reg := nseregistry.NewNSERegistry(fileName)
+	clients, nses, err := reg.LoadRegistry()
+	Expect(err).To(BeNil())
+	Expect(clients).To(Equal([]string{"nsm-1", "nsm\r2"}))
+	Expect(nses).To(Equal(map[string]nseregistry.NSEEntry{"endpoint1": createEntry("nsm-1", "endpoint1")}))
+
+	// Migrated file is read in the new format by the restarted NSMD.
+	data, err := ioutil.ReadFile(fileName)
+	Expect(err).To(BeNil())
+	Expect(string(data)).NotTo(HavePrefix("CLE"))
+	clients, nses, err = nseregistry.NewNSERegistry(fileName).LoadRegistry()
+	Expect(err).To(BeNil())
+	Expect(clients).To(Equal([]string{"nsm-1", "nsm\r2"}))
+	Expect(nses).To(HaveLen(1))
+}
+
+func TestNSERegistryTornRecord(t *testing.T) {
+	RegisterTestingT(t)
+	fileName := tmpFile()
+	defer os.Remove(fileName)
+	addValues(nseregistry.NewNSERegistry(fileName))
+
+	// Crash in the middle of the last record write.
+	info, err := os.Stat(fileName)
+	Expect(err).To(BeNil())
+	Expect(os.Truncate(fileName, info.Size()-3)).To(BeNil())
+
+	reg := nseregistry.NewNSERegistry(fileName)
+	clients, nses, err := reg.LoadRegistry()
+	Expect(err).To(BeNil())
+	Expect(clients).To(Equal([]string{"nsm-1", "nsm-2", "nsm-3"}))
+	Expect(nses).To(Equal(map[string]nseregistry.NSEEntry{"endpoint1": createEntry("nsm-1", "endpoint1")}))
+
+	// Records appended after the torn one are readable.
+	Expect(reg.AppendNSERegRequest("nsm-2", createNSEReg("endpoint2"))).To(BeNil())
+	_, nses, err = nseregistry.NewNSERegistry(fileName).LoadRegistry()
+	Expect(err).To(BeNil())
+	Expect(nses).To(HaveLen(2))
+}
+
+func TestNSERegistryCompaction(t *testing.T) {
+	RegisterTestingT(t)
+	fileName := tmpFile()
+	defer os.Remove(fileName)
+	reg := nseregistry.NewNSERegistry(fileName)
+	addValues(reg)
+
+	for i := 0; i < 100; i++ {
+		Expect(reg.AppendNSERegRequest("nsm-3", createNSEReg("endpoint3"))).To(BeNil())
+		Expect(reg.DeleteNSE("endpoint3")).To(BeNil())
+	}
+	info, err := os.Stat(fileName)
+	Expect(err).To(BeNil())
+	Expect(info.Size()).To(BeNumerically("<", 4096))
+
+	clients, nses, err := nseregistry.NewNSERegistry(fileName).LoadRegistry()
+	Expect(err).To(BeNil())
+	Expect(clients).To(Equal([]string{"nsm-1", "nsm-2", "nsm-3"}))
+	Expect(nses).To(Equal(map[string]nseregistry.NSEEntry{"endpoint1": createEntry("nsm-1", "endpoint1"), "endpoint2": createEntry("nsm-2", "endpoint2")}))
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdmissionWorkspaceRate(t *testing.T) {
+	RegisterTestingT(t)
+
+	metrics := nsmd.NewAdmissionMetrics()
+	ac := nsmd.NewAdmissionController(&nsmd.AdmissionProperties{
+		WorkspaceRequestRate:  0.001,
+		WorkspaceRequestBurst: 1,
+	}, metrics)
+
+	release, err := ac.Admit("nsm-1")
+	Expect(err).To(BeNil())
+	release()
+
+	_, err = ac.Admit("nsm-1")
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+	// Other workspaces are not affected
+	release, err = ac.Admit("nsm-2")
+	Expect(err).To(BeNil())
+	release()
+
+	// Limiter state is dropped with workspace
+	ac.RemoveWorkspace("nsm-1")
+	release, err = ac.Admit("nsm-1")
+	Expect(err).To(BeNil())
+	release()
+
+	Expect(metrics.Get().Admitted).To(Equal(uint64(3)))
+	Expect(metrics.Get().RejectedWorkspaceRate).To(Equal(uint64(1)))
+}
+
+func TestAdmissionGlobalRate(t *testing.T) {
+	RegisterTestingT(t)
+
+	metrics := nsmd.NewAdmissionMetrics()
+	ac := nsmd.NewAdmissionController(&nsmd.AdmissionProperties{
+		RequestRate:  0.001,
+		RequestBurst: 2,
+	}, metrics)
+	for _, workspace := range []string{"nsm-1", "nsm-2"} {
+		release, err := ac.Admit(workspace)
+		Expect(err).To(BeNil())
+		release()
+	}
+	_, err := ac.Admit("nsm-3")
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	Expect(metrics.Get().RejectedGlobalRate).To(Equal(uint64(1)))
+}
+
+func TestAdmissionRejectedRequestKeepsWorkspaceRate(t *testing.T) {
+	RegisterTestingT(t)
+
+	ac := nsmd.NewAdmissionController(&nsmd.AdmissionProperties{
+		RequestRate:           0.001,
+		RequestBurst:          1,
+		WorkspaceRequestRate:  0.001,
+		WorkspaceRequestBurst: 1,
+	}, nsmd.NewAdmissionMetrics())
+
+	release, err := ac.Admit("nsm-1")
+	Expect(err).To(BeNil())
+	release()
+
+	// Rejected by the global rate, workspace token is not taken
+	_, err = ac.Admit("nsm-2")
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	Expect(err.Error()).To(ContainSubstring("global"))
+
+	ac = nsmd.NewAdmissionController(&nsmd.AdmissionProperties{
+		WorkspaceRequestRate:  0.001,
+		WorkspaceRequestBurst: 1,
+		MaxConcurrentRequests: 1,
+	}, nsmd.NewAdmissionMetrics())
+
+	release, err = ac.Admit("nsm-1")
+	Expect(err).To(BeNil())
+
+	// Rejected by concurrency, workspace token is not taken
+	_, err = ac.Admit("nsm-2")
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	release()
+
+	release, err = ac.Admit("nsm-2")
+	Expect(err).To(BeNil())
+	release()
+}
+
+func TestAdmissionMaxConcurrent(t *testing.T) {
+	RegisterTestingT(t)
+
+	metrics := nsmd.NewAdmissionMetrics()
+	ac := nsmd.NewAdmissionController(&nsmd.AdmissionProperties{
+		MaxConcurrentRequests: 1,
+	}, metrics)
+
+	release, err := ac.Admit("nsm-1")
+	Expect(err).To(BeNil())
+	Expect(metrics.Get().InFlight).To(Equal(int64(1)))
+
+	_, err = ac.Admit("nsm-2")
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+	release()
+	release() // Second release should not free one more slot
+	Expect(metrics.Get().InFlight).To(Equal(int64(0)))
+
+	release, err = ac.Admit("nsm-2")
+	Expect(err).To(BeNil())
+	release()
+	Expect(metrics.Get().RejectedConcurrency).To(Equal(uint64(1)))
+}
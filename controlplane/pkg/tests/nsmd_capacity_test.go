@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNSMDRequestEndpointCapacity(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+
+	endpoint := srv.registerFakeEndpoint("golden_network", "test", Master)
+	endpoint.Endpoint.NetworkserviceEndpoint.Capacity = 1
+	srv.testModel.AddEndpoint(endpoint)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetNetworkService()).To(Equal("golden_network"))
+
+	// Load is reported to registry
+	Eventually(func() uint32 {
+		return storage.endpoints["golden_networkprovider"].GetLoad()
+	}, time.Second).Should(Equal(uint32(1)))
+
+	_, err = nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+	Expect(strings.Contains(err.Error(), "have reached their capacity")).To(BeTrue())
+
+	// Load is decreased once connection is closed
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	Eventually(func() uint32 {
+		return storage.endpoints["golden_networkprovider"].GetLoad()
+	}, time.Second).Should(Equal(uint32(0)))
+}
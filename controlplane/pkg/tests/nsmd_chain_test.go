@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+func TestNSMDRequestServiceChain(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("firewall", "test", Master))
+	gateway := srv.registerFakeEndpointWithName("golden_network", "test", Master, "gateway")
+	gateway.Endpoint.NetworkserviceEndpoint.Labels = map[string]string{"app": "gateway"}
+	srv.testModel.AddEndpoint(gateway)
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", Master))
+
+	storage.services["golden_network"].Chain = []*registry.ChainHop{
+		{NetworkService: "firewall"},
+		{NetworkService: "golden_network", Labels: map[string]string{"app": "gateway"}},
+	}
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// First hop is requested by client
+	request := createRequest(false)
+	request.Connection.Labels["app"] = "client"
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetNetworkService()).To(Equal("golden_network"))
+
+	nse := srv.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient)
+	Expect(nse.req.GetConnection().GetNetworkService()).To(Equal("firewall"))
+	Expect(nse.req.GetConnection().GetLabels()).To(Equal(map[string]string{
+		"app":                      "client",
+		registry.ChainServiceLabel: "golden_network",
+		registry.ChainHopLabel:     "1",
+	}))
+
+	// Second hop is requested by firewall endpoint
+	request = createRequest(false)
+	request.Connection.Labels = nse.req.GetConnection().GetLabels()
+	nsmResponse, err = nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(nse.req.GetConnection().GetNetworkService()).To(Equal("golden_network"))
+	Expect(nse.req.GetConnection().GetLabels()).To(Equal(map[string]string{"app": "client"}))
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId()).Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).To(Equal("gateway"))
+
+	// Invalid hop is rejected
+	request = createRequest(false)
+	request.Connection.Labels = map[string]string{
+		registry.ChainServiceLabel: "golden_network",
+		registry.ChainHopLabel:     "2",
+	}
+	_, err = nsmClient.Request(context.Background(), request)
+	Expect(err).NotTo(BeNil())
+}
@@ -2,20 +2,24 @@ package tests
 
 import (
 	"context"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"io/ioutil"
 	"net"
+	"os"
+	"path"
 	"testing"
+	"time"
 )
 
 func startAPIServer(model model.Model, nsmdApiAddress string) (error, *grpc.Server, *crossconnect_monitor.CrossConnectMonitor, net.Listener) {
@@ -84,7 +88,7 @@ func readNMSDCrossConnectEvents(address string, count int) []*crossconnect.Cross
 	dataplaneClient := crossconnect.NewMonitorCrossConnectClient(conn)
 
 	// Looping indefinetly or until grpc returns an error indicating the other end closed connection.
-	stream, err := dataplaneClient.MonitorCrossConnects(context.Background(), &empty.Empty{})
+	stream, err := dataplaneClient.MonitorCrossConnects(context.Background(), &crossconnect.MonitorRequest{})
 	if err != nil {
 		logrus.Warningf("Error: %+v.", err)
 		return nil
@@ -119,3 +123,105 @@ func createCrossMonitorDataplaneMock(dataplaneSocket string) (net.Listener, *grp
 	go server.Serve(ln)
 	return ln, server, monitor
 }
+
+// resumeRecordingMonitor records monitor requests and allows to break the monitor streams
+type resumeRecordingMonitor struct {
+	monitor  *crossconnect_monitor.CrossConnectMonitor
+	requests chan *crossconnect.MonitorRequest
+	breaks   chan context.CancelFunc
+	events   chan *crossconnect.CrossConnectEvent
+}
+
+func (m *resumeRecordingMonitor) MonitorCrossConnects(request *crossconnect.MonitorRequest, stream crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	m.requests <- request
+	m.breaks <- cancel
+	return m.monitor.MonitorCrossConnects(request, &breakableStream{
+		MonitorCrossConnect_MonitorCrossConnectsServer: stream,
+		ctx:    ctx,
+		events: m.events,
+	})
+}
+
+func (m *resumeRecordingMonitor) receive() *crossconnect.CrossConnectEvent {
+	select {
+	case event := <-m.events:
+		return event
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+type breakableStream struct {
+	crossconnect.MonitorCrossConnect_MonitorCrossConnectsServer
+	ctx    context.Context
+	events chan *crossconnect.CrossConnectEvent
+}
+
+func (s *breakableStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *breakableStream) Send(event *crossconnect.CrossConnectEvent) error {
+	if err := s.MonitorCrossConnect_MonitorCrossConnectsServer.Send(event); err != nil {
+		return err
+	}
+	s.events <- event
+	return nil
+}
+
+func TestDataplaneCrossConnectMonitorResume(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "dataplane")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	dataplaneSocket := path.Join(dir, "dataplane.sock")
+	ln, err := net.Listen("unix", dataplaneSocket)
+	Expect(err).To(BeNil())
+
+	recording := &resumeRecordingMonitor{
+		monitor:  crossconnect_monitor.NewCrossConnectMonitor(),
+		requests: make(chan *crossconnect.MonitorRequest, 10),
+		breaks:   make(chan context.CancelFunc, 10),
+		events:   make(chan *crossconnect.CrossConnectEvent, 10),
+	}
+	server := grpc.NewServer()
+	crossconnect.RegisterMonitorCrossConnectServer(server, recording)
+	go server.Serve(ln)
+	defer server.Stop()
+
+	myModel := model.NewModel()
+	serviceRegistry := nsmd.NewServiceRegistry()
+	manager := services.NewClientConnectionManager(myModel, nsm.NewNetworkServiceManager(myModel, serviceRegistry, nil), serviceRegistry)
+	monitorClient := nsmd.NewMonitorCrossConnectClient(crossconnect_monitor.NewCrossConnectMonitor(),
+		remote_connection_monitor.NewRemoteConnectionMonitor(manager), manager)
+	dataplane := &model.Dataplane{RegisteredName: "dataplane", SocketLocation: dataplaneSocket}
+	monitorClient.DataplaneAdded(dataplane)
+	defer monitorClient.DataplaneDeleted(dataplane)
+
+	var request *crossconnect.MonitorRequest
+	Eventually(recording.requests, 5*time.Second).Should(Receive(&request))
+	Expect(request.GetResumeFromSequence()).To(Equal(uint64(0)))
+	breakStream := <-recording.breaks
+	Expect(recording.receive().GetType()).To(Equal(crossconnect.CrossConnectEventType_INITIAL_STATE_TRANSFER))
+
+	recording.monitor.Update(&crossconnect.CrossConnect{Id: "1"})
+	recording.monitor.Update(&crossconnect.CrossConnect{Id: "2"})
+	Expect(recording.receive().GetSequence()).To(Equal(uint64(1)))
+	last := recording.receive()
+	Expect(last.GetSequence()).To(Equal(uint64(2)))
+	breakStream()
+
+	// Broken stream is re-established from the last received event
+	Eventually(recording.requests, 5*time.Second).Should(Receive(&request))
+	Expect(request.GetResumeFromSequence()).To(Equal(uint64(2)))
+	Expect(request.GetResumeFromServerId()).To(Equal(last.GetServerId()))
+	<-recording.breaks
+
+	// Resumed stream receives only new events
+	recording.monitor.Update(&crossconnect.CrossConnect{Id: "3"})
+	event := recording.receive()
+	Expect(event.GetType()).To(Equal(crossconnect.CrossConnectEventType_UPDATE))
+	Expect(event.GetSequence()).To(Equal(uint64(3)))
+}
@@ -1,6 +1,10 @@
 package tests
 
 import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint/composite"
@@ -63,3 +67,87 @@ func TestNSMDRestart1(t *testing.T) {
 	Expect(endpoints1[0].Endpoint.NetworkServiceManager.Name).ToNot(Equal(endpoints2[0].Endpoint.NetworkServiceManager.Name))
 }
 
+
+func TestNSMDRestartCheckpoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", Master))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	connection1 := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	xcons := srv.serviceRegistry.testDataplaneConnection.connections
+	Expect(len(xcons)).To(Equal(1))
+	srv.StopNoClean()
+
+	// Dataplane keeps cross connects while NSMD is restarted.
+	srv = newNSMDFullServerAt(Master, storage, srv.rootDir)
+	defer srv.Stop()
+	connection2 := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(connection2).NotTo(BeNil())
+	Expect(connection2.DataplaneState).To(Equal(model.DataplaneState_None))
+	Expect(proto.Equal(connection2.Request.(*networkservice.NetworkServiceRequest), connection1.Request.(*networkservice.NetworkServiceRequest))).To(BeTrue())
+	Expect(proto.Equal(connection2.Endpoint, connection1.Endpoint)).To(BeTrue())
+
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.manager.RestoreConnections(xcons, "test_data_plane")
+	connection2 = srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(connection2).NotTo(BeNil())
+	Expect(connection2.ConnectionState).To(Equal(model.ClientConnection_Ready))
+	Expect(connection2.DataplaneState).To(Equal(model.DataplaneState_Ready))
+	Expect(connection2.Dataplane.SocketLocation).To(Equal("tcp:some_addr"))
+
+	// Restored ids are not used by new connections.
+	Expect(srv.testModel.ConnectionId()).NotTo(Equal(nsmResponse.GetId()))
+}
+
+func TestNSMDRestartCheckpointLostConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", Master))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	srv.StopNoClean()
+
+	srv = newNSMDFullServerAt(Master, storage, srv.rootDir)
+	defer srv.Stop()
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).NotTo(BeNil())
+
+	// Dataplane is restarted as well and does not know the connection, so it is healed.
+	programmed := &dataplaneReadyListener{ready: make(chan string, 10)}
+	srv.testModel.AddListener(programmed)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.manager.RestoreConnections(nil, "test_data_plane")
+	select {
+	case id := <-programmed.ready:
+		Expect(id).To(Equal(nsmResponse.GetId()))
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Connection is not healed")
+	}
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.connections)).To(Equal(1))
+}
+
+// dataplaneReadyListener reports connections updated with the dataplane programmed.
+type dataplaneReadyListener struct {
+	model.ModelListenerImpl
+	ready chan string
+}
+
+func (l *dataplaneReadyListener) ClientConnectionUpdated(clientConnection *model.ClientConnection) {
+	if clientConnection.DataplaneState == model.DataplaneState_Ready {
+		l.ready <- clientConnection.GetId()
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
@@ -81,6 +82,16 @@ func (impl *nsmdTestServiceDiscovery) RemoveNSE(ctx context.Context, in *registr
 	return nil, nil
 }
 
+func (impl *nsmdTestServiceDiscovery) UpdateNSELoad(ctx context.Context, in *registry.UpdateNSELoadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	// Endpoints are shared with discovery responses, so stored endpoint is replaced instead of changed.
+	if ep, ok := impl.storage.endpoints[in.EndpointName]; ok {
+		updated := proto.Clone(ep).(*registry.NetworkServiceEndpoint)
+		updated.Load = in.Load
+		impl.storage.endpoints[in.EndpointName] = updated
+	}
+	return &empty.Empty{}, nil
+}
+
 func newNSMDTestServiceDiscovery(testApi *testApiRegistry, nsmgrName string, storage *sharedStorage, clusterConfiguration *registry.ClusterConfiguration) *nsmdTestServiceDiscovery {
 	return &nsmdTestServiceDiscovery{
 		storage:              storage,
@@ -497,7 +508,7 @@ func newNSMDFullServerAt(nsmgrName string, storage *sharedStorage, rootDir strin
 		return nil
 	}
 	// Lets start NSMD NSE registry service
-	nsmServer, err := nsmd.StartNSMServer(srv.testModel, srv.manager, srv.serviceRegistry, srv.apiRegistry)
+	nsmServer, err := nsmd.StartNSMServer(srv.testModel, srv.manager, srv.serviceRegistry, srv.apiRegistry, nil)
 	srv.nsmServer = nsmServer
 	Expect(err).To(BeNil())
 
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestNSMDRequestTracing(t *testing.T) {
+	RegisterTestingT(t)
+
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	storage := newSharedStorage()
+	srv := newNSMDFullServer(Master, storage)
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", Master))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetNetworkService()).To(Equal("golden_network"))
+
+	spans := map[string]*mocktracer.MockSpan{}
+	for _, span := range tracer.FinishedSpans() {
+		spans[span.OperationName] = span
+	}
+	request := spans["NetworkServiceManager.Request"]
+	Expect(request).NotTo(BeNil())
+	Expect(request.Tag("connection_id")).To(Equal(nsmResponse.GetId()))
+	Expect(request.Tag("network_service")).To(Equal("golden_network"))
+
+	for _, name := range []string{
+		"NetworkServiceManager.SelectDataplane",
+		"NetworkServiceManager.FindConnectNSE",
+		"NetworkServiceManager.ProgramDataplane",
+	} {
+		Expect(spans[name]).NotTo(BeNil(), name)
+		Expect(spans[name].ParentID).To(Equal(request.SpanContext.SpanID), name)
+	}
+	Expect(spans["NetworkServiceManager.NSERequest"].ParentID).To(Equal(spans["NetworkServiceManager.FindConnectNSE"].SpanContext.SpanID))
+	Expect(spans["NetworkServiceManager.DataplaneRequest"].ParentID).To(Equal(spans["NetworkServiceManager.ProgramDataplane"].SpanContext.SpanID))
+	Expect(spans["NetworkServiceManager.DataplaneRequest"].Tag("retry")).To(Equal(0))
+}
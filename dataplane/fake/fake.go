@@ -0,0 +1,389 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides a dataplane which programs nothing. It is intended for NSE and NSC tests: it registers
+// in NSMD as a real dataplane does, records requested cross connects, reports them through the cross connect
+// monitor and advertises configurable mechanisms. Latency, errors and mechanism changes could be injected
+// at any time to test how NSMD and its clients behave.
+package fake
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	local "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	remote "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/impl/dataplaneregistrarclient"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Dataplane is a fake dataplane implementing DataplaneServer.
+type Dataplane struct {
+	sync.Mutex
+	Name            string
+	Socket          string
+	registrarSocket string
+
+	localMechanisms  []*local.Mechanism
+	remoteMechanisms []*remote.Mechanism
+	mechanismUpdates map[chan *dataplane.MechanismUpdate]struct{}
+
+	crossConnects map[string]*crossconnect.CrossConnect
+	requests      []*crossconnect.CrossConnect
+	closes        []*crossconnect.CrossConnect
+
+	latency      time.Duration
+	requestFault *fault
+	closeFault   *fault
+
+	monitor      *crossconnect_monitor.CrossConnectMonitor
+	server       *grpc.Server
+	registration interface{ Close() }
+}
+
+// fault is an error returned by the next count calls, negative count means every call until faults are cleared.
+type fault struct {
+	err   error
+	count int
+}
+
+func (f *fault) next() error {
+	if f == nil || f.count == 0 {
+		return nil
+	}
+	if f.count > 0 {
+		f.count--
+	}
+	return f.err
+}
+
+// NewDataplane creates a fake dataplane serving at socket and registering in NSMD dataplane registrar
+// at registrarSocket. Registration is skipped if registrarSocket is empty. The dataplane advertises
+// kernel and memif local mechanisms and VXLAN remote mechanism with srcIP.
+func NewDataplane(name, socket, registrarSocket, srcIP string) *Dataplane {
+	return &Dataplane{
+		Name:            name,
+		Socket:          socket,
+		registrarSocket: registrarSocket,
+		localMechanisms: []*local.Mechanism{
+			{
+				Type: local.MechanismType_KERNEL_INTERFACE,
+			},
+			{
+				Type: local.MechanismType_MEM_INTERFACE,
+			},
+		},
+		remoteMechanisms: []*remote.Mechanism{
+			{
+				Type: remote.MechanismType_VXLAN,
+				Parameters: map[string]string{
+					remote.VXLANSrcIP: srcIP,
+				},
+			},
+		},
+		mechanismUpdates: map[chan *dataplane.MechanismUpdate]struct{}{},
+		crossConnects:    map[string]*crossconnect.CrossConnect{},
+	}
+}
+
+// Start serves the dataplane and registers it in NSMD, it blocks until NSMD accepts the registration.
+// Cross connects of the previous run are lost, as if the dataplane was restarted.
+func (d *Dataplane) Start() error {
+	d.Lock()
+	if err := tools.SocketCleanup(d.Socket); err != nil {
+		d.Unlock()
+		return err
+	}
+	listener, err := net.Listen("unix", d.Socket)
+	if err != nil {
+		d.Unlock()
+		return err
+	}
+	d.crossConnects = map[string]*crossconnect.CrossConnect{}
+	d.monitor = crossconnect_monitor.NewCrossConnectMonitor()
+	d.server = tools.NewServer()
+	dataplane.RegisterDataplaneServer(d.server, d)
+	crossconnect.RegisterMonitorCrossConnectServer(d.server, d.monitor)
+	server := d.server
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logrus.Errorf("Fake dataplane %s: failed to serve: %v", d.Name, err)
+		}
+	}()
+	d.Unlock()
+
+	if d.registrarSocket == "" {
+		return nil
+	}
+	registrar := dataplaneregistrarclient.NewDataplaneRegistrarClient("unix", d.registrarSocket)
+	registration := registrar.Register(context.Background(), d.Name, d.Socket, nil, nil)
+
+	d.Lock()
+	d.registration = registration
+	d.Unlock()
+	return nil
+}
+
+// Stop stops the dataplane as if it crashed.
+func (d *Dataplane) Stop() {
+	d.Lock()
+	if d.server == nil {
+		d.Unlock()
+		return
+	}
+	d.server.Stop()
+	d.server = nil
+	registration := d.registration
+	d.registration = nil
+	d.Unlock()
+
+	if registration != nil {
+		registration.Close()
+	}
+}
+
+// SetLatency delays every following Request and Close, the delay is cut short if the call context is done.
+func (d *Dataplane) SetLatency(latency time.Duration) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.latency = latency
+}
+
+// FailRequests makes the next count Requests fail with err, negative count fails all of them until ClearFaults.
+func (d *Dataplane) FailRequests(err error, count int) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.requestFault = &fault{err: err, count: count}
+}
+
+// FailCloses makes the next count Closes fail with err, negative count fails all of them until ClearFaults.
+// Failed Close keeps the cross connect programmed.
+func (d *Dataplane) FailCloses(err error, count int) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.closeFault = &fault{err: err, count: count}
+}
+
+// ClearFaults removes injected latency and errors.
+func (d *Dataplane) ClearFaults() {
+	d.Lock()
+	defer d.Unlock()
+
+	d.latency = 0
+	d.requestFault = nil
+	d.closeFault = nil
+}
+
+// SetMechanisms changes the advertised mechanisms and sends them to every MonitorMechanisms stream.
+func (d *Dataplane) SetMechanisms(localMechanisms []*local.Mechanism, remoteMechanisms []*remote.Mechanism) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.localMechanisms = localMechanisms
+	d.remoteMechanisms = remoteMechanisms
+	update := d.mechanismUpdate()
+	for updates := range d.mechanismUpdates {
+		// Only the latest mechanisms matter, drop the pending update if it is not sent yet.
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- update
+	}
+}
+
+// CrossConnects returns the cross connects programmed in the dataplane.
+func (d *Dataplane) CrossConnects() []*crossconnect.CrossConnect {
+	d.Lock()
+	defer d.Unlock()
+
+	var rv []*crossconnect.CrossConnect
+	for _, xcon := range d.crossConnects {
+		rv = append(rv, proto.Clone(xcon).(*crossconnect.CrossConnect))
+	}
+	return rv
+}
+
+// Requests returns every cross connect requested from the dataplane in order, including failed ones.
+func (d *Dataplane) Requests() []*crossconnect.CrossConnect {
+	d.Lock()
+	defer d.Unlock()
+
+	return cloneAll(d.requests)
+}
+
+// Closes returns every cross connect closed in the dataplane in order, including failed ones.
+func (d *Dataplane) Closes() []*crossconnect.CrossConnect {
+	d.Lock()
+	defer d.Unlock()
+
+	return cloneAll(d.closes)
+}
+
+// DestinationDown marks local destinations connected to the endpoint DOWN, the same way a real dataplane
+// reports the endpoint interface is gone. It returns the number of updated cross connects.
+func (d *Dataplane) DestinationDown(endpointName string) int {
+	d.Lock()
+	defer d.Unlock()
+
+	count := 0
+	for id, xcon := range d.crossConnects {
+		dst := xcon.GetLocalDestination()
+		if dst == nil || dst.GetMechanism().GetParameters()[local.WorkspaceNSEName] != endpointName {
+			continue
+		}
+		xcon = proto.Clone(xcon).(*crossconnect.CrossConnect)
+		xcon.GetLocalDestination().State = local.State_DOWN
+		d.crossConnects[id] = xcon
+		if d.monitor != nil {
+			d.monitor.Update(xcon)
+		}
+		count++
+	}
+	return count
+}
+
+// SourceDown marks the local source of the cross connect DOWN, the same way a real dataplane reports the client
+// interface is gone. It returns false if there is no such cross connect with a local source.
+func (d *Dataplane) SourceDown(crossConnectId string) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	xcon := d.crossConnects[crossConnectId]
+	if xcon.GetLocalSource() == nil {
+		return false
+	}
+	xcon = proto.Clone(xcon).(*crossconnect.CrossConnect)
+	xcon.GetLocalSource().State = local.State_DOWN
+	d.crossConnects[crossConnectId] = xcon
+	if d.monitor != nil {
+		d.monitor.Update(xcon)
+	}
+	return true
+}
+
+func (d *Dataplane) Request(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error) {
+	logger := tools.Log(ctx).WithField(tools.LogFieldConnectionID, crossConnect.GetId())
+	logger.Infof("Fake dataplane %s: request %v", d.Name, crossConnect)
+
+	if err := d.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.requests = append(d.requests, proto.Clone(crossConnect).(*crossconnect.CrossConnect))
+	if err := d.requestFault.next(); err != nil {
+		logger.Errorf("Fake dataplane %s: injected request error: %v", d.Name, err)
+		return nil, err
+	}
+	d.crossConnects[crossConnect.GetId()] = proto.Clone(crossConnect).(*crossconnect.CrossConnect)
+	if d.monitor != nil {
+		d.monitor.Update(crossConnect)
+	}
+	return crossConnect, nil
+}
+
+func (d *Dataplane) Close(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*empty.Empty, error) {
+	logger := tools.Log(ctx).WithField(tools.LogFieldConnectionID, crossConnect.GetId())
+	logger.Infof("Fake dataplane %s: close %v", d.Name, crossConnect)
+
+	if err := d.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.closes = append(d.closes, proto.Clone(crossConnect).(*crossconnect.CrossConnect))
+	if err := d.closeFault.next(); err != nil {
+		logger.Errorf("Fake dataplane %s: injected close error: %v", d.Name, err)
+		return nil, err
+	}
+	delete(d.crossConnects, crossConnect.GetId())
+	if d.monitor != nil {
+		d.monitor.Delete(crossConnect)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (d *Dataplane) MonitorMechanisms(empty *empty.Empty, updateSrv dataplane.Dataplane_MonitorMechanismsServer) error {
+	updates := make(chan *dataplane.MechanismUpdate, 1)
+	d.Lock()
+	updates <- d.mechanismUpdate()
+	d.mechanismUpdates[updates] = struct{}{}
+	d.Unlock()
+
+	defer func() {
+		d.Lock()
+		delete(d.mechanismUpdates, updates)
+		d.Unlock()
+	}()
+
+	for {
+		select {
+		case update := <-updates:
+			logrus.Infof("Fake dataplane %s: sending mechanisms update: %v", d.Name, update)
+			if err := updateSrv.Send(update); err != nil {
+				return err
+			}
+		case <-updateSrv.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (d *Dataplane) mechanismUpdate() *dataplane.MechanismUpdate {
+	return &dataplane.MechanismUpdate{
+		LocalMechanisms:  d.localMechanisms,
+		RemoteMechanisms: d.remoteMechanisms,
+	}
+}
+
+func (d *Dataplane) delay(ctx context.Context) error {
+	d.Lock()
+	latency := d.latency
+	d.Unlock()
+
+	if latency == 0 {
+		return nil
+	}
+	select {
+	case <-time.After(latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func cloneAll(xcons []*crossconnect.CrossConnect) []*crossconnect.CrossConnect {
+	var rv []*crossconnect.CrossConnect
+	for _, xcon := range xcons {
+		rv = append(rv, proto.Clone(xcon).(*crossconnect.CrossConnect))
+	}
+	return rv
+}
@@ -0,0 +1,150 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	local "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	. "github.com/onsi/gomega"
+)
+
+func startDataplane() (*Dataplane, dataplane.DataplaneClient, func()) {
+	dir, err := ioutil.TempDir("", "fake-dataplane")
+	Expect(err).To(BeNil())
+
+	dp := NewDataplane("fake", path.Join(dir, "dataplane.sock"), "", "127.0.0.1")
+	Expect(dp.Start()).To(BeNil())
+
+	conn, err := tools.SocketOperationCheck(tools.SocketPath(dp.Socket))
+	Expect(err).To(BeNil())
+	return dp, dataplane.NewDataplaneClient(conn), func() {
+		_ = conn.Close()
+		dp.Stop()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestRequestClose(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp, client, stop := startDataplane()
+	defer stop()
+
+	xcon := &crossconnect.CrossConnect{Id: "1"}
+	_, err := client.Request(context.Background(), xcon)
+	Expect(err).To(BeNil())
+	Expect(dp.CrossConnects()).To(HaveLen(1))
+
+	_, err = client.Close(context.Background(), xcon)
+	Expect(err).To(BeNil())
+	Expect(dp.CrossConnects()).To(BeEmpty())
+	Expect(dp.Requests()).To(HaveLen(1))
+	Expect(dp.Closes()).To(HaveLen(1))
+}
+
+func TestInjectedErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp, client, stop := startDataplane()
+	defer stop()
+
+	dp.FailRequests(errors.New("request failure"), 1)
+	_, err := client.Request(context.Background(), &crossconnect.CrossConnect{Id: "1"})
+	Expect(err).NotTo(BeNil())
+	_, err = client.Request(context.Background(), &crossconnect.CrossConnect{Id: "1"})
+	Expect(err).To(BeNil())
+
+	dp.FailCloses(errors.New("close failure"), -1)
+	for i := 0; i < 2; i++ {
+		_, err = client.Close(context.Background(), &crossconnect.CrossConnect{Id: "1"})
+		Expect(err).NotTo(BeNil())
+	}
+	Expect(dp.CrossConnects()).To(HaveLen(1))
+
+	dp.ClearFaults()
+	_, err = client.Close(context.Background(), &crossconnect.CrossConnect{Id: "1"})
+	Expect(err).To(BeNil())
+	Expect(dp.CrossConnects()).To(BeEmpty())
+	Expect(dp.Requests()).To(HaveLen(2))
+	Expect(dp.Closes()).To(HaveLen(3))
+}
+
+func TestInjectedLatency(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp, client, stop := startDataplane()
+	defer stop()
+
+	dp.SetLatency(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := client.Request(ctx, &crossconnect.CrossConnect{Id: "1"})
+	Expect(err).NotTo(BeNil())
+	Expect(dp.CrossConnects()).To(BeEmpty())
+}
+
+func TestMechanismsChange(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp, client, stop := startDataplane()
+	defer stop()
+
+	stream, err := client.MonitorMechanisms(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	update, err := stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(update.GetLocalMechanisms()).To(HaveLen(2))
+	Expect(update.GetRemoteMechanisms()).To(HaveLen(1))
+
+	dp.SetMechanisms([]*local.Mechanism{{Type: local.MechanismType_MEM_INTERFACE}}, nil)
+	update, err = stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(update.GetLocalMechanisms()).To(HaveLen(1))
+	Expect(update.GetRemoteMechanisms()).To(BeEmpty())
+}
+
+func TestDestinationDownNotStarted(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp := NewDataplane("fake", "", "", "127.0.0.1")
+	xcon := &crossconnect.CrossConnect{
+		Id: "1",
+		Destination: &crossconnect.CrossConnect_LocalDestination{
+			LocalDestination: &local.Connection{
+				Mechanism: &local.Mechanism{
+					Parameters: map[string]string{local.WorkspaceNSEName: "ep1"},
+				},
+			},
+		},
+	}
+	_, err := dp.Request(context.Background(), xcon)
+	Expect(err).To(BeNil())
+	Expect(dp.DestinationDown("ep1")).To(Equal(1))
+	Expect(dp.CrossConnects()[0].GetLocalDestination().GetState()).To(Equal(local.State_DOWN))
+}
+
+func TestSourceDownNotStarted(t *testing.T) {
+	RegisterTestingT(t)
+
+	dp := NewDataplane("fake", "", "", "127.0.0.1")
+	xcon := &crossconnect.CrossConnect{
+		Id: "1",
+		Source: &crossconnect.CrossConnect_LocalSource{
+			LocalSource: &local.Connection{Id: "1"},
+		},
+	}
+	_, err := dp.Request(context.Background(), xcon)
+	Expect(err).To(BeNil())
+	Expect(dp.SourceDown("2")).To(BeFalse())
+	Expect(dp.SourceDown("1")).To(BeTrue())
+	Expect(dp.CrossConnects()[0].GetLocalSource().GetState()).To(Equal(local.State_DOWN))
+}
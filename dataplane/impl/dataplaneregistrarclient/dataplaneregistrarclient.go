@@ -54,7 +54,7 @@ func (dr *dataplaneRegistration) register(ctx context.Context) {
 	logrus.Infof("Retry interval: %s", dr.registrar.registrationRetryInterval)
 
 	// Wait fo NSMD to be ready to register dataplane.
-	_ = tools.WaitForPortAvailable(context.Background(), dr.registrar.registrarSocket.Network(), dr.registrar.registrarSocket.String(), 100* time.Millisecond)
+	_ = tools.WaitForPortAvailable(ctx, dr.registrar.registrarSocket.Network(), dr.registrar.registrarSocket.String(), 100*time.Millisecond)
 	ticker := time.NewTicker(dr.registrar.registrationRetryInterval)
 	for ; true; <-ticker.C {
 		select {
@@ -107,7 +107,8 @@ func (dr *dataplaneRegistration) tryRegistration(ctx context.Context) error {
 // that NSM is gone and the dataplane needs to start re-registration logic.
 func (dr *dataplaneRegistration) livenessMonitor(ctx context.Context) {
 	logrus.Infof("Starting DataplaneRegistrarClient liveliness monitor")
-	stream, err := dr.client.RequestLiveness(context.Background())
+	// Stream is closed with the registration, so NSM does not wait for it on graceful stop.
+	stream, err := dr.client.RequestLiveness(ctx)
 	if err != nil {
 		logrus.Errorf("%s: fail to create liveness grpc channel with NSM with error: %s, grpc code: %+v", dr.dataplaneName, err.Error(), status.Convert(err).Code())
 		return
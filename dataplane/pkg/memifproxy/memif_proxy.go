@@ -1,22 +1,112 @@
 package memifproxy
 
 import (
-	"github.com/sirupsen/logrus"
+	"fmt"
 	"net"
+	"os"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 const (
-	bufferSize = 128
-	cmsgSize   = 24
-	network    = "unixpacket"
+	// memif control messages are 128 bytes long, buffer is big enough to not truncate any of them
+	bufferSize = 1024
+	// maxFds is a max number of file descriptors passed with a single control message
+	maxFds  = 8
+	network = "unixpacket"
+
+	// redialInterval is a delay between attempts to connect the target socket
+	redialInterval = 100 * time.Millisecond
+	// redialTimeout is how long target socket is waited for after source connection is accepted
+	redialTimeout = 5 * time.Second
 )
 
+// State is a state of the proxy.
+type State int32
+
+const (
+	// StateStopped - proxy is not started or stopped
+	StateStopped State = iota
+	// StateListening - proxy waits for a connection to source socket
+	StateListening
+	// StateConnecting - source connection is accepted, proxy connects to target socket
+	StateConnecting
+	// StateConnected - source and target connections are bridged
+	StateConnected
+)
+
+func (s State) String() string {
+	switch s {
+	case StateStopped:
+		return "STOPPED"
+	case StateListening:
+		return "LISTENING"
+	case StateConnecting:
+		return "CONNECTING"
+	case StateConnected:
+		return "CONNECTED"
+	}
+	return fmt.Sprintf("State(%d)", int32(s))
+}
+
+// Counters are statistics of messages passed in one direction.
+type Counters struct {
+	Messages uint64
+	Bytes    uint64
+	Fds      uint64
+}
+
+// Stats are statistics of the proxy.
+type Stats struct {
+	State          State
+	SourceToTarget Counters
+	TargetToSource Counters
+	// Connections is a number of bridged connections, it grows when peers reconnect
+	Connections uint64
+}
+
+type counters struct {
+	messages uint64
+	bytes    uint64
+	fds      uint64
+}
+
+func (c *counters) add(bytes, fds int) {
+	atomic.AddUint64(&c.messages, 1)
+	atomic.AddUint64(&c.bytes, uint64(bytes))
+	atomic.AddUint64(&c.fds, uint64(fds))
+}
+
+func (c *counters) get() Counters {
+	return Counters{
+		Messages: atomic.LoadUint64(&c.messages),
+		Bytes:    atomic.LoadUint64(&c.bytes),
+		Fds:      atomic.LoadUint64(&c.fds),
+	}
+}
+
+// Proxy passes memif control messages with file descriptors between source and target unix sockets.
+// Proxy listens on the source socket and connects the target socket once source peer is connected.
+// If either peer disconnects, both connections are closed and the proxy waits for the source peer to reconnect.
 type Proxy struct {
+	sourceToTarget counters
+	targetToSource counters
+	connections    uint64
+	state          int32
+
 	sourceSocket string
 	targetSocket string
+	target       *net.UnixAddr
+	listener     *net.UnixListener
 	stopCh       chan struct{}
+	wg           sync.WaitGroup
+
+	sync.Mutex
+	conns []*net.UnixConn
 }
 
 func NewProxy(sourceSocket, targetSocket string) *Proxy {
@@ -26,11 +116,10 @@ func NewProxy(sourceSocket, targetSocket string) *Proxy {
 	}
 }
 
+// Start listens on the source socket and starts serving connections.
 func (mp *Proxy) Start() error {
 	logrus.Infof("Request proxy source: %s, target: %s", mp.sourceSocket, mp.targetSocket)
 
-	mp.stopCh = make(chan struct{})
-
 	logrus.Infof("Resolving source socket unix address: %v", mp.sourceSocket)
 	source, err := net.ResolveUnixAddr(network, mp.sourceSocket)
 	if err != nil {
@@ -38,109 +127,235 @@ func (mp *Proxy) Start() error {
 	}
 
 	logrus.Infof("Resolving target socket unix address: %v", mp.targetSocket)
-	target, err := net.ResolveUnixAddr(network, mp.targetSocket)
+	mp.target, err = net.ResolveUnixAddr(network, mp.targetSocket)
+	if err != nil {
+		return err
+	}
+
+	// A socket file could be left by a previous proxy which was not stopped properly.
+	if err := os.Remove(mp.sourceSocket); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	logrus.Info("Listening source socket...")
+	mp.listener, err = net.ListenUnix(network, source)
 	if err != nil {
 		return err
 	}
 
-	go proxy(source, target, mp.stopCh)
+	mp.stopCh = make(chan struct{})
+	mp.setState(StateListening)
+	mp.wg.Add(1)
+	go mp.serve()
 	return nil
 }
 
+// Stop closes the source socket and all the connections, it waits for proxy goroutines to finish.
+// Stop does nothing if the proxy is not started.
 func (mp *Proxy) Stop() {
+	if mp.listener == nil {
+		return
+	}
 	close(mp.stopCh)
+	if err := mp.listener.Close(); err != nil {
+		logrus.Errorf("Failed to close source socket %s: %v", mp.sourceSocket, err)
+	}
+	mp.closeConns()
+	mp.wg.Wait()
+	mp.listener = nil
+	mp.setState(StateStopped)
 }
 
-func proxy(source, target *net.UnixAddr, stopCh <-chan struct{}) {
-	logrus.Info("Listening source socket...")
-	sourceListener, err := net.ListenUnix(network, source)
-	if err != nil {
-		logrus.Error(err)
-		return
+// State returns a current state of the proxy.
+func (mp *Proxy) State() State {
+	return State(atomic.LoadInt32(&mp.state))
+}
+
+// Stats returns a snapshot of the proxy statistics.
+func (mp *Proxy) Stats() Stats {
+	return Stats{
+		State:          mp.State(),
+		SourceToTarget: mp.sourceToTarget.get(),
+		TargetToSource: mp.targetToSource.get(),
+		Connections:    atomic.LoadUint64(&mp.connections),
 	}
-	logrus.Info("Accepting connections to source socket...")
-	sourceConn, err := sourceListener.AcceptUnix()
-	if err != nil {
-		logrus.Error(err)
-		return
+}
+
+func (mp *Proxy) setState(state State) {
+	atomic.StoreInt32(&mp.state, int32(state))
+}
+
+func (mp *Proxy) stopped() bool {
+	select {
+	case <-mp.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (mp *Proxy) serve() {
+	defer mp.wg.Done()
+	for {
+		mp.setState(StateListening)
+		logrus.Infof("Accepting connections to source socket %s...", mp.sourceSocket)
+		sourceConn, err := mp.listener.AcceptUnix()
+		if err != nil {
+			if mp.stopped() {
+				return
+			}
+			logrus.Errorf("Failed to accept connection to source socket %s: %v", mp.sourceSocket, err)
+			select {
+			case <-mp.stopCh:
+				return
+			case <-time.After(redialInterval):
+			}
+			continue
+		}
+		logrus.Infof("Connection from source socket %s successfully accepted", mp.sourceSocket)
+
+		mp.setState(StateConnecting)
+		targetConn, err := mp.dialTarget()
+		if err != nil {
+			logrus.Errorf("Failed to connect target socket %s: %v", mp.targetSocket, err)
+			_ = sourceConn.Close()
+			if mp.stopped() {
+				return
+			}
+			continue
+		}
+		logrus.Infof("Successfully connected to target socket %s", mp.targetSocket)
+
+		if !mp.trackConns(sourceConn, targetConn) {
+			return
+		}
+		atomic.AddUint64(&mp.connections, 1)
+		mp.setState(StateConnected)
+		mp.bridge(sourceConn, targetConn)
+		mp.closeConns()
+		logrus.Infof("Proxy connection %s -> %s is closed", mp.sourceSocket, mp.targetSocket)
 	}
-	logrus.Info("Connection from source socket successfully accepted")
+}
 
-	logrus.Info("Connecting to target socket...")
-	targetConn, err := net.DialUnix(network, nil, target)
-	if err != nil {
-		logrus.Error(err)
-		return
+// dialTarget connects the target socket, retrying while the target peer is restarting.
+func (mp *Proxy) dialTarget() (*net.UnixConn, error) {
+	deadline := time.Now().Add(redialTimeout)
+	for {
+		conn, err := net.DialUnix(network, nil, mp.target)
+		if err == nil {
+			return conn, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-mp.stopCh:
+			return nil, fmt.Errorf("proxy is stopped")
+		case <-time.After(redialInterval):
+		}
 	}
-	logrus.Info("Successfully connected to target socket")
+}
 
-	sourceFd, closeSourceFd, err := getConnFd(sourceConn)
-	if err != nil {
-		logrus.Error(err)
-		return
+// trackConns remembers connections to be closed by Stop, false is returned if proxy is already stopped.
+func (mp *Proxy) trackConns(conns ...*net.UnixConn) bool {
+	mp.Lock()
+	defer mp.Unlock()
+	if mp.stopped() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+		return false
 	}
-	defer closeSourceFd()
-	logrus.Infof("Source connection fd: %v", sourceFd)
+	mp.conns = conns
+	return true
+}
 
-	targetFd, closeTargetFd, err := getConnFd(targetConn)
-	if err != nil {
-		logrus.Error(err)
-		return
+func (mp *Proxy) closeConns() {
+	mp.Lock()
+	defer mp.Unlock()
+	for _, conn := range mp.conns {
+		_ = conn.Close()
 	}
-	defer closeTargetFd()
-	logrus.Infof("Target connection fd: %v", targetFd)
+	mp.conns = nil
+}
 
+func (mp *Proxy) bridge(sourceConn, targetConn *net.UnixConn) {
 	var wg sync.WaitGroup
 	wg.Add(2)
 
+	// Once either side is closed, the other one is closed too, to make the peer reconnect.
 	go func() {
 		defer wg.Done()
-		transfer(sourceFd, targetFd, stopCh)
+		transfer(sourceConn, targetConn, &mp.sourceToTarget)
+		mp.closeConns()
 	}()
 
 	go func() {
 		defer wg.Done()
-		transfer(targetFd, sourceFd, stopCh)
+		transfer(targetConn, sourceConn, &mp.targetToSource)
+		mp.closeConns()
 	}()
 
 	wg.Wait()
 }
 
-func transfer(fromFd, toFd int, stopCh <-chan struct{}) {
+func transfer(from, to *net.UnixConn, stats *counters) {
 	dataBuffer := make([]byte, bufferSize)
-	cmsgBuffer := make([]byte, cmsgSize)
+	cmsgBuffer := make([]byte, syscall.CmsgSpace(maxFds*4))
 	for {
-		select {
-		case <-stopCh:
-			break
-		default:
-			dataN, cmsgN, _, _, err := syscall.Recvmsg(fromFd, dataBuffer, cmsgBuffer, 0)
-			if err != nil {
-				logrus.Error(err)
-				return
-			}
-			var sendDataBuf []byte = nil
-			if dataN > 0 {
-				sendDataBuf = dataBuffer
-			}
-			var sendCmsgBuf []byte = nil
-			if cmsgN > 0 {
-				sendCmsgBuf = cmsgBuffer
-			}
-			if err := syscall.Sendmsg(toFd, sendDataBuf, sendCmsgBuf, nil, 0); err != nil {
-				logrus.Error(err)
-				return
-			}
+		dataN, cmsgN, flags, _, err := from.ReadMsgUnix(dataBuffer, cmsgBuffer)
+		if err != nil {
+			logrus.Infof("Stop reading from %v: %v", from.LocalAddr(), err)
+			return
 		}
+		if dataN == 0 && cmsgN == 0 {
+			logrus.Infof("Connection %v is closed by peer", from.LocalAddr())
+			return
+		}
+		if flags&(syscall.MSG_TRUNC|syscall.MSG_CTRUNC) != 0 {
+			logrus.Warnf("Message from %v is truncated, flags: %x", from.LocalAddr(), flags)
+		}
+
+		fds, err := parseFds(cmsgBuffer[:cmsgN])
+		if err != nil {
+			logrus.Errorf("Failed to parse control message: %v", err)
+		}
+		_, _, err = to.WriteMsgUnix(dataBuffer[:dataN], cmsgBuffer[:cmsgN], nil)
+		// File descriptors are duplicated to the peer process, received ones should be closed.
+		closeFds(fds)
+		if err != nil {
+			logrus.Infof("Stop writing to %v: %v", to.LocalAddr(), err)
+			return
+		}
+		stats.add(dataN, len(fds))
 	}
 }
 
-func getConnFd(conn *net.UnixConn) (int, func(), error) {
-	file, err := conn.File()
+func parseFds(cmsg []byte) ([]int, error) {
+	if len(cmsg) == 0 {
+		return nil, nil
+	}
+	messages, err := syscall.ParseSocketControlMessage(cmsg)
 	if err != nil {
-		return -1, func() {}, err
+		return nil, err
 	}
+	var fds []int
+	for i := range messages {
+		if messages[i].Header.Level != syscall.SOL_SOCKET || messages[i].Header.Type != syscall.SCM_RIGHTS {
+			continue
+		}
+		rights, err := syscall.ParseUnixRights(&messages[i])
+		if err != nil {
+			return fds, err
+		}
+		fds = append(fds, rights...)
+	}
+	return fds, nil
+}
 
-	fd := int(file.Fd())
-	return fd, func() { file.Close() }, nil
+func closeFds(fds []int) {
+	for _, fd := range fds {
+		if err := syscall.Close(fd); err != nil {
+			logrus.Errorf("Failed to close fd %d: %v", fd, err)
+		}
+	}
 }
@@ -0,0 +1,204 @@
+package memifproxy
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const testTimeout = 5 * time.Second
+
+type testPeers struct {
+	dir      string
+	source   string
+	target   string
+	listener *net.UnixListener
+}
+
+func newTestPeers() *testPeers {
+	dir, err := ioutil.TempDir("", "memifproxy")
+	Expect(err).To(BeNil())
+	p := &testPeers{
+		dir:    dir,
+		source: path.Join(dir, "source.sock"),
+		target: path.Join(dir, "target.sock"),
+	}
+	p.listenTarget()
+	return p
+}
+
+func (p *testPeers) listenTarget() {
+	listener, err := net.ListenUnix(network, &net.UnixAddr{Name: p.target, Net: network})
+	Expect(err).To(BeNil())
+	p.listener = listener
+}
+
+func (p *testPeers) connect() (source, target *net.UnixConn) {
+	source, err := net.DialUnix(network, nil, &net.UnixAddr{Name: p.source, Net: network})
+	Expect(err).To(BeNil())
+	Expect(p.listener.SetDeadline(time.Now().Add(testTimeout))).To(BeNil())
+	target, err = p.listener.AcceptUnix()
+	Expect(err).To(BeNil())
+	return source, target
+}
+
+func (p *testPeers) cleanup() {
+	_ = p.listener.Close()
+	_ = os.RemoveAll(p.dir)
+}
+
+func send(conn *net.UnixConn, data string, fds ...int) {
+	var oob []byte
+	if len(fds) > 0 {
+		oob = syscall.UnixRights(fds...)
+	}
+	_, _, err := conn.WriteMsgUnix([]byte(data), oob, nil)
+	Expect(err).To(BeNil())
+}
+
+// receive returns an empty message if the connection is closed by the proxy.
+func receive(conn *net.UnixConn) (string, []int) {
+	data := make([]byte, bufferSize)
+	oob := make([]byte, syscall.CmsgSpace(maxFds*4))
+	Expect(conn.SetReadDeadline(time.Now().Add(testTimeout))).To(BeNil())
+	n, oobn, _, _, err := conn.ReadMsgUnix(data, oob)
+	if err == io.EOF {
+		return "", nil
+	}
+	Expect(err).To(BeNil())
+	fds, err := parseFds(oob[:oobn])
+	Expect(err).To(BeNil())
+	return string(data[:n]), fds
+}
+
+func TestProxyTransfer(t *testing.T) {
+	RegisterTestingT(t)
+
+	peers := newTestPeers()
+	defer peers.cleanup()
+
+	proxy := NewProxy(peers.source, peers.target)
+	Expect(proxy.Start()).To(BeNil())
+	defer proxy.Stop()
+	Expect(proxy.State()).To(Equal(StateListening))
+
+	source, target := peers.connect()
+	defer source.Close()
+	defer target.Close()
+
+	// A pipe is passed through the proxy, the target writes to it and the source reads.
+	pipe := make([]int, 2)
+	Expect(syscall.Pipe(pipe)).To(BeNil())
+	defer syscall.Close(pipe[0])
+	send(source, "hello", pipe[1])
+	Expect(syscall.Close(pipe[1])).To(BeNil())
+
+	data, fds := receive(target)
+	Expect(data).To(Equal("hello"))
+	Expect(fds).To(HaveLen(1))
+	_, err := syscall.Write(fds[0], []byte("via fd"))
+	Expect(err).To(BeNil())
+	Expect(syscall.Close(fds[0])).To(BeNil())
+
+	buffer := make([]byte, 16)
+	n, err := syscall.Read(pipe[0], buffer)
+	Expect(err).To(BeNil())
+	Expect(string(buffer[:n])).To(Equal("via fd"))
+
+	send(target, "reply")
+	data, fds = receive(source)
+	Expect(data).To(Equal("reply"))
+	Expect(fds).To(BeEmpty())
+
+	Expect(proxy.Stats()).To(Equal(Stats{
+		State:          StateConnected,
+		SourceToTarget: Counters{Messages: 1, Bytes: 5, Fds: 1},
+		TargetToSource: Counters{Messages: 1, Bytes: 5},
+		Connections:    1,
+	}))
+}
+
+func TestProxyReconnect(t *testing.T) {
+	RegisterTestingT(t)
+
+	peers := newTestPeers()
+	defer peers.cleanup()
+
+	proxy := NewProxy(peers.source, peers.target)
+	Expect(proxy.Start()).To(BeNil())
+	defer proxy.Stop()
+
+	source, target := peers.connect()
+	send(source, "first")
+	data, _ := receive(target)
+	Expect(data).To(Equal("first"))
+
+	// Target peer restarts, the source connection is closed by the proxy.
+	Expect(target.Close()).To(BeNil())
+	Expect(peers.listener.Close()).To(BeNil())
+	data, _ = receive(source)
+	Expect(data).To(BeEmpty())
+	Expect(source.Close()).To(BeNil())
+	Eventually(proxy.State, testTimeout).Should(Equal(StateListening))
+
+	peers.listenTarget()
+	source, target = peers.connect()
+	defer source.Close()
+	defer target.Close()
+
+	send(source, "second")
+	data, _ = receive(target)
+	Expect(data).To(Equal("second"))
+
+	stats := proxy.Stats()
+	Expect(stats.State).To(Equal(StateConnected))
+	Expect(stats.Connections).To(Equal(uint64(2)))
+	Expect(stats.SourceToTarget.Messages).To(Equal(uint64(2)))
+}
+
+func TestProxyStop(t *testing.T) {
+	RegisterTestingT(t)
+
+	peers := newTestPeers()
+	defer peers.cleanup()
+
+	proxy := NewProxy(peers.source, peers.target)
+	Expect(proxy.Start()).To(BeNil())
+
+	source, target := peers.connect()
+	defer source.Close()
+	defer target.Close()
+	send(source, "hello")
+	_, _ = receive(target)
+
+	proxy.Stop()
+	Expect(proxy.State()).To(Equal(StateStopped))
+
+	// Connections are closed and the source socket doesn't accept new ones.
+	data, _ := receive(target)
+	Expect(data).To(BeEmpty())
+	_, err := net.DialUnix(network, nil, &net.UnixAddr{Name: peers.source, Net: network})
+	Expect(err).NotTo(BeNil())
+}
+
+func TestProxyStopNotStarted(t *testing.T) {
+	RegisterTestingT(t)
+
+	peers := newTestPeers()
+	defer peers.cleanup()
+
+	// Source socket directory doesn't exist, so Start fails.
+	proxy := NewProxy(path.Join(peers.dir, "missing", "source.sock"), peers.target)
+	Expect(proxy.Start()).NotTo(BeNil())
+	proxy.Stop()
+	Expect(proxy.State()).To(Equal(StateStopped))
+
+	NewProxy(peers.source, peers.target).Stop()
+}
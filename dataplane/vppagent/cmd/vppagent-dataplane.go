@@ -49,6 +49,7 @@ const (
 )
 
 func main() {
+	tools.InitLogging()
 	start := time.Now()
 	tracer, closer := tools.InitJaeger("vppagent-dataplane")
 	opentracing.SetGlobalTracer(tracer)
@@ -17,32 +17,28 @@ package converter
 
 import (
 	"fmt"
+	"sort"
+
 	"github.com/ligato/vpp-agent/plugins/vpp/model/acl"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
-	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
-	"net"
-	"strconv"
-	"strings"
 )
 
 type aclConverter struct {
 	Name             string
 	Rules            map[string]string
 	IngressInterface string
+	EgressInterface  string
 }
 
-// NewAclConverter creates a new ACL converter
-//
-// action - DENY, PERMIT, REFLECT
-//
-// dtsnet, srcnet - IPv4 or IPv6 CIDR
+// NewAclConverter creates a new ACL converter applied to the traffic coming to VPP through the ingress interface.
+// Rules are keyed by rule names and applied in the order of names, see ParseAclRule for the rule grammar, e.g.
 //
-// icmptype - 8-bit unsigned integer
+// "action=reflect,proto=tcp,dstport=80"
 //
-// tcplowport, tcpupport - 16-bit unsigned integer
+// "action=permit,dstnet=2001:db8::/32,icmptype=128"
 //
-// udplowport, udpupport - 16-bit unsigned integer
+// "action=deny,srcmac=02:fe:00:00:00:01,srcip=10.0.0.1/32"
 //
 func NewAclConverter(name, ingress string, rules map[string]string) Converter {
 	rv := &aclConverter{
@@ -53,180 +49,15 @@ func NewAclConverter(name, ingress string, rules map[string]string) Converter {
 	return rv
 }
 
-func getAction(parsed map[string]string) (acl.AclAction, error) {
-	action_name, ok := parsed["action"]
-	if !ok {
-		return acl.AclAction(0), fmt.Errorf("Rule should have 'action' set.")
-	}
-	action, ok := acl.AclAction_value[strings.ToUpper(action_name)]
-	if !ok {
-		return acl.AclAction(0), fmt.Errorf("Rule should have a valid 'action'.")
-	}
-	return acl.AclAction(action), nil
-}
-
-func getIp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Ip, error) {
-	dstNet, dstNetOk := parsed["dstnet"]
-	srcNet, srcNetOk := parsed["srcnet"]
-	if dstNetOk {
-		_, _, err := net.ParseCIDR(dstNet)
-		if err != nil {
-			return nil, fmt.Errorf("dstnet is not a valid CIDR [%v]. Failed with: %v", dstNet, err)
-		}
-	} else {
-		dstNet = ""
-	}
-
-	if srcNetOk {
-		_, _, err := net.ParseCIDR(srcNet)
-		if err != nil {
-			return nil, fmt.Errorf("srcnet is not a valid CIDR [%v]. Failed with: %v", srcNet, err)
-		}
-	} else {
-		srcNet = ""
-	}
-
-	if dstNetOk || srcNetOk {
-		return &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{
-			DestinationNetwork: dstNet,
-			SourceNetwork:      srcNet,
-		}, nil
-	}
-	return nil, nil
-}
-
-func getIcmp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Icmp, error) {
-	icmpType, ok := parsed["icmptype"]
-	if !ok {
-		return nil, nil
-	}
-	icmpType8, err := strconv.ParseUint(icmpType, 10, 8)
-	if err != nil {
-		return nil, fmt.Errorf("Failed parsing icmptype [%v] with: %v", icmpType, err)
-	}
-	return &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp{
-		Icmpv6: false,
-		IcmpCodeRange: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range{
-			First: uint32(0),
-			Last:  uint32(65535),
-		},
-		IcmpTypeRange: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range{
-			First: uint32(icmpType8),
-			Last:  uint32(icmpType8),
-		},
-	}, nil
-}
-
-func getPort(name string, parsed map[string]string) (uint16, bool, error) {
-	port, ok := parsed[name]
-	if !ok {
-		return 0, false, nil
-	}
-	port16, err := strconv.ParseUint(port, 10, 16)
-	if err != nil {
-		return 0, true, fmt.Errorf("Failed parsing %s [%v] with: %v", name, port, err)
-	}
-
-	return uint16(port16), true, nil
-}
-
-func getTcp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Tcp, error) {
-	lowerPort, lpFound, lpErr := getPort("tcplowport", parsed)
-	if !lpFound {
-		return nil, nil
-	} else if lpErr != nil {
-		return nil, lpErr
-	}
-
-	upperPort, upFound, upErr := getPort("tcpupport", parsed)
-	if !upFound {
-		return nil, nil
-	} else if upErr != nil {
-		return nil, lpErr
-	}
-
-	return &acl.AccessLists_Acl_Rule_Match_IpRule_Tcp{
-		DestinationPortRange: &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
-			LowerPort: uint32(lowerPort),
-			UpperPort: uint32(upperPort),
-		},
-		SourcePortRange: &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
-			LowerPort: uint32(0),
-			UpperPort: uint32(65535),
-		},
-		TcpFlagsMask:  0,
-		TcpFlagsValue: 0,
-	}, nil
-}
-
-func getUdp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Udp, error) {
-	lowerPort, lpFound, lpErr := getPort("udplowport", parsed)
-	if !lpFound {
-		return nil, nil
-	} else if lpErr != nil {
-		return nil, lpErr
-	}
-
-	upperPort, upFound, upErr := getPort("udpupport", parsed)
-	if !upFound {
-		return nil, nil
-	} else if upErr != nil {
-		return nil, lpErr
-	}
-
-	return &acl.AccessLists_Acl_Rule_Match_IpRule_Udp{
-		DestinationPortRange: &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
-			LowerPort: uint32(lowerPort),
-			UpperPort: uint32(upperPort),
-		},
-		SourcePortRange: &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
-			LowerPort: uint32(0),
-			UpperPort: uint32(65535),
-		},
-	}, nil
-}
-
-func getIpRule(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule, error) {
-
-	ip, err := getIp(parsed)
-	if err != nil {
-		return nil, err
-	}
-
-	icmp, err := getIcmp(parsed)
-	if err != nil {
-		return nil, err
-	}
-
-	tcp, err := getTcp(parsed)
-	if err != nil {
-		return nil, err
-	}
-
-	udp, err := getUdp(parsed)
-	if err != nil {
-		return nil, err
-	}
-
-	return &acl.AccessLists_Acl_Rule_Match_IpRule{
-		Ip:   ip,
-		Icmp: icmp,
-		Tcp:  tcp,
-		Udp:  udp,
-	}, nil
-}
-
-func getMatch(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match, error) {
-
-	iprule, err := getIpRule(parsed)
-	if err != nil {
-		return nil, err
+// NewEgressAclConverter creates a new ACL converter applied to the traffic leaving VPP through the egress interface.
+// Rules have the same format as in NewAclConverter.
+func NewEgressAclConverter(name, egress string, rules map[string]string) Converter {
+	rv := &aclConverter{
+		Name:            name,
+		Rules:           rules,
+		EgressInterface: egress,
 	}
-
-	return &acl.AccessLists_Acl_Rule_Match{
-		IpRule:    iprule,
-		MacipRule: nil,
-	}, nil
+	return rv
 }
 
 func (c *aclConverter) ToDataRequest(rv *rpc.DataRequest, connect bool) (*rpc.DataRequest, error) {
@@ -238,21 +69,23 @@ func (c *aclConverter) ToDataRequest(rv *rpc.DataRequest, connect bool) (*rpc.Da
 		rv = &rpc.DataRequest{}
 	}
 
-	rules := []*acl.AccessLists_Acl_Rule{}
-
-	for name, rule := range c.Rules {
-		parsed := tools.ParseKVStringToMap(rule, ",", "=")
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		action, err := getAction(parsed)
+	rules := []*acl.AccessLists_Acl_Rule{}
+	macip := 0
+	for _, name := range names {
+		rule := c.Rules[name]
+		action, match, err := ParseAclRule(rule)
 		if err != nil {
 			logrus.Errorf("Parsing rule %s failed with %v", rule, err)
 			return nil, err
 		}
-
-		match, err := getMatch(parsed)
-		if err != nil {
-			logrus.Errorf("Parsing rule %s failed with %v", rule, err)
-			return nil, err
+		if match.GetMacipRule() != nil {
+			macip++
 		}
 
 		rules = append(rules, &acl.AccessLists_Acl_Rule{
@@ -260,18 +93,29 @@ func (c *aclConverter) ToDataRequest(rv *rpc.DataRequest, connect bool) (*rpc.Da
 			AclAction: action,
 			Match:     match,
 		})
+	}
+	if macip != 0 && macip != len(rules) {
+		return nil, fmt.Errorf("ACL %s could not have both MAC-IP and IP rules", c.Name)
+	}
+	if macip != 0 && c.EgressInterface != "" {
+		return nil, fmt.Errorf("ACL %s with MAC-IP rules could be applied to ingress interfaces only", c.Name)
+	}
 
-		rv.AccessLists = append(rv.AccessLists, &acl.AccessLists_Acl{
-			AclName: c.Name,
-			Rules:   rules,
-			Interfaces: &acl.AccessLists_Acl_Interfaces{
-				Egress: []string{},
-				Ingress: []string{
-					c.IngressInterface,
-				},
-			},
-		})
+	interfaces := &acl.AccessLists_Acl_Interfaces{
+		Egress:  []string{},
+		Ingress: []string{},
+	}
+	if c.IngressInterface != "" {
+		interfaces.Ingress = append(interfaces.Ingress, c.IngressInterface)
+	}
+	if c.EgressInterface != "" {
+		interfaces.Egress = append(interfaces.Egress, c.EgressInterface)
 	}
+	rv.AccessLists = append(rv.AccessLists, &acl.AccessLists_Acl{
+		AclName:    c.Name,
+		Rules:      rules,
+		Interfaces: interfaces,
+	})
 
 	return rv, nil
 }
@@ -0,0 +1,547 @@
+// Copyright 2018 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ligato/vpp-agent/plugins/vpp/model/acl"
+)
+
+// ACL rule grammar:
+//
+//   rule       = pair { "," pair }
+//   pair       = key "=" value
+//
+//   action     = "deny" | "permit" | "reflect"             - mandatory
+//
+// IP rules:
+//   srcnet     = cidr                                      - IPv4 or IPv6, the same family as dstnet
+//   dstnet     = cidr
+//   proto      = "icmp" | "icmpv6" | "tcp" | "udp" | number - only 1, 6, 17 and 58 numbers are supported
+//   icmptype   = range8                                    - implies "icmp", or "icmpv6" for IPv6 networks
+//   icmpcode   = range8
+//   srcport    = range16                                   - requires "tcp" or "udp"
+//   dstport    = range16                                   - requires "tcp" or "udp"
+//   tcpflags   = uint8 "/" uint8                           - value/mask, implies "tcp"
+//   tcplowport, tcpupport, udplowport, udpupport = uint16  - legacy "dstport" of "tcp" and "udp"
+//
+// MAC-IP rules, could not be mixed with IP rules:
+//   srcmac     = mac
+//   srcmacmask = mac                                       - defaults to ff:ff:ff:ff:ff:ff
+//   srcip      = cidr
+//
+//   range8     = uint8 [ "-" uint8 ]
+//   range16    = uint16 [ "-" uint16 ]
+//
+// Numbers could be decimal or hexadecimal with "0x" prefix. Host bits of cidr addresses are masked.
+
+const (
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+
+	maxPort     = 65535
+	maxIcmpCode = 255
+	fullMacMask = "ff:ff:ff:ff:ff:ff"
+)
+
+var protoNames = map[string]int{
+	"icmp":   protoICMP,
+	"tcp":    protoTCP,
+	"udp":    protoUDP,
+	"icmpv6": protoICMPv6,
+}
+
+var ipRuleKeys = []string{"srcnet", "dstnet", "proto", "icmptype", "icmpcode", "srcport", "dstport", "tcpflags",
+	"tcplowport", "tcpupport", "udplowport", "udpupport"}
+
+var macipRuleKeys = []string{"srcmac", "srcmacmask", "srcip"}
+
+// ParseAclRule parses the rule string into ACL action and match
+func ParseAclRule(rule string) (acl.AclAction, *acl.AccessLists_Acl_Rule_Match, error) {
+	parsed, err := parseRulePairs(rule)
+	if err != nil {
+		return acl.AclAction(0), nil, err
+	}
+	action, err := getAction(parsed)
+	if err != nil {
+		return acl.AclAction(0), nil, err
+	}
+	match, err := getMatch(parsed)
+	if err != nil {
+		return acl.AclAction(0), nil, err
+	}
+	return action, match, nil
+}
+
+// FormatAclRule returns the canonical rule string, ParseAclRule of it returns the same action and match
+func FormatAclRule(action acl.AclAction, match *acl.AccessLists_Acl_Rule_Match) string {
+	pairs := []string{"action=" + strings.ToLower(action.String())}
+	add := func(key, value string) {
+		if value != "" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	if macip := match.GetMacipRule(); macip != nil {
+		add("srcmac", macip.GetSourceMacAddress())
+		if macip.GetSourceMacAddressMask() != fullMacMask {
+			add("srcmacmask", macip.GetSourceMacAddressMask())
+		}
+		if macip.GetSourceAddress() != "" {
+			add("srcip", fmt.Sprintf("%s/%d", macip.GetSourceAddress(), macip.GetSourceAddressPrefix()))
+		}
+		return strings.Join(pairs, ",")
+	}
+
+	ipRule := match.GetIpRule()
+	add("srcnet", ipRule.GetIp().GetSourceNetwork())
+	add("dstnet", ipRule.GetIp().GetDestinationNetwork())
+	switch {
+	case ipRule.GetIcmp() != nil:
+		icmp := ipRule.GetIcmp()
+		if icmp.GetIcmpv6() {
+			add("proto", "icmpv6")
+		} else {
+			add("proto", "icmp")
+		}
+		add("icmptype", formatRange(icmp.GetIcmpTypeRange().GetFirst(), icmp.GetIcmpTypeRange().GetLast(), maxIcmpCode))
+		add("icmpcode", formatRange(icmp.GetIcmpCodeRange().GetFirst(), icmp.GetIcmpCodeRange().GetLast(), maxIcmpCode))
+	case ipRule.GetTcp() != nil:
+		tcp := ipRule.GetTcp()
+		add("proto", "tcp")
+		add("srcport", formatRange(tcp.GetSourcePortRange().GetLowerPort(), tcp.GetSourcePortRange().GetUpperPort(), maxPort))
+		add("dstport", formatRange(tcp.GetDestinationPortRange().GetLowerPort(), tcp.GetDestinationPortRange().GetUpperPort(), maxPort))
+		if tcp.GetTcpFlagsMask() != 0 {
+			add("tcpflags", fmt.Sprintf("0x%02x/0x%02x", tcp.GetTcpFlagsValue(), tcp.GetTcpFlagsMask()))
+		}
+	case ipRule.GetUdp() != nil:
+		udp := ipRule.GetUdp()
+		add("proto", "udp")
+		add("srcport", formatRange(udp.GetSourcePortRange().GetLowerPort(), udp.GetSourcePortRange().GetUpperPort(), maxPort))
+		add("dstport", formatRange(udp.GetDestinationPortRange().GetLowerPort(), udp.GetDestinationPortRange().GetUpperPort(), maxPort))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func formatRange(first, last, max uint32) string {
+	if first == 0 && last == max {
+		return ""
+	}
+	if first == last {
+		return strconv.FormatUint(uint64(first), 10)
+	}
+	return fmt.Sprintf("%d-%d", first, last)
+}
+
+func parseRulePairs(rule string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, pair := range strings.Split(rule, ",") {
+		keyValue := strings.Split(pair, "=")
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("Rule pair should have 'key=value' format: [%v]", pair)
+		}
+		key, value := strings.TrimSpace(keyValue[0]), strings.TrimSpace(keyValue[1])
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("Rule pair should have 'key=value' format: [%v]", pair)
+		}
+		if _, ok := parsed[key]; ok {
+			return nil, fmt.Errorf("Rule has duplicate key: %s", key)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+func hasAny(parsed map[string]string, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := parsed[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func checkKeys(parsed map[string]string) error {
+	for key := range parsed {
+		keys := map[string]string{key: ""}
+		if key != "action" && !hasAny(keys, ipRuleKeys) && !hasAny(keys, macipRuleKeys) {
+			return fmt.Errorf("Rule has unknown key: %s", key)
+		}
+	}
+	return nil
+}
+
+func parseUint(name, value string, bitSize int) (uint32, error) {
+	number, err := strconv.ParseUint(value, 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("Failed parsing %s [%v] with: %v", name, value, err)
+	}
+	return uint32(number), nil
+}
+
+// parseRange parses "first[-last]" range, ok is false if the key is not set.
+func parseRange(name string, parsed map[string]string, bitSize int, max uint32) (first, last uint32, ok bool, err error) {
+	value, ok := parsed[name]
+	if !ok {
+		return 0, max, false, nil
+	}
+	bounds := strings.Split(value, "-")
+	if len(bounds) > 2 {
+		return 0, 0, true, fmt.Errorf("Failed parsing %s [%v]: range should have 'first-last' format", name, value)
+	}
+	if first, err = parseUint(name, bounds[0], bitSize); err != nil {
+		return 0, 0, true, err
+	}
+	last = first
+	if len(bounds) == 2 {
+		if last, err = parseUint(name, bounds[1], bitSize); err != nil {
+			return 0, 0, true, err
+		}
+	}
+	if first > last {
+		return 0, 0, true, fmt.Errorf("Failed parsing %s [%v]: range start is greater than range end", name, value)
+	}
+	return first, last, true, nil
+}
+
+// parseCIDR returns the network of the CIDR, host bits of the address are masked, so 10.0.0.1/24 is 10.0.0.0/24.
+func parseCIDR(name, value string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid CIDR [%v]. Failed with: %v", name, value, err)
+	}
+	return ipNet, nil
+}
+
+func isIPv6(ipNet *net.IPNet) bool {
+	return ipNet.IP.To4() == nil
+}
+
+func getAction(parsed map[string]string) (acl.AclAction, error) {
+	action_name, ok := parsed["action"]
+	if !ok {
+		return acl.AclAction(0), fmt.Errorf("Rule should have 'action' set.")
+	}
+	action, ok := acl.AclAction_value[strings.ToUpper(action_name)]
+	if !ok {
+		return acl.AclAction(0), fmt.Errorf("Rule should have a valid 'action'.")
+	}
+	return acl.AclAction(action), nil
+}
+
+// getIp returns IP networks of the rule and whether they are IPv6
+func getIp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Ip, bool, error) {
+	var ip *acl.AccessLists_Acl_Rule_Match_IpRule_Ip
+	var families []bool
+	for _, name := range []string{"srcnet", "dstnet"} {
+		value, ok := parsed[name]
+		if !ok {
+			continue
+		}
+		ipNet, err := parseCIDR(name, value)
+		if err != nil {
+			return nil, false, err
+		}
+		if ip == nil {
+			ip = &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{}
+		}
+		if name == "srcnet" {
+			ip.SourceNetwork = ipNet.String()
+		} else {
+			ip.DestinationNetwork = ipNet.String()
+		}
+		families = append(families, isIPv6(ipNet))
+	}
+	if len(families) == 2 && families[0] != families[1] {
+		return nil, false, fmt.Errorf("srcnet and dstnet should be of the same IP family")
+	}
+	return ip, len(families) > 0 && families[0], nil
+}
+
+// getProto returns the rule protocol number, 0 matches any protocol.
+func getProto(parsed map[string]string, ipv6 bool) (int, error) {
+	proto := 0
+	if value, ok := parsed["proto"]; ok {
+		if number, ok := protoNames[strings.ToLower(value)]; ok {
+			proto = number
+		} else {
+			number, err := parseUint("proto", value, 8)
+			if err != nil {
+				return 0, err
+			}
+			proto = int(number)
+		}
+		switch proto {
+		case protoICMP, protoTCP, protoUDP, protoICMPv6:
+		default:
+			return 0, fmt.Errorf("proto [%v] is not supported, use one of icmp, icmpv6, tcp, udp", value)
+		}
+	}
+
+	implied := 0
+	switch {
+	case hasAny(parsed, []string{"icmptype", "icmpcode"}):
+		implied = protoICMP
+		if ipv6 || proto == protoICMPv6 {
+			implied = protoICMPv6
+		}
+	case hasAny(parsed, []string{"tcplowport", "tcpupport", "tcpflags"}):
+		implied = protoTCP
+	case hasAny(parsed, []string{"udplowport", "udpupport"}):
+		implied = protoUDP
+	}
+	if implied != 0 {
+		if proto != 0 && proto != implied {
+			return 0, fmt.Errorf("proto [%v] does not match the rule keys", parsed["proto"])
+		}
+		proto = implied
+	}
+
+	if hasAny(parsed, []string{"srcport", "dstport"}) && proto != protoTCP && proto != protoUDP {
+		return 0, fmt.Errorf("srcport and dstport require tcp or udp proto")
+	}
+	if proto == protoICMP && ipv6 {
+		return 0, fmt.Errorf("icmp proto could not be used with IPv6 networks, use icmpv6")
+	}
+	if proto == protoICMPv6 && hasAny(parsed, []string{"srcnet", "dstnet"}) && !ipv6 {
+		return 0, fmt.Errorf("icmpv6 proto could not be used with IPv4 networks, use icmp")
+	}
+	if hasAny(parsed, []string{"tcplowport", "tcpupport", "tcpflags", "udplowport", "udpupport"}) &&
+		hasAny(parsed, []string{"tcplowport", "tcpupport", "tcpflags"}) == hasAny(parsed, []string{"udplowport", "udpupport"}) {
+		return 0, fmt.Errorf("Rule could not have both tcp and udp keys")
+	}
+	return proto, nil
+}
+
+func getIcmp(parsed map[string]string, icmpv6 bool) (*acl.AccessLists_Acl_Rule_Match_IpRule_Icmp, error) {
+	typeFirst, typeLast, _, err := parseRange("icmptype", parsed, 8, maxIcmpCode)
+	if err != nil {
+		return nil, err
+	}
+	codeFirst, codeLast, _, err := parseRange("icmpcode", parsed, 8, maxIcmpCode)
+	if err != nil {
+		return nil, err
+	}
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp{
+		Icmpv6: icmpv6,
+		IcmpCodeRange: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range{
+			First: codeFirst,
+			Last:  codeLast,
+		},
+		IcmpTypeRange: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range{
+			First: typeFirst,
+			Last:  typeLast,
+		},
+	}, nil
+}
+
+// getPorts returns source and destination port ranges, legacy lowport and upport keys of the protocol are
+// the destination port range.
+func getPorts(parsed map[string]string, proto string) (src, dst *acl.AccessLists_Acl_Rule_Match_IpRule_PortRange, err error) {
+	srcLower, srcUpper, _, err := parseRange("srcport", parsed, 16, maxPort)
+	if err != nil {
+		return nil, nil, err
+	}
+	dstLower, dstUpper, dstOk, err := parseRange("dstport", parsed, 16, maxPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lowerPort, lowerOk, err := getPort(proto+"lowport", parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+	upperPort, upperOk, err := getPort(proto+"upport", parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lowerOk || upperOk {
+		if dstOk {
+			return nil, nil, fmt.Errorf("dstport could not be used with %slowport and %support", proto, proto)
+		}
+		if !upperOk {
+			upperPort = lowerPort
+		}
+		dstLower, dstUpper = uint32(lowerPort), uint32(upperPort)
+		if dstLower > dstUpper {
+			return nil, nil, fmt.Errorf("%slowport is greater than %support", proto, proto)
+		}
+	}
+
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
+			LowerPort: srcLower,
+			UpperPort: srcUpper,
+		}, &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{
+			LowerPort: dstLower,
+			UpperPort: dstUpper,
+		}, nil
+}
+
+func getPort(name string, parsed map[string]string) (uint16, bool, error) {
+	port, ok := parsed[name]
+	if !ok {
+		return 0, false, nil
+	}
+	port16, err := parseUint(name, port, 16)
+	if err != nil {
+		return 0, true, err
+	}
+	return uint16(port16), true, nil
+}
+
+func getTcpFlags(parsed map[string]string) (value, mask uint32, err error) {
+	flags, ok := parsed["tcpflags"]
+	if !ok {
+		return 0, 0, nil
+	}
+	valueMask := strings.Split(flags, "/")
+	if len(valueMask) != 2 {
+		return 0, 0, fmt.Errorf("Failed parsing tcpflags [%v]: should have 'value/mask' format", flags)
+	}
+	if value, err = parseUint("tcpflags value", valueMask[0], 8); err != nil {
+		return 0, 0, err
+	}
+	if mask, err = parseUint("tcpflags mask", valueMask[1], 8); err != nil {
+		return 0, 0, err
+	}
+	if mask == 0 || value&^mask != 0 {
+		return 0, 0, fmt.Errorf("Failed parsing tcpflags [%v]: value should be within non-zero mask", flags)
+	}
+	return value, mask, nil
+}
+
+func getTcp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Tcp, error) {
+	src, dst, err := getPorts(parsed, "tcp")
+	if err != nil {
+		return nil, err
+	}
+	value, mask, err := getTcpFlags(parsed)
+	if err != nil {
+		return nil, err
+	}
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_Tcp{
+		DestinationPortRange: dst,
+		SourcePortRange:      src,
+		TcpFlagsMask:         mask,
+		TcpFlagsValue:        value,
+	}, nil
+}
+
+func getUdp(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule_Udp, error) {
+	src, dst, err := getPorts(parsed, "udp")
+	if err != nil {
+		return nil, err
+	}
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_Udp{
+		DestinationPortRange: dst,
+		SourcePortRange:      src,
+	}, nil
+}
+
+func getIpRule(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_IpRule, error) {
+	ip, ipv6, err := getIp(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	proto, err := getProto(parsed, ipv6)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := &acl.AccessLists_Acl_Rule_Match_IpRule{
+		Ip: ip,
+	}
+	switch proto {
+	case protoICMP, protoICMPv6:
+		rv.Icmp, err = getIcmp(parsed, proto == protoICMPv6)
+	case protoTCP:
+		rv.Tcp, err = getTcp(parsed)
+	case protoUDP:
+		rv.Udp, err = getUdp(parsed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func getMacipRule(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match_MacIpRule, error) {
+	srcMac, ok := parsed["srcmac"]
+	if !ok {
+		return nil, fmt.Errorf("MAC-IP rule should have 'srcmac' set")
+	}
+	mac, err := net.ParseMAC(srcMac)
+	if err != nil {
+		return nil, fmt.Errorf("srcmac is not a valid MAC address [%v]. Failed with: %v", srcMac, err)
+	}
+	rv := &acl.AccessLists_Acl_Rule_Match_MacIpRule{
+		SourceMacAddress:     mac.String(),
+		SourceMacAddressMask: fullMacMask,
+	}
+	if srcMacMask, ok := parsed["srcmacmask"]; ok {
+		mask, err := net.ParseMAC(srcMacMask)
+		if err != nil {
+			return nil, fmt.Errorf("srcmacmask is not a valid MAC address [%v]. Failed with: %v", srcMacMask, err)
+		}
+		rv.SourceMacAddressMask = mask.String()
+	}
+	if srcIp, ok := parsed["srcip"]; ok {
+		ipNet, err := parseCIDR("srcip", srcIp)
+		if err != nil {
+			return nil, err
+		}
+		ones, _ := ipNet.Mask.Size()
+		rv.SourceAddress = ipNet.IP.String()
+		rv.SourceAddressPrefix = uint32(ones)
+	}
+	return rv, nil
+}
+
+func getMatch(parsed map[string]string) (*acl.AccessLists_Acl_Rule_Match, error) {
+	if err := checkKeys(parsed); err != nil {
+		return nil, err
+	}
+
+	if hasAny(parsed, macipRuleKeys) {
+		if hasAny(parsed, ipRuleKeys) {
+			return nil, fmt.Errorf("MAC-IP rule keys could not be mixed with IP rule keys")
+		}
+		macipRule, err := getMacipRule(parsed)
+		if err != nil {
+			return nil, err
+		}
+		return &acl.AccessLists_Acl_Rule_Match{
+			MacipRule: macipRule,
+		}, nil
+	}
+
+	iprule, err := getIpRule(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &acl.AccessLists_Acl_Rule_Match{
+		IpRule:    iprule,
+		MacipRule: nil,
+	}, nil
+}
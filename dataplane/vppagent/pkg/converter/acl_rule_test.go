@@ -0,0 +1,285 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/ligato/vpp-agent/plugins/vpp/model/acl"
+	. "github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
+	. "github.com/onsi/gomega"
+)
+
+func portRange(lower, upper uint32) *acl.AccessLists_Acl_Rule_Match_IpRule_PortRange {
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_PortRange{LowerPort: lower, UpperPort: upper}
+}
+
+func icmpRange(first, last uint32) *acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range {
+	return &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp_Range{First: first, Last: last}
+}
+
+func ipMatch(ipRule *acl.AccessLists_Acl_Rule_Match_IpRule) *acl.AccessLists_Acl_Rule_Match {
+	return &acl.AccessLists_Acl_Rule_Match{IpRule: ipRule}
+}
+
+func TestParseAclRule(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		rule      string
+		action    acl.AclAction
+		match     *acl.AccessLists_Acl_Rule_Match
+		canonical string
+	}{
+		{
+			name:      "any",
+			rule:      "action=deny",
+			action:    acl.AclAction_DENY,
+			match:     ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{}),
+			canonical: "action=deny",
+		},
+		{
+			name:   "legacy icmp",
+			rule:   "action=reflect,icmptype=8",
+			action: acl.AclAction_REFLECT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Icmp: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp{
+					IcmpCodeRange: icmpRange(0, 255),
+					IcmpTypeRange: icmpRange(8, 8),
+				},
+			}),
+			canonical: "action=reflect,proto=icmp,icmptype=8",
+		},
+		{
+			name:   "legacy tcp",
+			rule:   "action=reflect,tcplowport=80,tcpupport=80",
+			action: acl.AclAction_REFLECT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Tcp: &acl.AccessLists_Acl_Rule_Match_IpRule_Tcp{
+					DestinationPortRange: portRange(80, 80),
+					SourcePortRange:      portRange(0, 65535),
+				},
+			}),
+			canonical: "action=reflect,proto=tcp,dstport=80",
+		},
+		{
+			name:   "legacy udp",
+			rule:   "action=permit,udplowport=53,udpupport=54",
+			action: acl.AclAction_PERMIT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Udp: &acl.AccessLists_Acl_Rule_Match_IpRule_Udp{
+					DestinationPortRange: portRange(53, 54),
+					SourcePortRange:      portRange(0, 65535),
+				},
+			}),
+			canonical: "action=permit,proto=udp,dstport=53-54",
+		},
+		{
+			name:   "ipv4 networks",
+			rule:   "action=permit,srcnet=10.0.0.0/8,dstnet=192.168.1.0/24",
+			action: acl.AclAction_PERMIT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Ip: &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{
+					SourceNetwork:      "10.0.0.0/8",
+					DestinationNetwork: "192.168.1.0/24",
+				},
+			}),
+			canonical: "action=permit,srcnet=10.0.0.0/8,dstnet=192.168.1.0/24",
+		},
+		{
+			name:   "host bits are masked",
+			rule:   "action=permit,dstnet=10.0.0.1/24",
+			action: acl.AclAction_PERMIT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Ip: &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{
+					DestinationNetwork: "10.0.0.0/24",
+				},
+			}),
+			canonical: "action=permit,dstnet=10.0.0.0/24",
+		},
+		{
+			name:   "icmpv6 by network",
+			rule:   "action=permit,dstnet=2001:db8::/32,icmptype=128-129,icmpcode=0",
+			action: acl.AclAction_PERMIT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Ip: &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{
+					DestinationNetwork: "2001:db8::/32",
+				},
+				Icmp: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp{
+					Icmpv6:        true,
+					IcmpCodeRange: icmpRange(0, 0),
+					IcmpTypeRange: icmpRange(128, 129),
+				},
+			}),
+			canonical: "action=permit,dstnet=2001:db8::/32,proto=icmpv6,icmptype=128-129,icmpcode=0",
+		},
+		{
+			name:   "icmpv6 by proto number",
+			rule:   "action=deny,proto=58",
+			action: acl.AclAction_DENY,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Icmp: &acl.AccessLists_Acl_Rule_Match_IpRule_Icmp{
+					Icmpv6:        true,
+					IcmpCodeRange: icmpRange(0, 255),
+					IcmpTypeRange: icmpRange(0, 255),
+				},
+			}),
+			canonical: "action=deny,proto=icmpv6",
+		},
+		{
+			name:   "tcp ports and flags",
+			rule:   "action=permit,srcnet=fd00::/64,proto=tcp,srcport=1024-65535,dstport=443,tcpflags=0x02/0x12",
+			action: acl.AclAction_PERMIT,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Ip: &acl.AccessLists_Acl_Rule_Match_IpRule_Ip{
+					SourceNetwork: "fd00::/64",
+				},
+				Tcp: &acl.AccessLists_Acl_Rule_Match_IpRule_Tcp{
+					DestinationPortRange: portRange(443, 443),
+					SourcePortRange:      portRange(1024, 65535),
+					TcpFlagsMask:         0x12,
+					TcpFlagsValue:        0x02,
+				},
+			}),
+			canonical: "action=permit,srcnet=fd00::/64,proto=tcp,srcport=1024-65535,dstport=443,tcpflags=0x02/0x12",
+		},
+		{
+			name:   "udp by proto number",
+			rule:   "action=deny,proto=17,srcport=53",
+			action: acl.AclAction_DENY,
+			match: ipMatch(&acl.AccessLists_Acl_Rule_Match_IpRule{
+				Udp: &acl.AccessLists_Acl_Rule_Match_IpRule_Udp{
+					DestinationPortRange: portRange(0, 65535),
+					SourcePortRange:      portRange(53, 53),
+				},
+			}),
+			canonical: "action=deny,proto=udp,srcport=53",
+		},
+		{
+			name:   "macip",
+			rule:   "action=permit,srcmac=02:FE:00:00:00:01,srcip=10.0.0.1/32",
+			action: acl.AclAction_PERMIT,
+			match: &acl.AccessLists_Acl_Rule_Match{
+				MacipRule: &acl.AccessLists_Acl_Rule_Match_MacIpRule{
+					SourceAddress:        "10.0.0.1",
+					SourceAddressPrefix:  32,
+					SourceMacAddress:     "02:fe:00:00:00:01",
+					SourceMacAddressMask: "ff:ff:ff:ff:ff:ff",
+				},
+			},
+			canonical: "action=permit,srcmac=02:fe:00:00:00:01,srcip=10.0.0.1/32",
+		},
+		{
+			name:   "macip with mask",
+			rule:   "action=deny,srcmac=02:fe:00:00:00:00,srcmacmask=ff:ff:ff:00:00:00",
+			action: acl.AclAction_DENY,
+			match: &acl.AccessLists_Acl_Rule_Match{
+				MacipRule: &acl.AccessLists_Acl_Rule_Match_MacIpRule{
+					SourceMacAddress:     "02:fe:00:00:00:00",
+					SourceMacAddressMask: "ff:ff:ff:00:00:00",
+				},
+			},
+			canonical: "action=deny,srcmac=02:fe:00:00:00:00,srcmacmask=ff:ff:ff:00:00:00",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			action, match, err := ParseAclRule(test.rule)
+			Expect(err).To(BeNil())
+			Expect(action).To(Equal(test.action))
+			Expect(match).To(Equal(test.match))
+
+			canonical := FormatAclRule(action, match)
+			Expect(canonical).To(Equal(test.canonical))
+
+			roundTripAction, roundTripMatch, err := ParseAclRule(canonical)
+			Expect(err).To(BeNil())
+			Expect(roundTripAction).To(Equal(action))
+			Expect(roundTripMatch).To(Equal(match))
+		})
+	}
+}
+
+func TestParseAclRuleErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"no action", "icmptype=8"},
+		{"invalid action", "action=drop"},
+		{"malformed pair", "action=deny,srcnet"},
+		{"trailing comma", "action=deny,"},
+		{"duplicate key", "action=deny,action=permit"},
+		{"unknown key", "action=deny,vlan=10"},
+		{"invalid cidr", "action=deny,srcnet=10.0.0.0/33"},
+		{"mixed families", "action=deny,srcnet=10.0.0.0/8,dstnet=fd00::/8"},
+		{"unsupported proto", "action=deny,proto=47"},
+		{"invalid proto", "action=deny,proto=sctp"},
+		{"ports without proto", "action=deny,dstport=80"},
+		{"ports with icmp", "action=deny,proto=icmp,dstport=80"},
+		{"icmp with ipv6", "action=deny,dstnet=fd00::/8,proto=icmp"},
+		{"icmpv6 with ipv4", "action=deny,dstnet=10.0.0.0/8,proto=icmpv6"},
+		{"icmp type with tcp", "action=deny,proto=tcp,icmptype=8"},
+		{"icmp type overflow", "action=deny,icmptype=256"},
+		{"port overflow", "action=deny,proto=tcp,dstport=65536"},
+		{"reversed range", "action=deny,proto=tcp,dstport=90-80"},
+		{"malformed range", "action=deny,proto=tcp,dstport=1-2-3"},
+		{"tcp and udp keys", "action=deny,tcplowport=80,udplowport=53"},
+		{"legacy and dstport", "action=deny,proto=tcp,tcplowport=80,dstport=80"},
+		{"legacy udp with tcp proto", "action=deny,proto=tcp,udplowport=53"},
+		{"tcpflags without mask", "action=deny,tcpflags=0x02"},
+		{"tcpflags outside mask", "action=deny,tcpflags=0x03/0x02"},
+		{"tcpflags with udp", "action=deny,proto=udp,tcpflags=0x02/0x02"},
+		{"macip without mac", "action=deny,srcip=10.0.0.1/32"},
+		{"invalid mac", "action=deny,srcmac=02:fe"},
+		{"macip mixed with ip", "action=deny,srcmac=02:fe:00:00:00:01,proto=tcp"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			_, _, err := ParseAclRule(test.rule)
+			Expect(err).NotTo(BeNil())
+		})
+	}
+}
+
+func TestAclConverter(t *testing.T) {
+	RegisterTestingT(t)
+
+	rules := map[string]string{
+		"2 Allow TCP 80": "action=reflect,tcplowport=80,tcpupport=80",
+		"1 Allow ICMP":   "action=reflect,icmptype=8",
+		"3 Allow ICMPv6": "action=reflect,proto=icmpv6,icmptype=128",
+	}
+	dataRequest, err := NewAclConverter("ingress", "SRC-1", rules).ToDataRequest(nil, true)
+	Expect(err).To(BeNil())
+	Expect(dataRequest.AccessLists).To(HaveLen(1))
+
+	accessList := dataRequest.AccessLists[0]
+	Expect(accessList.AclName).To(Equal("ingress"))
+	Expect(accessList.Interfaces.Ingress).To(Equal([]string{"SRC-1"}))
+	Expect(accessList.Interfaces.Egress).To(BeEmpty())
+	Expect(accessList.Rules).To(HaveLen(3))
+	for i, name := range []string{"1 Allow ICMP", "2 Allow TCP 80", "3 Allow ICMPv6"} {
+		Expect(accessList.Rules[i].RuleName).To(Equal(name))
+	}
+
+	dataRequest, err = NewEgressAclConverter("egress", "SRC-1", rules).ToDataRequest(nil, true)
+	Expect(err).To(BeNil())
+	Expect(dataRequest.AccessLists[0].Interfaces.Egress).To(Equal([]string{"SRC-1"}))
+	Expect(dataRequest.AccessLists[0].Interfaces.Ingress).To(BeEmpty())
+}
+
+func TestAclConverterMacip(t *testing.T) {
+	RegisterTestingT(t)
+
+	macip := map[string]string{"Allow MAC": "action=permit,srcmac=02:fe:00:00:00:01"}
+	_, err := NewAclConverter("ingress", "SRC-1", macip).ToDataRequest(nil, true)
+	Expect(err).To(BeNil())
+
+	_, err = NewEgressAclConverter("egress", "SRC-1", macip).ToDataRequest(nil, true)
+	Expect(err).NotTo(BeNil())
+
+	macip["Deny all"] = "action=deny"
+	_, err = NewAclConverter("ingress", "SRC-1", macip).ToDataRequest(nil, true)
+	Expect(err).NotTo(BeNil())
+}
@@ -29,6 +29,9 @@ func (c *CrossConnectConverter) ToDataRequest(rv *rpc.DataRequest, connect bool)
 	if err := c.IsComplete(); err != nil {
 		return rv, err
 	}
+	if IsDirectKernelConnection(c.CrossConnect) {
+		return NewDirectKernelConnectionConverter(c.CrossConnect).ToDataRequest(rv, connect)
+	}
 	if rv == nil {
 		rv = &rpc.DataRequest{}
 	}
@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+
+	linux_interfaces "github.com/ligato/vpp-agent/plugins/linux/model/interfaces"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/sirupsen/logrus"
+)
+
+const DataplaneAllowDirectKernel = "DATAPLANE_ALLOW_DIRECT_KERNEL" // To disallow direct veth pairs please pass "false" into this env variable.
+
+// DirectKernelConnectionConverter connects local source and destination kernel interfaces
+// with a single veth pair between their network namespaces, VPP is not involved.
+type DirectKernelConnectionConverter struct {
+	*crossconnect.CrossConnect
+}
+
+// NewDirectKernelConnectionConverter creates a new direct kernel connection converter
+func NewDirectKernelConnectionConverter(c *crossconnect.CrossConnect) *DirectKernelConnectionConverter {
+	return &DirectKernelConnectionConverter{
+		CrossConnect: c,
+	}
+}
+
+// IsDirectKernelConnection checks if cross connect could be served by a direct veth pair:
+// both source and destination are local kernel interfaces.
+func IsDirectKernelConnection(c *crossconnect.CrossConnect) bool {
+	if os.Getenv(DataplaneAllowDirectKernel) == "false" {
+		return false
+	}
+	return c.GetLocalSource().GetMechanism().GetType() == connection.MechanismType_KERNEL_INTERFACE &&
+		c.GetLocalDestination().GetMechanism().GetType() == connection.MechanismType_KERNEL_INTERFACE
+}
+
+func (c *DirectKernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect bool) (*rpc.DataRequest, error) {
+	if c == nil {
+		return rv, fmt.Errorf("DirectKernelConnectionConverter cannot be nil")
+	}
+	if !IsDirectKernelConnection(c.CrossConnect) {
+		return rv, fmt.Errorf("DirectKernelConnectionConverter cannot be used on CrossConnect %v", c.CrossConnect)
+	}
+	src := c.GetLocalSource()
+	dst := c.GetLocalDestination()
+	if err := src.IsComplete(); err != nil {
+		return rv, err
+	}
+	if err := dst.IsComplete(); err != nil {
+		return rv, err
+	}
+	if rv == nil {
+		rv = &rpc.DataRequest{}
+	}
+
+	srcFilepath, err := src.GetMechanism().NetNsFileName()
+	if err != nil && connect {
+		return nil, err
+	}
+	dstFilepath, err := dst.GetMechanism().NetNsFileName()
+	if err != nil && connect {
+		return nil, err
+	}
+
+	srcName := "SRC-" + c.GetId()
+	dstName := "DST-" + c.GetId()
+	logrus.Infof("Using direct veth pair %s <-> %s", srcName, dstName)
+
+	rv.LinuxInterfaces = append(rv.LinuxInterfaces, &linux_interfaces.LinuxInterfaces_Interface{
+		Name:        srcName,
+		Type:        linux_interfaces.LinuxInterfaces_VETH,
+		Enabled:     true,
+		Description: src.GetMechanism().GetParameters()[connection.InterfaceDescriptionKey],
+		IpAddresses: []string{src.GetContext().SrcIpAddr},
+		HostIfName:  src.GetMechanism().GetParameters()[connection.InterfaceNameKey],
+		Mtu:         src.GetMechanism().GetMtu(),
+		Namespace: &linux_interfaces.LinuxInterfaces_Interface_Namespace{
+			Type:     linux_interfaces.LinuxInterfaces_Interface_Namespace_FILE_REF_NS,
+			Filepath: srcFilepath,
+		},
+		Veth: &linux_interfaces.LinuxInterfaces_Interface_Veth{
+			PeerIfName: dstName,
+		},
+	})
+	rv.LinuxInterfaces = append(rv.LinuxInterfaces, &linux_interfaces.LinuxInterfaces_Interface{
+		Name:        dstName,
+		Type:        linux_interfaces.LinuxInterfaces_VETH,
+		Enabled:     true,
+		Description: dst.GetMechanism().GetParameters()[connection.InterfaceDescriptionKey],
+		IpAddresses: []string{dst.GetContext().DstIpAddr},
+		HostIfName:  dst.GetMechanism().GetParameters()[connection.InterfaceNameKey],
+		Mtu:         dst.GetMechanism().GetMtu(),
+		Namespace: &linux_interfaces.LinuxInterfaces_Interface_Namespace{
+			Type:     linux_interfaces.LinuxInterfaces_Interface_Namespace_FILE_REF_NS,
+			Filepath: dstFilepath,
+		},
+		Veth: &linux_interfaces.LinuxInterfaces_Interface_Veth{
+			PeerIfName: srcName,
+		},
+	})
+
+	return appendSourceRoutes(rv, srcName, srcFilepath, src), nil
+}
@@ -0,0 +1,96 @@
+package converter_test
+
+import (
+	"os"
+	"testing"
+
+	linux_interfaces "github.com/ligato/vpp-agent/plugins/linux/model/interfaces"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	. "github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
+	. "github.com/onsi/gomega"
+)
+
+func createKernelConnection(ifName string) *connection.Connection {
+	return &connection.Connection{
+		Id:             connectionId,
+		NetworkService: networkService,
+		Mechanism: &connection.Mechanism{
+			Type: connection.MechanismType_KERNEL_INTERFACE,
+			Parameters: map[string]string{
+				connection.InterfaceNameKey:        ifName,
+				connection.InterfaceDescriptionKey: mechanismDescription,
+				connection.NetNsInodeKey:           "1",
+			},
+		},
+		Context: &connectioncontext.ConnectionContext{
+			SrcIpAddr: srcIp,
+			DstIpAddr: dstIp,
+			Routes:    []*connectioncontext.Route{{Prefix: "8.8.8.8/32"}},
+		},
+	}
+}
+
+func createKernelCrossConnect() *crossconnect.CrossConnect {
+	return &crossconnect.CrossConnect{
+		Id:      connectionId,
+		Payload: "IP",
+		Source: &crossconnect.CrossConnect_LocalSource{
+			LocalSource: createKernelConnection("nsm0"),
+		},
+		Destination: &crossconnect.CrossConnect_LocalDestination{
+			LocalDestination: createKernelConnection("nse0"),
+		},
+	}
+}
+
+func TestDirectKernelConverter(t *testing.T) {
+	RegisterTestingT(t)
+
+	xcon := createKernelCrossConnect()
+	Expect(IsDirectKernelConnection(xcon)).To(BeTrue())
+
+	// Pod network namespaces are not resolvable outside of the cluster, so disconnect request is built.
+	dataRequest, err := NewCrossConnectConverter(xcon, &CrossConnectConversionParameters{}).ToDataRequest(nil, false)
+	Expect(err).To(BeNil())
+
+	Expect(dataRequest.Interfaces).To(BeEmpty())
+	Expect(dataRequest.XCons).To(BeEmpty())
+	Expect(dataRequest.LinuxInterfaces).To(HaveLen(2))
+
+	src, dst := dataRequest.LinuxInterfaces[0], dataRequest.LinuxInterfaces[1]
+	Expect(src.Type).To(Equal(linux_interfaces.LinuxInterfaces_VETH))
+	Expect(src.HostIfName).To(Equal("nsm0"))
+	Expect(src.IpAddresses).To(Equal([]string{srcIp}))
+	Expect(src.Veth.PeerIfName).To(Equal(dst.Name))
+
+	Expect(dst.Type).To(Equal(linux_interfaces.LinuxInterfaces_VETH))
+	Expect(dst.HostIfName).To(Equal("nse0"))
+	Expect(dst.IpAddresses).To(Equal([]string{dstIp}))
+	Expect(dst.Veth.PeerIfName).To(Equal(src.Name))
+
+	Expect(dataRequest.LinuxRoutes).To(HaveLen(1))
+	Expect(dataRequest.LinuxRoutes[0].Interface).To(Equal(src.Name))
+	Expect(dataRequest.LinuxRoutes[0].GwAddr).To(Equal("10.30.1.2"))
+}
+
+func TestDirectKernelConverterDisabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(os.Setenv(DataplaneAllowDirectKernel, "false")).To(BeNil())
+	defer os.Unsetenv(DataplaneAllowDirectKernel)
+
+	Expect(IsDirectKernelConnection(createKernelCrossConnect())).To(BeFalse())
+}
+
+func TestDirectKernelConverterMixedMechanisms(t *testing.T) {
+	RegisterTestingT(t)
+
+	xcon := createKernelCrossConnect()
+	xcon.GetLocalDestination().Mechanism = createTestMechanism()
+	Expect(IsDirectKernelConnection(xcon)).To(BeFalse())
+
+	_, err := NewDirectKernelConnectionConverter(xcon).ToDataRequest(nil, true)
+	Expect(err).NotTo(BeNil())
+}
@@ -93,6 +93,7 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 			Description: m.GetParameters()[connection.InterfaceDescriptionKey],
 			IpAddresses: ipAddresses,
 			HostIfName:  m.GetParameters()[connection.InterfaceNameKey],
+			Mtu:         m.GetMtu(),
 			Namespace: &linux_interfaces.LinuxInterfaces_Interface_Namespace{
 				Type:     linux_interfaces.LinuxInterfaces_Interface_Namespace_FILE_REF_NS,
 				Filepath: filepath,
@@ -121,6 +122,7 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 			Description: m.GetParameters()[connection.InterfaceDescriptionKey],
 			IpAddresses: ipAddresses,
 			HostIfName:  m.GetParameters()[connection.InterfaceNameKey],
+			Mtu:         m.GetMtu(),
 			Namespace: &linux_interfaces.LinuxInterfaces_Interface_Namespace{
 				Type:     linux_interfaces.LinuxInterfaces_Interface_Namespace_FILE_REF_NS,
 				Filepath: filepath,
@@ -140,40 +142,8 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 
 	}
 
-	// Process static routes
 	if c.conversionParameters.Side == SOURCE {
-		for idx, route := range c.Connection.GetContext().GetRoutes() {
-			rv.LinuxRoutes = append(rv.LinuxRoutes, &l3.LinuxStaticRoutes_Route{
-				Name:        fmt.Sprintf("%s_route_%d", c.conversionParameters.Name, idx),
-				DstIpAddr:   route.Prefix,
-				Description: "Route to " + route.Prefix,
-				Interface:   c.conversionParameters.Name,
-				Namespace: &l3.LinuxStaticRoutes_Route_Namespace{
-					Type:     l3.LinuxStaticRoutes_Route_Namespace_FILE_REF_NS,
-					Filepath: filepath,
-				},
-				GwAddr: extractCleanIPAddress(c.Connection.GetContext().DstIpAddr),
-			})
-		}
-	}
-
-	// Process IP Neighbor entries
-	if c.conversionParameters.Side == SOURCE {
-		for idx, neightbour := range c.Connection.GetContext().GetIpNeighbors() {
-			rv.LinuxArpEntries = append(rv.LinuxArpEntries, &l3.LinuxStaticArpEntries_ArpEntry{
-				Name:      fmt.Sprintf("%s_arp_%d", c.conversionParameters.Name, idx),
-				IpAddr:    neightbour.Ip,
-				Interface: c.conversionParameters.Name,
-				HwAddress: neightbour.HardwareAddress,
-				Namespace: &l3.LinuxStaticArpEntries_ArpEntry_Namespace{
-					Type:     l3.LinuxStaticArpEntries_ArpEntry_Namespace_FILE_REF_NS,
-					Filepath: filepath,
-				},
-				State: &l3.LinuxStaticArpEntries_ArpEntry_NudState{
-					Type: l3.LinuxStaticArpEntries_ArpEntry_NudState_PERMANENT, // or NOARP, REACHABLE, STALE
-				},
-			})
-		}
+		rv = appendSourceRoutes(rv, c.conversionParameters.Name, filepath, c.Connection)
 	}
 
 	return rv, nil
@@ -189,3 +159,38 @@ func useVHostNet() bool {
 	}
 	return false
 }
+
+// appendSourceRoutes appends static routes and IP neighbor entries of the connection context
+// to the source side interface.
+func appendSourceRoutes(rv *rpc.DataRequest, name, filepath string, c *connection.Connection) *rpc.DataRequest {
+	for idx, route := range c.GetContext().GetRoutes() {
+		rv.LinuxRoutes = append(rv.LinuxRoutes, &l3.LinuxStaticRoutes_Route{
+			Name:        fmt.Sprintf("%s_route_%d", name, idx),
+			DstIpAddr:   route.Prefix,
+			Description: "Route to " + route.Prefix,
+			Interface:   name,
+			Namespace: &l3.LinuxStaticRoutes_Route_Namespace{
+				Type:     l3.LinuxStaticRoutes_Route_Namespace_FILE_REF_NS,
+				Filepath: filepath,
+			},
+			GwAddr: extractCleanIPAddress(c.GetContext().DstIpAddr),
+		})
+	}
+
+	for idx, neightbour := range c.GetContext().GetIpNeighbors() {
+		rv.LinuxArpEntries = append(rv.LinuxArpEntries, &l3.LinuxStaticArpEntries_ArpEntry{
+			Name:      fmt.Sprintf("%s_arp_%d", name, idx),
+			IpAddr:    neightbour.Ip,
+			Interface: name,
+			HwAddress: neightbour.HardwareAddress,
+			Namespace: &l3.LinuxStaticArpEntries_ArpEntry_Namespace{
+				Type:     l3.LinuxStaticArpEntries_ArpEntry_Namespace_FILE_REF_NS,
+				Filepath: filepath,
+			},
+			State: &l3.LinuxStaticArpEntries_ArpEntry_NudState{
+				Type: l3.LinuxStaticArpEntries_ArpEntry_NudState_PERMANENT, // or NOARP, REACHABLE, STALE
+			},
+		})
+	}
+	return rv
+}
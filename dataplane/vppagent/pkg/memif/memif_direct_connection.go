@@ -3,6 +3,7 @@ package memif
 import (
 	"os"
 	"path"
+	"strconv"
 	"sync"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
@@ -15,6 +16,11 @@ type DirectMemifConnector struct {
 	baseDir  string
 }
 
+type directMemifProxy struct {
+	proxy        *memifproxy.Proxy
+	crossConnect *crossconnect.CrossConnect
+}
+
 func NewDirectMemifConnector(baseDir string) *DirectMemifConnector {
 	return &DirectMemifConnector{
 		proxyMap: &sync.Map{},
@@ -39,8 +45,11 @@ func (d *DirectMemifConnector) connect(crossConnect *crossconnect.CrossConnect)
 	fullyQualifiedDstSocketFilename := path.Join(d.baseDir, dst.GetWorkspace(), dst.GetSocketFilename())
 	fullyQualifiedSrcSocketFilename := path.Join(d.baseDir, src.GetWorkspace(), src.GetSocketFilename())
 
-	value, exist := d.proxyMap.LoadOrStore(crossConnect.Id, memifproxy.NewProxy(fullyQualifiedSrcSocketFilename, fullyQualifiedDstSocketFilename))
-	proxy := value.(*memifproxy.Proxy)
+	value, exist := d.proxyMap.LoadOrStore(crossConnect.Id, &directMemifProxy{
+		proxy:        memifproxy.NewProxy(fullyQualifiedSrcSocketFilename, fullyQualifiedDstSocketFilename),
+		crossConnect: crossConnect,
+	})
+	proxy := value.(*directMemifProxy).proxy
 
 	if exist {
 		logrus.Warnf("Proxy for cross connect with id=%s already exists", crossConnect.Id)
@@ -53,6 +62,7 @@ func (d *DirectMemifConnector) connect(crossConnect *crossconnect.CrossConnect)
 	logrus.Infof("Successfully created directory: %v", path.Dir(fullyQualifiedSrcSocketFilename))
 
 	if err := proxy.Start(); err != nil {
+		d.proxyMap.Delete(crossConnect.Id)
 		return nil, err
 	}
 
@@ -67,8 +77,33 @@ func (d *DirectMemifConnector) disconnect(crossConnect *crossconnect.CrossConnec
 		return
 	}
 
-	proxy := value.(*memifproxy.Proxy)
-	proxy.Stop()
+	value.(*directMemifProxy).proxy.Stop()
 
 	d.proxyMap.Delete(crossConnect.Id)
 }
+
+// CrossConnects returns copies of cross connects served by direct memif proxies with metrics set to the proxy statistics.
+func (d *DirectMemifConnector) CrossConnects() []*crossconnect.CrossConnect {
+	var result []*crossconnect.CrossConnect
+	d.proxyMap.Range(func(key, value interface{}) bool {
+		p := value.(*directMemifProxy)
+		xcon := *p.crossConnect
+		xcon.Metrics = proxyMetrics(p.proxy.Stats())
+		result = append(result, &xcon)
+		return true
+	})
+	return result
+}
+
+func proxyMetrics(stats memifproxy.Stats) map[string]string {
+	return map[string]string{
+		"memif_proxy_state":       stats.State.String(),
+		"memif_proxy_connections": strconv.FormatUint(stats.Connections, 10),
+		"src_to_dst_messages":     strconv.FormatUint(stats.SourceToTarget.Messages, 10),
+		"src_to_dst_bytes":        strconv.FormatUint(stats.SourceToTarget.Bytes, 10),
+		"src_to_dst_fds":          strconv.FormatUint(stats.SourceToTarget.Fds, 10),
+		"dst_to_src_messages":     strconv.FormatUint(stats.TargetToSource.Messages, 10),
+		"dst_to_src_bytes":        strconv.FormatUint(stats.TargetToSource.Bytes, 10),
+		"dst_to_src_fds":          strconv.FormatUint(stats.TargetToSource.Fds, 10),
+	}
+}
@@ -16,12 +16,13 @@ package vppagent
 
 import (
 	"context"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/acl"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
@@ -32,7 +33,6 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
 	"github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/memif"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
-	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
@@ -49,8 +49,13 @@ type VPPAgent struct {
 	baseDir              string
 	egressInterface      *EgressInterface
 	directMemifConnector *memif.DirectMemifConnector
+	// closeMutex prevents memif proxy statistics from being reported for already closed cross connects
+	closeMutex sync.Mutex
 }
 
+// memifProxyStatsInterval is an interval of direct memif proxies statistics reporting
+const memifProxyStatsInterval = 5 * time.Second
+
 func NewVPPAgent(vppAgentEndpoint string, monitor *crossconnect_monitor.CrossConnectMonitor, baseDir string, egressInterface *EgressInterface) *VPPAgent {
 	// TODO provide some validations here for inputs
 	rv := &VPPAgent{
@@ -81,9 +86,27 @@ func NewVPPAgent(vppAgentEndpoint string, monitor *crossconnect_monitor.CrossCon
 	}
 	rv.reset()
 	rv.programMgmtInterface()
+	go rv.reportMemifProxyStats()
 	return rv
 }
 
+// reportMemifProxyStats periodically sends cross connects with changed direct memif proxy metrics to the monitor.
+func (v *VPPAgent) reportMemifProxyStats() {
+	reported := map[string]map[string]string{}
+	for range time.Tick(memifProxyStatsInterval) {
+		v.closeMutex.Lock()
+		current := map[string]map[string]string{}
+		for _, xcon := range v.directMemifConnector.CrossConnects() {
+			current[xcon.GetId()] = xcon.GetMetrics()
+			if !reflect.DeepEqual(reported[xcon.GetId()], xcon.GetMetrics()) {
+				v.monitor.Update(xcon)
+			}
+		}
+		v.closeMutex.Unlock()
+		reported = current
+	}
+}
+
 // Mechanisms is a message used to communicate any changes in operational parameters and constraints
 type Mechanisms struct {
 	remoteMechanisms []*remote.Mechanism
@@ -120,10 +143,14 @@ func (v *VPPAgent) MonitorMechanisms(empty *empty.Empty, updateSrv dataplane.Dat
 }
 
 func (v *VPPAgent) Request(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error) {
-	logrus.Infof("Request(ConnectRequest) called with %v", crossConnect)
+	logger := tools.Log(ctx).WithField(tools.LogFieldConnectionID, crossConnect.GetId())
+	logger.Infof("Request(ConnectRequest) called with %v", crossConnect)
 	xcon, err := v.ConnectOrDisConnect(ctx, crossConnect, true)
 	v.monitor.Update(xcon)
-	logrus.Infof("Request(ConnectRequest) called with %v returning: %v", crossConnect, xcon)
+	if err != nil {
+		logger.Errorf("Request(ConnectRequest) failed: %v", err)
+	}
+	logger.Infof("Request(ConnectRequest) called with %v returning: %v", crossConnect, xcon)
 	return xcon, err
 }
 
@@ -134,12 +161,7 @@ func (v *VPPAgent) ConnectOrDisConnect(ctx context.Context, crossConnect *crossc
 	}
 
 	// TODO look at whether keepin a single conn might be better
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.Dial(v.vppAgentEndpoint, grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	conn, err := grpc.Dial(v.vppAgentEndpoint, tools.DialOptions(grpc.WithInsecure())...)
 	if err != nil {
 		logrus.Errorf("can't dial grpc server: %v", err)
 		return nil, err
@@ -154,14 +176,15 @@ func (v *VPPAgent) ConnectOrDisConnect(ctx context.Context, crossConnect *crossc
 		logrus.Error(err)
 		return nil, err
 	}
-	logrus.Infof("Sending DataChange to vppagent: %v", dataChange)
+	logger := tools.Log(ctx).WithField(tools.LogFieldConnectionID, crossConnect.GetId())
+	logger.Infof("Sending DataChange to vppagent: %v", dataChange)
 	if connect {
 		_, err = client.Put(ctx, dataChange)
 	} else {
 		_, err = client.Del(ctx, dataChange)
 	}
 	if err != nil {
-		logrus.Error(err)
+		logger.Error(err)
 		// TODO handle connection tracking
 		// TODO handle teardown of any partial config that happened
 		return crossConnect, err
@@ -173,12 +196,7 @@ func (v *VPPAgent) reset() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
 	tools.WaitForPortAvailable(ctx, "tcp", v.vppAgentEndpoint, 100*time.Millisecond)
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.Dial(v.vppAgentEndpoint, grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	conn, err := grpc.Dial(v.vppAgentEndpoint, tools.DialOptions(grpc.WithInsecure())...)
 	if err != nil {
 		logrus.Errorf("can't dial grpc server: %v", err)
 		return err
@@ -198,12 +216,7 @@ func (v *VPPAgent) programMgmtInterface() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
 	tools.WaitForPortAvailable(ctx, "tcp", v.vppAgentEndpoint, 100*time.Millisecond)
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.Dial(v.vppAgentEndpoint, grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	conn, err := grpc.Dial(v.vppAgentEndpoint, tools.DialOptions(grpc.WithInsecure())...)
 	if err != nil {
 		logrus.Errorf("can't dial grpc server: %v", err)
 		return err
@@ -274,10 +287,13 @@ func (v *VPPAgent) programMgmtInterface() error {
 }
 
 func (v *VPPAgent) Close(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*empty.Empty, error) {
-	logrus.Infof("vppagent.DisconnectRequest called with %#v", crossConnect)
+	logger := tools.Log(ctx).WithField(tools.LogFieldConnectionID, crossConnect.GetId())
+	logger.Infof("vppagent.DisconnectRequest called with %#v", crossConnect)
+	v.closeMutex.Lock()
+	defer v.closeMutex.Unlock()
 	xcon, err := v.ConnectOrDisConnect(ctx, crossConnect, false)
 	if err != nil {
-		logrus.Warn(err)
+		logger.Warn(err)
 	}
 	v.monitor.Delete(xcon)
 	return &empty.Empty{}, nil
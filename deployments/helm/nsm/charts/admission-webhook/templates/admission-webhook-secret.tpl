@@ -33,6 +33,9 @@ spec:
         - name: nsm-admission-webhook
           image: {{ .Values.registry }}/networkservicemesh/admission-webhook:{{ .Values.tag }}
           imagePullPolicy: {{ .Values.pullPolicy }}
+          env:
+            - name: INJECTION_MODE
+              value: {{ .Values.injectionMode | quote }}
           volumeMounts:
             - name: webhook-certs
               mountPath: /etc/webhook/certs
@@ -71,6 +74,26 @@ webhooks:
       caBundle: {{ $ca.Cert | b64enc }}
     rules:
       - operations: ["CREATE"]
-        apiGroups: ["apps", "extensions", ""]
+        apiGroups: ["apps", "extensions", "batch", ""]
         apiVersions: ["v1", "v1beta1"]
-        resources: ["deployments", "services", "pods"]
\ No newline at end of file
+        resources: ["deployments", "statefulsets", "daemonsets", "replicasets", "jobs", "cronjobs", "services", "pods"]
+---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: nsm-admission-webhook-validating-cfg
+  labels:
+    app: nsm-admission-webhook
+webhooks:
+  - name: validating-admission-webhook.networkservicemesh.io
+    clientConfig:
+      service:
+        name: nsm-admission-webhook-svc
+        namespace: default
+        path: "/validate"
+      caBundle: {{ $ca.Cert | b64enc }}
+    rules:
+      - operations: ["CREATE", "UPDATE"]
+        apiGroups: ["networkservicemesh.io"]
+        apiVersions: ["v1"]
+        resources: ["networkservices", "networkserviceendpoints", "networkservicemanagers"]
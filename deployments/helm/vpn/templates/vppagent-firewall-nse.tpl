@@ -28,9 +28,29 @@ spec:
               value: "app=firewall"
             - name: TRACER_ENABLED
               value: "true"
+            - name: ACL_RULES_FILE
+              value: "/etc/vppagent-firewall/config.yaml"
+          volumeMounts:
+            - mountPath: /etc/vppagent-firewall
+              name: vppagent-firewall-config-volume
           resources:
             limits:
               networkservicemesh.io/socket: 1
+      volumes:
+        - name: vppagent-firewall-config-volume
+          configMap:
+            name: vppagent-firewall-config-file
 metadata:
   name: vppagent-firewall-nse
   namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: vppagent-firewall-config-file
+  namespace: default
+data:
+  config.yaml: |
+    ingress:
+      Allow ICMP: action=reflect,icmptype=8
+      Allow TCP 80: action=reflect,tcplowport=80,tcpupport=80
@@ -21,6 +21,7 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint/composite"
 	"github.com/sirupsen/logrus"
@@ -28,6 +29,7 @@ import (
 
 func main() {
 
+	tools.InitLogging()
 	composite := composite.NewMonitorCompositeEndpoint(nil).SetNext(
 		composite.NewIpamCompositeEndpoint(nil).SetNext(
 			composite.NewConnectionCompositeEndpoint(nil)))
@@ -15,25 +15,72 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/client"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// longRunningEnv keeps nsc running to monitor connections and restore them if they are lost
+	longRunningEnv = "NSC_LONG_RUNNING"
+)
+
 func main() {
 
+	tools.InitLogging()
 	tracer, closer := tools.InitJaeger("nsc")
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
 
-	client, err := client.NewNSMClientList(nil, nil)
+	nsc, err := client.NewNSMClientList(nil, nil)
 	if err != nil {
 		logrus.Fatalf("Unable to create the NSM client %v", err)
 	}
 
-	if err := client.Connect("nsm", "kernel", "Primary interface"); err != nil {
+	if err := nsc.Connect("nsm", "kernel", "Primary interface"); err != nil {
 		logrus.Fatalf("Client connect failed with error: %v", err)
 	}
 	logrus.Info("nsm client: initialization is completed successfully")
+
+	if longRunning, _ := strconv.ParseBool(os.Getenv(longRunningEnv)); !longRunning {
+		return
+	}
+	logrus.Info("nsm client: monitoring connections")
+
+	// Capture signals to close the connections before exiting
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan os.Signal, 1)
+	signal.Notify(c,
+		os.Interrupt,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	err = nsc.Monitor(ctx, func(event *client.ConnectionEvent) {
+		if event.Error != nil {
+			logrus.Errorf("nsm client: connection %s is %v: %v", event.Connection.GetId(), event.State, event.Error)
+			return
+		}
+		logrus.Infof("nsm client: connection %s is %v", event.Connection.GetId(), event.State)
+	})
+	// Connections are requested again on restart, so the current ones should not be left behind.
+	_ = nsc.Destroy()
+	if ctx.Err() == nil {
+		logrus.Errorf("nsm client: connection monitor failed: %v", err)
+		closer.Close()
+		os.Exit(1)
+	}
+	logrus.Info("nsm client: connections are closed")
 }
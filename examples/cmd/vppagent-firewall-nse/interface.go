@@ -143,67 +143,3 @@ func newVppAgentXConnComposite(configuration *common.NSConfiguration) *vppAgentX
 
 	return newVppAgentXConnComposite
 }
-
-type vppAgentAclComposite struct {
-	endpoint.BaseCompositeEndpoint
-	vppAgentEndpoint string
-}
-
-func (vac *vppAgentAclComposite) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
-
-	if vac.GetNext() == nil {
-		logrus.Fatal("Should have Next set")
-	}
-
-	incoming, err := vac.GetNext().Request(ctx, request)
-	if err != nil {
-		logrus.Error(err)
-		return nil, err
-	}
-
-	opaque := vac.GetNext().GetOpaque(incoming)
-	if opaque == nil {
-		err := fmt.Errorf("Backend: Unable to find the ingressIfName")
-		logrus.Errorf("%v", err)
-		return nil, err
-	}
-	ingressIfName := opaque.(string)
-
-	aclRules := map[string]string{
-		"Allow ICMP":   "action=reflect,icmptype=8",
-		"Allow TCP 80": "action=reflect,tcplowport=80,tcpupport=80",
-	}
-
-	err = vac.applyAclOnVppInterface(ctx, "IngressACL", ingressIfName, aclRules)
-	if err != nil {
-		logrus.Error(err)
-		return nil, err
-	}
-
-	return incoming, nil
-}
-
-func (vac *vppAgentAclComposite) Close(ctx context.Context, conn *connection.Connection) (*empty.Empty, error) {
-	if vac.GetNext() != nil {
-		return vac.GetNext().Close(ctx, conn)
-	}
-	return &empty.Empty{}, nil
-}
-
-// NewVppAgentComposite creates a new VPP Agent composite
-func newVppAgentAclComposite(configuration *common.NSConfiguration) *vppAgentAclComposite {
-	// ensure the env variables are processed
-	if configuration == nil {
-		configuration = &common.NSConfiguration{}
-	}
-	configuration.CompleteNSConfiguration()
-
-	logrus.Infof("newVppAgentComposite")
-
-	newVppAgentAclComposite := &vppAgentAclComposite{
-		vppAgentEndpoint: defaultVPPAgentEndpoint,
-	}
-	newVppAgentAclComposite.SetSelf(newVppAgentAclComposite)
-
-	return newVppAgentAclComposite
-}
@@ -21,23 +21,33 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint/composite"
 	"github.com/sirupsen/logrus"
 )
 
+// defaultAclConfig is used unless ACL_RULES_FILE is set
+var defaultAclConfig = &composite.AclConfig{
+	Ingress: composite.AclRules{
+		"Allow ICMP":   "action=reflect,icmptype=8",
+		"Allow TCP 80": "action=reflect,tcplowport=80,tcpupport=80",
+	},
+}
+
 func main() {
 
 	logrus.SetOutput(os.Stdout)
 	logrus.SetLevel(logrus.TraceLevel)
+	tools.InitLogging()
 
 	configuration := &common.NSConfiguration{
 		MechanismType: "mem",
 	}
 
 	composite := composite.NewMonitorCompositeEndpoint(nil).SetNext(
-		newVppAgentAclComposite(configuration).SetNext(
+		composite.NewAclCompositeEndpoint(configuration, defaultAclConfig).SetNext(
 			newVppAgentXConnComposite(configuration).SetNext(
 				composite.NewClientCompositeEndpoint(configuration).SetNext(
 					composite.NewConnectionCompositeEndpoint(configuration)))))
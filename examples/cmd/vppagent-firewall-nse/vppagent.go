@@ -88,35 +88,3 @@ func (vxc *vppAgentXConnComposite) reset() error {
 	logrus.Infof("Finished resetting vppagent...")
 	return nil
 }
-
-func (vac *vppAgentAclComposite) applyAclOnVppInterface(ctx context.Context, aclname, ifname string, rules map[string]string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
-	defer cancel()
-	tools.WaitForPortAvailable(ctx, "tcp", vac.vppAgentEndpoint, 100*time.Millisecond)
-	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.Dial(vac.vppAgentEndpoint, grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
-	if err != nil {
-		logrus.Errorf("can't dial grpc server: %v", err)
-		return err
-	}
-	defer conn.Close()
-	client := rpc.NewDataChangeServiceClient(conn)
-
-	dataChange, err := converter.NewAclConverter(aclname, ifname, rules).ToDataRequest(nil, true)
-
-	if err != nil {
-		logrus.Error(err)
-		return err
-	}
-	logrus.Infof("Sending DataChange to vppagent: %v", dataChange)
-	if _, err := client.Put(ctx, dataChange); err != nil {
-		logrus.Error(err)
-		client.Del(ctx, dataChange)
-		return err
-	}
-	return nil
-}
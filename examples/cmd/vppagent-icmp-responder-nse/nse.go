@@ -21,6 +21,7 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint/composite"
@@ -29,6 +30,7 @@ import (
 
 func main() {
 
+	tools.InitLogging()
 	configuration := &common.NSConfiguration{
 		MechanismType: "mem",
 	}
@@ -56,6 +56,7 @@ func (nscb *nsClientBackend) Connect(connection *connection.Connection) error {
 
 func main() {
 
+	tools.InitLogging()
 	tracer, closer := tools.InitJaeger("nsc")
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
@@ -15,6 +15,8 @@ import (
 	"k8s.io/api/admission/v1beta1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -54,21 +56,34 @@ var (
 	repo          string
 	initContainer string
 	tag           string
+	injectionMode string
 )
 
 const (
 	nsmAnnotationKey = "ns.networkservicemesh.io"
+	// nsmModeAnnotationKey selects an injection mode for a particular pod, overriding the webhook default
+	nsmModeAnnotationKey = "ns.networkservicemesh.io/mode"
 
 	repoEnv          = "REPO"
 	initContainerEnv = "INITCONTAINER"
 	tagEnv           = "TAG"
+	// injectionModeEnv is a default injection mode of the webhook
+	injectionModeEnv = "INJECTION_MODE"
 
 	repoDefault          = "networkservicemesh"
 	initContainerDefault = "nsc"
 	tagDefault           = "latest"
 
-	pathDeploymentInitContainers = "/spec/template/spec/initContainers"
-	pathPodInitContainers        = "/spec/initContainers"
+	// injectionModeInit injects nsc as an init container, connections are requested once at pod start
+	injectionModeInit = "init"
+	// injectionModeSidecar injects nsc as a long-running sidecar container, which monitors and restores connections
+	injectionModeSidecar = "sidecar"
+
+	nscContainerName = "nsc"
+
+	pathPodSpec         = "/spec"
+	pathTemplatePodSpec = "/spec/template/spec"
+	pathCronJobPodSpec  = "/spec/jobTemplate/spec/template/spec"
 )
 
 func init() {
@@ -81,9 +96,14 @@ func init() {
 
 func getAnnotationValue(ignoredList []string, metadata *metav1.ObjectMeta, spec *corev1.PodSpec) (string, bool) {
 
-	// check if InitContainer already injected
+	// check if nsc is already injected
 	for _, c := range spec.InitContainers {
-		if c.Name == "nsc" {
+		if c.Name == nscContainerName {
+			return "", false
+		}
+	}
+	for _, c := range spec.Containers {
+		if c.Name == nscContainerName {
 			return "", false
 		}
 	}
@@ -105,41 +125,72 @@ func getAnnotationValue(ignoredList []string, metadata *metav1.ObjectMeta, spec
 	return value, ok
 }
 
+func getInjectionMode(metadata *metav1.ObjectMeta, defaultMode string) (string, error) {
+	mode, ok := metadata.GetAnnotations()[nsmModeAnnotationKey]
+	if !ok {
+		return defaultMode, nil
+	}
+	switch mode {
+	case injectionModeInit, injectionModeSidecar:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid %s annotation value %q, expected %s or %s", nsmModeAnnotationKey, mode, injectionModeInit, injectionModeSidecar)
+}
+
 func validateAnnotationValue(value string) error {
 	urls, err := tools.ParseAnnotationValue(value)
 	logrus.Infof("Annotation nsurls: %v", urls)
-	return err
+	if err != nil {
+		return err
+	}
+	// Reserved parameters are validated, so typos are rejected at pod creation.
+	for _, url := range urls {
+		if _, err := url.Options(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func createPatch(annotationValue string, path string) ([]byte, error) {
-	var patch []patchOperation
-
-	value := []interface{}{
-		map[string]interface{}{
-			"name":            "nsc",
-			"image":           fmt.Sprintf("%s/%s:%s", repo, initContainer, tag),
-			"imagePullPolicy": "IfNotPresent",
-			"env": []interface{}{
-				map[string]string{
-					"name":  "NS_NETWORKSERVICEMESH_IO",
-					"value": annotationValue,
-				},
-			},
-			"resources": map[string]interface{}{
-				"limits": map[string]interface{}{
-					"networkservicemesh.io/socket": 1,
-				},
-			},
-		},
+// podTemplate returns metadata, pod spec and JSON path of the pod spec of the admitted object, ok is false
+// if the object kind does not contain a pod.
+func podTemplate(kind string, raw []byte) (meta *metav1.ObjectMeta, spec *corev1.PodSpec, path string, ok bool, err error) {
+	switch kind {
+	case "Pod":
+		var pod corev1.Pod
+		err = json.Unmarshal(raw, &pod)
+		return &pod.ObjectMeta, &pod.Spec, pathPodSpec, true, err
+	case "Deployment":
+		var deployment appsv1.Deployment
+		err = json.Unmarshal(raw, &deployment)
+		return &deployment.ObjectMeta, &deployment.Spec.Template.Spec, pathTemplatePodSpec, true, err
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		err = json.Unmarshal(raw, &statefulSet)
+		return &statefulSet.ObjectMeta, &statefulSet.Spec.Template.Spec, pathTemplatePodSpec, true, err
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		err = json.Unmarshal(raw, &daemonSet)
+		return &daemonSet.ObjectMeta, &daemonSet.Spec.Template.Spec, pathTemplatePodSpec, true, err
+	case "ReplicaSet":
+		var replicaSet appsv1.ReplicaSet
+		err = json.Unmarshal(raw, &replicaSet)
+		return &replicaSet.ObjectMeta, &replicaSet.Spec.Template.Spec, pathTemplatePodSpec, true, err
+	case "Job":
+		var job batchv1.Job
+		err = json.Unmarshal(raw, &job)
+		return &job.ObjectMeta, &job.Spec.Template.Spec, pathTemplatePodSpec, true, err
+	case "CronJob":
+		var cronJob batchv1beta1.CronJob
+		err = json.Unmarshal(raw, &cronJob)
+		return &cronJob.ObjectMeta, &cronJob.Spec.JobTemplate.Spec.Template.Spec, pathCronJobPodSpec, true, err
 	}
+	return nil, nil, "", false, nil
+}
 
-	patch = append(patch, patchOperation{
-		Op:    "add",
-		Path:  path,
-		Value: value,
-	})
-
-	return json.Marshal(patch)
+// isRunToCompletion checks if pods of the kind are expected to terminate, a sidecar would prevent it.
+func isRunToCompletion(kind string) bool {
+	return kind == "Job" || kind == "CronJob"
 }
 
 func (whsvr *WebhookServer) mutate(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -148,38 +199,16 @@ func (whsvr *WebhookServer) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 	logrus.Infof("AdmissionReview for Kind=%v, Namespace=%v Name=%v UID=%v patchOperation=%v UserInfo=%v",
 		req.Kind, req.Namespace, req.Name, req.UID, req.Operation, req.UserInfo)
 
-	var meta *metav1.ObjectMeta
-	var spec *corev1.PodSpec
-	var path string
-
-	switch req.Kind.Kind {
-	case "Deployment":
-		var deployment appsv1.Deployment
-		if err := json.Unmarshal(req.Object.Raw, &deployment); err != nil {
-			logrus.Errorf("Could not unmarshal raw object: %v", err)
-			return &v1beta1.AdmissionResponse{
-				Result: &metav1.Status{
-					Message: err.Error(),
-				},
-			}
-		}
-		meta = &deployment.ObjectMeta
-		spec = &deployment.Spec.Template.Spec
-		path = pathDeploymentInitContainers
-	case "Pod":
-		var pod corev1.Pod
-		if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
-			logrus.Errorf("Could not unmarshal raw object: %v", err)
-			return &v1beta1.AdmissionResponse{
-				Result: &metav1.Status{
-					Message: err.Error(),
-				},
-			}
+	meta, spec, path, ok, err := podTemplate(req.Kind.Kind, req.Object.Raw)
+	if err != nil {
+		logrus.Errorf("Could not unmarshal raw object: %v", err)
+		return &v1beta1.AdmissionResponse{
+			Result: &metav1.Status{
+				Message: err.Error(),
+			},
 		}
-		meta = &pod.ObjectMeta
-		spec = &pod.Spec
-		path = pathPodInitContainers
-	default:
+	}
+	if !ok {
 		return &v1beta1.AdmissionResponse{
 			Allowed: true,
 		}
@@ -194,7 +223,16 @@ func (whsvr *WebhookServer) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		}
 	}
 
-	err := validateAnnotationValue(value)
+	err = validateAnnotationValue(value)
+	if err != nil {
+		return &v1beta1.AdmissionResponse{
+			Result: &metav1.Status{
+				Message: err.Error(),
+			},
+		}
+	}
+
+	mode, err := getInjectionMode(meta, injectionMode)
 	if err != nil {
 		return &v1beta1.AdmissionResponse{
 			Result: &metav1.Status{
@@ -202,8 +240,12 @@ func (whsvr *WebhookServer) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 			},
 		}
 	}
+	if mode == injectionModeSidecar && isRunToCompletion(req.Kind.Kind) {
+		logrus.Infof("%s %s/%s should run to completion, injecting nsc as init container", req.Kind.Kind, meta.Namespace, meta.Name)
+		mode = injectionModeInit
+	}
 
-	patchBytes, err := createPatch(value, path)
+	patchBytes, err := createPatch(value, mode, spec, path)
 	if err != nil {
 		return &v1beta1.AdmissionResponse{
 			Result: &metav1.Status{
@@ -223,7 +265,10 @@ func (whsvr *WebhookServer) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 	}
 }
 
-func (whsvr *WebhookServer) serve(w http.ResponseWriter, r *http.Request) {
+// admitFunc handles an admission review, returning the admission response
+type admitFunc func(*v1beta1.AdmissionReview) *v1beta1.AdmissionResponse
+
+func (whsvr *WebhookServer) serve(w http.ResponseWriter, r *http.Request, admit admitFunc) {
 	var body []byte
 	if r.Body != nil {
 		if data, err := ioutil.ReadAll(r.Body); err == nil {
@@ -254,7 +299,7 @@ func (whsvr *WebhookServer) serve(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 	} else {
-		admissionResponse = whsvr.mutate(&ar)
+		admissionResponse = admit(&ar)
 	}
 
 	admissionReview := v1beta1.AdmissionReview{}
@@ -294,6 +339,14 @@ func main() {
 		tag = tagDefault
 	}
 
+	injectionMode = os.Getenv(injectionModeEnv)
+	if injectionMode == "" {
+		injectionMode = injectionModeInit
+	}
+	if injectionMode != injectionModeInit && injectionMode != injectionModeSidecar {
+		logrus.Fatalf("Invalid %s value %s, expected %s or %s", injectionModeEnv, injectionMode, injectionModeInit, injectionModeSidecar)
+	}
+
 	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
 	if err != nil {
 		logrus.Fatalf("Failed to load key pair: %v", err)
@@ -308,7 +361,12 @@ func main() {
 
 	// define http server and server handler
 	mux := http.NewServeMux()
-	mux.HandleFunc("/mutate", whsvr.serve)
+	mux.HandleFunc("/mutate", func(w http.ResponseWriter, r *http.Request) {
+		whsvr.serve(w, r, whsvr.mutate)
+	})
+	mux.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
+		whsvr.serve(w, r, whsvr.validate)
+	})
 	whsvr.server.Handler = mux
 
 	// start webhook server in new routine
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationEnv  = "NS_NETWORKSERVICEMESH_IO"
+	longRunningEnv = "NSC_LONG_RUNNING"
+)
+
+func nscContainer(annotationValue string, longRunning bool) map[string]interface{} {
+	env := []interface{}{
+		map[string]string{
+			"name":  annotationEnv,
+			"value": annotationValue,
+		},
+	}
+	if longRunning {
+		env = append(env, map[string]string{
+			"name":  longRunningEnv,
+			"value": "true",
+		})
+	}
+	return map[string]interface{}{
+		"name":            nscContainerName,
+		"image":           fmt.Sprintf("%s/%s:%s", repo, initContainer, tag),
+		"imagePullPolicy": "IfNotPresent",
+		"env":             env,
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{
+				"networkservicemesh.io/socket": 1,
+			},
+		},
+	}
+}
+
+// createPatch creates a JSON patch injecting nsc container into the pod spec located at specPath.
+func createPatch(annotationValue string, mode string, spec *corev1.PodSpec, specPath string) ([]byte, error) {
+	var patch []patchOperation
+
+	if mode == injectionModeSidecar {
+		// Pod spec always has containers, so sidecar is appended
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  specPath + "/containers/-",
+			Value: nscContainer(annotationValue, true),
+		})
+	} else if len(spec.InitContainers) > 0 {
+		// nsc is added after existing init containers, adding the whole list would replace them
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  specPath + "/initContainers/-",
+			Value: nscContainer(annotationValue, false),
+		})
+	} else {
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  specPath + "/initContainers",
+			Value: []interface{}{nscContainer(annotationValue, false)},
+		})
+	}
+
+	return json.Marshal(patch)
+}
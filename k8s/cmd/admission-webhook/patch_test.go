@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	repo, initContainer, tag = repoDefault, initContainerDefault, tagDefault
+}
+
+func expectedContainer(annotationValue string, longRunning bool) map[string]interface{} {
+	env := []interface{}{
+		map[string]interface{}{"name": annotationEnv, "value": annotationValue},
+	}
+	if longRunning {
+		env = append(env, map[string]interface{}{"name": longRunningEnv, "value": "true"})
+	}
+	return map[string]interface{}{
+		"name":            "nsc",
+		"image":           "networkservicemesh/nsc:latest",
+		"imagePullPolicy": "IfNotPresent",
+		"env":             env,
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{
+				"networkservicemesh.io/socket": float64(1),
+			},
+		},
+	}
+}
+
+func decodePatch(t *testing.T, patch []byte) []map[string]interface{} {
+	var result []map[string]interface{}
+	if err := json.Unmarshal(patch, &result); err != nil {
+		t.Fatalf("Invalid JSON patch %s: %v", patch, err)
+	}
+	return result
+}
+
+func TestCreatePatch(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		spec *corev1.PodSpec
+		want []map[string]interface{}
+	}{
+		{
+			name: "Init",
+			mode: injectionModeInit,
+			spec: &corev1.PodSpec{},
+			want: []map[string]interface{}{{
+				"op":    "add",
+				"path":  "/spec/template/spec/initContainers",
+				"value": []interface{}{expectedContainer("icmp", false)},
+			}},
+		},
+		{
+			name: "InitWithExistingInitContainers",
+			mode: injectionModeInit,
+			spec: &corev1.PodSpec{InitContainers: []corev1.Container{{Name: "init"}}},
+			want: []map[string]interface{}{{
+				"op":    "add",
+				"path":  "/spec/template/spec/initContainers/-",
+				"value": expectedContainer("icmp", false),
+			}},
+		},
+		{
+			name: "Sidecar",
+			mode: injectionModeSidecar,
+			spec: &corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			want: []map[string]interface{}{{
+				"op":    "add",
+				"path":  "/spec/template/spec/containers/-",
+				"value": expectedContainer("icmp", true),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := createPatch("icmp", tt.mode, tt.spec, pathTemplatePodSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := decodePatch(t, patch); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createPatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMutate(t *testing.T) {
+	injectionMode = injectionModeInit
+
+	meta := func(annotations map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: annotations}
+	}
+	template := corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	annotations := map[string]string{nsmAnnotationKey: "icmp"}
+	sidecarAnnotations := map[string]string{nsmAnnotationKey: "icmp", nsmModeAnnotationKey: injectionModeSidecar}
+
+	tests := []struct {
+		name     string
+		kind     string
+		object   runtime.Object
+		wantPath string
+		allowed  bool
+	}{
+		{
+			name:     "Pod",
+			kind:     "Pod",
+			object:   &corev1.Pod{ObjectMeta: meta(annotations), Spec: template.Spec},
+			wantPath: "/spec/initContainers",
+			allowed:  true,
+		},
+		{
+			name:     "PodSidecar",
+			kind:     "Pod",
+			object:   &corev1.Pod{ObjectMeta: meta(sidecarAnnotations), Spec: template.Spec},
+			wantPath: "/spec/containers/-",
+			allowed:  true,
+		},
+		{
+			name:     "Deployment",
+			kind:     "Deployment",
+			object:   &appsv1.Deployment{ObjectMeta: meta(annotations), Spec: appsv1.DeploymentSpec{Template: template}},
+			wantPath: "/spec/template/spec/initContainers",
+			allowed:  true,
+		},
+		{
+			name:     "StatefulSetSidecar",
+			kind:     "StatefulSet",
+			object:   &appsv1.StatefulSet{ObjectMeta: meta(sidecarAnnotations), Spec: appsv1.StatefulSetSpec{Template: template}},
+			wantPath: "/spec/template/spec/containers/-",
+			allowed:  true,
+		},
+		{
+			name:     "DaemonSet",
+			kind:     "DaemonSet",
+			object:   &appsv1.DaemonSet{ObjectMeta: meta(annotations), Spec: appsv1.DaemonSetSpec{Template: template}},
+			wantPath: "/spec/template/spec/initContainers",
+			allowed:  true,
+		},
+		{
+			name:     "JobSidecarFallsBackToInit",
+			kind:     "Job",
+			object:   &batchv1.Job{ObjectMeta: meta(sidecarAnnotations), Spec: batchv1.JobSpec{Template: template}},
+			wantPath: "/spec/template/spec/initContainers",
+			allowed:  true,
+		},
+		{
+			name: "CronJob",
+			kind: "CronJob",
+			object: &batchv1beta1.CronJob{ObjectMeta: meta(annotations), Spec: batchv1beta1.CronJobSpec{
+				JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+			}},
+			wantPath: "/spec/jobTemplate/spec/template/spec/initContainers",
+			allowed:  true,
+		},
+		{
+			name:    "NotAnnotated",
+			kind:    "Deployment",
+			object:  &appsv1.Deployment{ObjectMeta: meta(nil), Spec: appsv1.DeploymentSpec{Template: template}},
+			allowed: true,
+		},
+		{
+			name:    "InvalidAnnotation",
+			kind:    "Pod",
+			object:  &corev1.Pod{ObjectMeta: meta(map[string]string{nsmAnnotationKey: "icmp?mtu=abc"}), Spec: template.Spec},
+			allowed: false,
+		},
+		{
+			name:    "InvalidMode",
+			kind:    "Pod",
+			object:  &corev1.Pod{ObjectMeta: meta(map[string]string{nsmAnnotationKey: "icmp", nsmModeAnnotationKey: "sidecars"}), Spec: template.Spec},
+			allowed: false,
+		},
+		{
+			name:    "AlreadyInjected",
+			kind:    "Pod",
+			object:  &corev1.Pod{ObjectMeta: meta(annotations), Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "nsc"}}}},
+			allowed: true,
+		},
+		{
+			name:    "Service",
+			kind:    "Service",
+			object:  &corev1.Service{ObjectMeta: meta(annotations)},
+			allowed: true,
+		},
+	}
+
+	whsvr := &WebhookServer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response := whsvr.mutate(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Kind:   metav1.GroupVersionKind{Kind: tt.kind},
+					Object: runtime.RawExtension{Raw: raw},
+				},
+			})
+			if response.Allowed != tt.allowed {
+				t.Fatalf("mutate() allowed = %v, want %v: %v", response.Allowed, tt.allowed, response.Result)
+			}
+			if tt.wantPath == "" {
+				if response.Patch != nil {
+					t.Errorf("mutate() patch = %s, want none", response.Patch)
+				}
+				return
+			}
+			patch := decodePatch(t, response.Patch)
+			if len(patch) != 1 || patch[0]["path"] != tt.wantPath {
+				t.Errorf("mutate() patch = %s, want path %s", response.Patch, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	nsmv1 "github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateResource validates raw NSM custom resource of passed kind, ok is false if the kind is not an NSM resource.
+// Namespace of the request is used if the resource has no namespace set.
+func validateResource(kind, namespace string, raw []byte) (errs field.ErrorList, ok bool, err error) {
+	switch kind {
+	case "NetworkService":
+		var ns nsmv1.NetworkService
+		if err = json.Unmarshal(raw, &ns); err != nil {
+			return nil, true, err
+		}
+		if ns.Namespace == "" {
+			ns.Namespace = namespace
+		}
+		return ns.Validate(), true, nil
+	case "NetworkServiceEndpoint":
+		var nse nsmv1.NetworkServiceEndpoint
+		if err = json.Unmarshal(raw, &nse); err != nil {
+			return nil, true, err
+		}
+		return nse.Validate(), true, nil
+	case "NetworkServiceManager":
+		var nsm nsmv1.NetworkServiceManager
+		if err = json.Unmarshal(raw, &nsm); err != nil {
+			return nil, true, err
+		}
+		return nsm.Validate(), true, nil
+	}
+	return nil, false, nil
+}
+
+func (whsvr *WebhookServer) validate(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	req := ar.Request
+
+	logrus.Infof("Validating AdmissionReview for Kind=%v, Name=%v UID=%v Operation=%v UserInfo=%v",
+		req.Kind, req.Name, req.UID, req.Operation, req.UserInfo)
+
+	if req.Operation == v1beta1.Delete {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	errs, ok, err := validateResource(req.Kind.Kind, req.Namespace, req.Object.Raw)
+	if err != nil {
+		logrus.Errorf("Could not unmarshal raw object: %v", err)
+		return &v1beta1.AdmissionResponse{
+			Result: &metav1.Status{
+				Message: err.Error(),
+			},
+		}
+	}
+	if !ok || len(errs) == 0 {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	logrus.Infof("%s %s is rejected: %v", req.Kind.Kind, req.Name, errs.ToAggregate())
+	return &v1beta1.AdmissionResponse{
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: errs.ToAggregate().Error(),
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
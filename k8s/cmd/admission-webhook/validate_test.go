@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	nsmv1 "github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidate(t *testing.T) {
+	routes := []*nsmv1.Destination{{DestinationSelector: map[string]string{"app": "icmp-responder"}}}
+
+	tests := []struct {
+		name      string
+		kind      string
+		operation v1beta1.Operation
+		namespace string
+		object    runtime.Object
+		allowed   bool
+		message   string
+	}{
+		{
+			name: "NetworkService",
+			kind: "NetworkService",
+			object: &nsmv1.NetworkService{Spec: nsmv1.NetworkServiceSpec{
+				Payload: "IP",
+				Matches: []*nsmv1.Match{{Routes: routes}},
+			}},
+			allowed: true,
+		},
+		{
+			name: "NetworkServiceEmptyRoutes",
+			kind: "NetworkService",
+			object: &nsmv1.NetworkService{Spec: nsmv1.NetworkServiceSpec{
+				Payload: "IP",
+				Matches: []*nsmv1.Match{{}},
+			}},
+			allowed: false,
+			message: "spec.matches[0].route: Required value",
+		},
+		{
+			name: "NetworkServiceDuplicateMatches",
+			kind: "NetworkService",
+			object: &nsmv1.NetworkService{Spec: nsmv1.NetworkServiceSpec{
+				Payload: "IP",
+				Matches: []*nsmv1.Match{{Routes: routes}, {Routes: routes}},
+			}},
+			allowed: false,
+			message: "spec.matches[1].sourceSelector: Duplicate value",
+		},
+		{
+			name:      "NetworkServiceChainToItself",
+			kind:      "NetworkService",
+			namespace: "team-a",
+			object: &nsmv1.NetworkService{
+				ObjectMeta: metav1.ObjectMeta{Name: "secure-intranet"},
+				Spec: nsmv1.NetworkServiceSpec{
+					Payload: "IP",
+					Matches: []*nsmv1.Match{{Routes: routes}},
+					Chain:   []*nsmv1.ChainHop{{NetworkService: "secure-intranet.team-a"}},
+				},
+			},
+			allowed: false,
+			message: "spec.chain[0].networkService: Invalid value",
+		},
+		{
+			name:    "NetworkServiceEndpoint",
+			kind:    "NetworkServiceEndpoint",
+			object:  &nsmv1.NetworkServiceEndpoint{},
+			allowed: false,
+			message: "spec.networkservicename: Required value",
+		},
+		{
+			name:    "NetworkServiceManager",
+			kind:    "NetworkServiceManager",
+			object:  &nsmv1.NetworkServiceManager{Status: nsmv1.NetworkServiceManagerStatus{URL: "nsm-1"}},
+			allowed: false,
+			message: "status.url: Invalid value",
+		},
+		{
+			name:      "Delete",
+			kind:      "NetworkServiceManager",
+			operation: v1beta1.Delete,
+			object:    &nsmv1.NetworkServiceManager{},
+			allowed:   true,
+		},
+		{
+			name:    "Pod",
+			kind:    "Pod",
+			object:  &corev1.Pod{},
+			allowed: true,
+		},
+	}
+
+	whsvr := &WebhookServer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			operation := tt.operation
+			if operation == "" {
+				operation = v1beta1.Create
+			}
+			response := whsvr.validate(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Kind: tt.kind},
+					Operation: operation,
+					Namespace: tt.namespace,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			if response.Allowed != tt.allowed {
+				t.Fatalf("validate() allowed = %v, want %v: %v", response.Allowed, tt.allowed, response.Result)
+			}
+			if tt.message != "" && !strings.Contains(response.Result.Message, tt.message) {
+				t.Errorf("validate() message = %q, want it to contain %q", response.Result.Message, tt.message)
+			}
+		})
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
 	"github.com/sirupsen/logrus"
@@ -38,7 +37,7 @@ func monitorCrossConnects(address string, continuousMonitor bool) {
 	dataplaneClient := crossconnect.NewMonitorCrossConnectClient(conn)
 
 	// Looping indefinetly or until grpc returns an error indicating the other end closed connection.
-	stream, err := dataplaneClient.MonitorCrossConnects(context.Background(), &empty.Empty{})
+	stream, err := dataplaneClient.MonitorCrossConnects(context.Background(), &crossconnect.MonitorRequest{})
 
 	if err != nil {
 		logrus.Warningf("Error: %+v.", err)
@@ -93,7 +92,7 @@ func lookForNSMServers() {
 	}
 
 	for !closing {
-		result, err := nsmClientSet.Networkservicemesh().NetworkServiceManagers("default").List(metav1.ListOptions{})
+		result, err := nsmClientSet.Networkservicemesh().NetworkServiceManagers(metav1.NamespaceNone).List(metav1.ListOptions{})
 		if err != nil {
 			logrus.Fatalln("Unable to find NSMs", err)
 		}
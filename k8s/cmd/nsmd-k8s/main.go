@@ -15,12 +15,14 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
 func main() {
+	tools.InitLogging()
 	// Capture signals to cleanup before exiting
 	c := make(chan os.Signal, 1)
 	signal.Notify(c,
@@ -41,7 +43,8 @@ func main() {
 	if !ok {
 		logrus.Fatalf("You must set env variable NODE_NAME to match the name of your Node.  See https://kubernetes.io/docs/tasks/inject-data-application/environment-variable-expose-pod-information/")
 	}
-	logrus.Println("Starting NSMD Kubernetes on " + address + " with NsmName " + nsmName)
+	namespace := registryserver.GetNamespace()
+	logrus.Println("Starting NSMD Kubernetes on " + address + " with NsmName " + nsmName + " in namespace " + namespace)
 
 	var kubeconfig *string
 	if home := homedir.HomeDir(); home != "" {
@@ -68,7 +71,12 @@ func main() {
 		logrus.Fatalln(err)
 	}
 
-	server := registryserver.New(nsmClientSet, nsmName)
+	kubeClientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logrus.Fatalln("Unable to create Kubernetes clientset", err)
+	}
+
+	server, stop := registryserver.New(nsmClientSet, kubeClientSet, nsmName, namespace)
 
 	clusterInfoService, err := registryserver.NewK8sClusterInfoService(config)
 	if err != nil {
@@ -79,6 +87,7 @@ func main() {
 
 	logrus.Print("nsmd-k8s initialized and waiting for connection")
 	err = server.Serve(listener)
+	stop()
 	logrus.Fatalln(err)
 	<-c
 }
@@ -14,7 +14,6 @@ const (
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type NetworkService struct {
 	metaV1.TypeMeta   `json:",inline"`
@@ -25,8 +24,15 @@ type NetworkService struct {
 }
 
 type NetworkServiceSpec struct {
-	Payload string   `json:"payload"`
-	Matches []*Match `json:"matches"`
+	Payload string      `json:"payload"`
+	Matches []*Match    `json:"matches"`
+	Chain   []*ChainHop `json:"chain,omitempty"`
+}
+
+// ChainHop is a hop of service function chain, connection is passed through the hops in the listed order.
+type ChainHop struct {
+	NetworkService string            `json:"networkService"`
+	Labels         map[string]string `json:"labels,omitempty"`
 }
 
 type Match struct {
@@ -39,7 +45,56 @@ type Destination struct {
 	Weight              uint32            `json:"weight,omitempty"`
 }
 
-type NetworkServiceStatus struct{}
+// NetworkServiceStatus is an observed state of the network service endpoints and connections,
+// and how many endpoints are currently matched by each route of the network service.
+type NetworkServiceStatus struct {
+	Endpoints      int                        `json:"endpoints"`
+	EndpointsByNsm map[string]int             `json:"endpointsByNsm,omitempty"`
+	Connections    uint32                     `json:"connections"`
+	Conditions     []*NetworkServiceCondition `json:"conditions,omitempty"`
+	Matches        []*MatchStatus             `json:"matches,omitempty"`
+}
+
+// NetworkServiceConditionType is a type of the network service condition.
+type NetworkServiceConditionType string
+
+const (
+	// NoEndpoints is True when there are no endpoints registered for the network service
+	NoEndpoints NetworkServiceConditionType = "NoEndpoints"
+	// UnmatchedRoutes is True when some of the routes do not match any registered endpoint
+	UnmatchedRoutes NetworkServiceConditionType = "UnmatchedRoutes"
+)
+
+// ConditionStatus is a status of the network service condition.
+type ConditionStatus string
+
+const (
+	// ConditionTrue means the condition is observed
+	ConditionTrue ConditionStatus = "True"
+	// ConditionFalse means the condition is not observed
+	ConditionFalse ConditionStatus = "False"
+)
+
+// NetworkServiceCondition is an observed condition of the network service.
+type NetworkServiceCondition struct {
+	Type               NetworkServiceConditionType `json:"type"`
+	Status             ConditionStatus             `json:"status"`
+	LastTransitionTime metaV1.Time                 `json:"lastTransitionTime,omitempty"`
+	Reason             string                      `json:"reason,omitempty"`
+	Message            string                      `json:"message,omitempty"`
+}
+
+// MatchStatus is a status of a Match, routes are listed in the same order as in the spec.
+type MatchStatus struct {
+	SourceSelector map[string]string `json:"sourceSelector,omitempty"`
+	Routes         []*RouteStatus    `json:"routes,omitempty"`
+}
+
+// RouteStatus is a number of endpoints matched by a route destination selector.
+type RouteStatus struct {
+	DestinationSelector map[string]string `json:"destinationSelector,omitempty"`
+	Endpoints           int               `json:"endpoints"`
+}
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type NetworkServiceList struct {
@@ -59,13 +114,20 @@ type NetworkServiceEndpoint struct {
 	Status NetworkServiceEndpointStatus `json:"status"`
 }
 
+// NetworkServiceEndpointSpec keeps endpoint labels and metadata as is, they are not restricted by
+// Kubernetes label syntax.
 type NetworkServiceEndpointSpec struct {
-	NetworkServiceName string `json:"networkservicename"`
-	NsmName            string `json:"nsmname"`
+	NetworkServiceName string            `json:"networkservicename"`
+	NsmName            string            `json:"nsmname"`
+	Capacity           uint32            `json:"capacity,omitempty"`
+	Payload            string            `json:"payload,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
 }
 
 type NetworkServiceEndpointStatus struct {
-	State State `json:"state"`
+	State State  `json:"state"`
+	Load  uint32 `json:"load,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
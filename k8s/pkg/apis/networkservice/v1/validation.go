@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var validStates = []string{OFFLINE, RUNNING, PAUSED, ERROR}
+
+// Validate checks the network service is well formed, a list of found problems is returned.
+// Selectors referencing labels no endpoint has are not an error, they are reported by Status.
+func (ns *NetworkService) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if ns.Spec.Payload == "" {
+		errs = append(errs, field.Required(specPath.Child("payload"), "network service payload should be specified"))
+	}
+
+	matchesPath := specPath.Child("matches")
+	for i, match := range ns.Spec.Matches {
+		matchPath := matchesPath.Index(i)
+		if match == nil {
+			errs = append(errs, field.Required(matchPath, "match should not be empty"))
+			continue
+		}
+		errs = append(errs, validateLabels(match.SourceSelector, matchPath.Child("sourceSelector"))...)
+		for j := 0; j < i; j++ {
+			if other := ns.Spec.Matches[j]; other != nil && selectorsEqual(other.SourceSelector, match.SourceSelector) {
+				errs = append(errs, field.Duplicate(matchPath.Child("sourceSelector"), match.SourceSelector))
+				break
+			}
+		}
+		errs = append(errs, validateRoutes(match.Routes, matchPath.Child("route"))...)
+	}
+
+	chainPath := specPath.Child("chain")
+	for i, hop := range ns.Spec.Chain {
+		hopPath := chainPath.Index(i)
+		if hop == nil || hop.NetworkService == "" {
+			errs = append(errs, field.Required(hopPath.Child("networkService"), "chain hop should reference a network service"))
+			continue
+		}
+		if ns.isSelfReference(hop.NetworkService) {
+			errs = append(errs, field.Invalid(hopPath.Child("networkService"), hop.NetworkService, "chain hop should not reference the network service itself"))
+		}
+		errs = append(errs, validateLabels(hop.Labels, hopPath.Child("labels"))...)
+	}
+	return errs
+}
+
+// isSelfReference checks if a chain hop references the network service itself, either by name or by the name
+// qualified with the network service namespace.
+func (ns *NetworkService) isSelfReference(networkService string) bool {
+	return networkService == ns.Name || (ns.Namespace != "" && networkService == ns.Name+"."+ns.Namespace)
+}
+
+// validateLabels checks labels and selectors with NSM label rules. Endpoint labels are not restricted by
+// Kubernetes label syntax, so a key should only be non empty and have no spaces, keys and values should be printable.
+func validateLabels(labels map[string]string, labelsPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for key, value := range labels {
+		if key == "" {
+			errs = append(errs, field.Invalid(labelsPath, key, "label key should not be empty"))
+		} else if strings.IndexFunc(key, func(r rune) bool { return unicode.IsSpace(r) || !unicode.IsPrint(r) }) >= 0 {
+			errs = append(errs, field.Invalid(labelsPath, key, "label key should not contain spaces or non printable characters"))
+		}
+		if strings.IndexFunc(value, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+			errs = append(errs, field.Invalid(labelsPath.Key(key), value, "label value should not contain non printable characters"))
+		}
+	}
+	return errs
+}
+
+func validateRoutes(routes []*Destination, routesPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(routes) == 0 {
+		errs = append(errs, field.Required(routesPath, "match should have at least one route"))
+	}
+	for i, route := range routes {
+		routePath := routesPath.Index(i)
+		if route == nil {
+			errs = append(errs, field.Required(routePath, "route should not be empty"))
+			continue
+		}
+		errs = append(errs, validateLabels(route.DestinationSelector, routePath.Child("destinationSelector"))...)
+		for j := 0; j < i; j++ {
+			if other := routes[j]; other != nil && selectorsEqual(other.DestinationSelector, route.DestinationSelector) {
+				errs = append(errs, field.Duplicate(routePath.Child("destinationSelector"), route.DestinationSelector))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// Validate checks the network service endpoint is well formed, a list of found problems is returned.
+func (nse *NetworkServiceEndpoint) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if nse.Spec.NetworkServiceName == "" {
+		errs = append(errs, field.Required(specPath.Child("networkservicename"), "endpoint should reference a network service"))
+	}
+	if nse.Spec.NsmName == "" {
+		errs = append(errs, field.Required(specPath.Child("nsmname"), "endpoint should reference a network service manager"))
+	}
+	errs = append(errs, validateState(nse.Status.State, field.NewPath("status", "state"))...)
+	return errs
+}
+
+// Validate checks the network service manager is well formed, a list of found problems is returned.
+func (nsm *NetworkServiceManager) Validate() field.ErrorList {
+	var errs field.ErrorList
+	statusPath := field.NewPath("status")
+	if nsm.Status.URL == "" {
+		errs = append(errs, field.Required(statusPath.Child("url"), "network service manager url should be specified"))
+	} else if _, _, err := net.SplitHostPort(nsm.Status.URL); err != nil {
+		errs = append(errs, field.Invalid(statusPath.Child("url"), nsm.Status.URL, err.Error()))
+	}
+	errs = append(errs, validateState(nsm.Status.State, statusPath.Child("state"))...)
+	return errs
+}
+
+func validateState(state State, statePath *field.Path) field.ErrorList {
+	if state == "" {
+		return nil
+	}
+	for _, valid := range validStates {
+		if string(state) == valid {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(statePath, state, validStates)}
+}
+
+func selectorsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validNetworkService() *NetworkService {
+	return &NetworkService{
+		ObjectMeta: metaV1.ObjectMeta{Name: "secure-intranet-connectivity"},
+		Spec: NetworkServiceSpec{
+			Payload: "IP",
+			Matches: []*Match{
+				{
+					SourceSelector: map[string]string{"app": "firewall"},
+					Routes: []*Destination{
+						{DestinationSelector: map[string]string{"app": "vpn-gateway"}},
+					},
+				},
+				{
+					Routes: []*Destination{
+						{DestinationSelector: map[string]string{"app": "firewall"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func errorFields(errs field.ErrorList) []string {
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, err.Type.String()+" "+err.Field)
+	}
+	return fields
+}
+
+func checkErrors(t *testing.T, errs field.ErrorList, want []string) {
+	got := errorFields(errs)
+	if len(got) != len(want) {
+		t.Fatalf("Validate() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Validate() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNetworkServiceValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(ns *NetworkService)
+		want   []string
+	}{
+		{
+			name:   "Valid",
+			modify: func(ns *NetworkService) {},
+		},
+		{
+			name: "NoPayload",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Payload = ""
+			},
+			want: []string{"Required value spec.payload"},
+		},
+		{
+			name: "EmptyRoutes",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[1].Routes = nil
+			},
+			want: []string{"Required value spec.matches[1].route"},
+		},
+		{
+			name: "NilMatch",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[0] = nil
+			},
+			want: []string{"Required value spec.matches[0]"},
+		},
+		{
+			name: "DuplicateMatch",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[1].SourceSelector = map[string]string{"app": "firewall"}
+			},
+			want: []string{"Duplicate value spec.matches[1].sourceSelector"},
+		},
+		{
+			name: "DuplicateRoute",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[0].Routes = append(ns.Spec.Matches[0].Routes, &Destination{
+					DestinationSelector: map[string]string{"app": "vpn-gateway"},
+				})
+			},
+			want: []string{"Duplicate value spec.matches[0].route[1].destinationSelector"},
+		},
+		{
+			name: "InvalidSelector",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[0].Routes[0].DestinationSelector = map[string]string{"vpn gateway": "true"}
+			},
+			want: []string{"Invalid value spec.matches[0].route[0].destinationSelector"},
+		},
+		{
+			name: "EmptySelectorKey",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Matches[0].SourceSelector = map[string]string{"": "firewall"}
+			},
+			want: []string{"Invalid value spec.matches[0].sourceSelector"},
+		},
+		{
+			name: "InvalidSelectorValue",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Chain = []*ChainHop{{NetworkService: "firewall", Labels: map[string]string{"app": "fire\nwall"}}}
+			},
+			want: []string{"Invalid value spec.chain[0].labels[app]"},
+		},
+		{
+			name: "NsmLabels",
+			modify: func(ns *NetworkService) {
+				// Labels which are not valid Kubernetes labels are valid NSM labels
+				ns.Spec.Matches[0].SourceSelector = map[string]string{"app.kubernetes.io/name/role": "vpn gateway"}
+				ns.Spec.Matches[0].Routes[0].DestinationSelector = map[string]string{"_region": strings.Repeat("eu", 40)}
+			},
+		},
+		{
+			name: "ChainHopWithoutService",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Chain = []*ChainHop{{Labels: map[string]string{"app": "firewall"}}}
+			},
+			want: []string{"Required value spec.chain[0].networkService"},
+		},
+		{
+			name: "ChainHopToItself",
+			modify: func(ns *NetworkService) {
+				ns.Spec.Chain = []*ChainHop{{NetworkService: ns.Name}}
+			},
+			want: []string{"Invalid value spec.chain[0].networkService"},
+		},
+		{
+			name: "ChainHopToItselfQualified",
+			modify: func(ns *NetworkService) {
+				ns.Namespace = "team-a"
+				ns.Spec.Chain = []*ChainHop{{NetworkService: ns.Name + ".team-a"}}
+			},
+			want: []string{"Invalid value spec.chain[0].networkService"},
+		},
+		{
+			name: "ChainHopToOtherNamespace",
+			modify: func(ns *NetworkService) {
+				ns.Namespace = "team-a"
+				ns.Spec.Chain = []*ChainHop{{NetworkService: ns.Name + ".team-b"}}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ns := validNetworkService()
+			test.modify(ns)
+			checkErrors(t, ns.Validate(), test.want)
+		})
+	}
+}
+
+func TestNetworkServiceEndpointValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		nse  *NetworkServiceEndpoint
+		want []string
+	}{
+		{
+			name: "Valid",
+			nse: &NetworkServiceEndpoint{
+				Spec:   NetworkServiceEndpointSpec{NetworkServiceName: "icmp-responder", NsmName: "nsm-1"},
+				Status: NetworkServiceEndpointStatus{State: RUNNING},
+			},
+		},
+		{
+			name: "Empty",
+			nse:  &NetworkServiceEndpoint{},
+			want: []string{"Required value spec.networkservicename", "Required value spec.nsmname"},
+		},
+		{
+			name: "InvalidState",
+			nse: &NetworkServiceEndpoint{
+				Spec:   NetworkServiceEndpointSpec{NetworkServiceName: "icmp-responder", NsmName: "nsm-1"},
+				Status: NetworkServiceEndpointStatus{State: "UNKNOWN"},
+			},
+			want: []string{"Unsupported value status.state"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkErrors(t, test.nse.Validate(), test.want)
+		})
+	}
+}
+
+func TestNetworkServiceManagerValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		nsm  *NetworkServiceManager
+		want []string
+	}{
+		{
+			name: "Valid",
+			nsm:  &NetworkServiceManager{Status: NetworkServiceManagerStatus{URL: "10.0.0.1:5001", State: RUNNING}},
+		},
+		{
+			name: "NoURL",
+			nsm:  &NetworkServiceManager{},
+			want: []string{"Required value status.url"},
+		},
+		{
+			name: "InvalidURL",
+			nsm:  &NetworkServiceManager{Status: NetworkServiceManagerStatus{URL: "10.0.0.1"}},
+			want: []string{"Invalid value status.url"},
+		},
+		{
+			name: "InvalidState",
+			nsm:  &NetworkServiceManager{Status: NetworkServiceManagerStatus{URL: "10.0.0.1:5001", State: "UNKNOWN"}},
+			want: []string{"Unsupported value status.state"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkErrors(t, test.nsm.Validate(), test.want)
+		})
+	}
+}
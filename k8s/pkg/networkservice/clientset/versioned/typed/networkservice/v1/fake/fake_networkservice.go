@@ -99,6 +99,18 @@ func (c *FakeNetworkServices) Update(networkService *networkservicev1.NetworkSer
 	return obj.(*networkservicev1.NetworkService), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNetworkServices) UpdateStatus(networkService *networkservicev1.NetworkService) (*networkservicev1.NetworkService, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(networkservicesResource, "status", c.ns, networkService), &networkservicev1.NetworkService{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*networkservicev1.NetworkService), err
+}
+
 // Delete takes name of the networkService and deletes it. Returns an error if one occurs.
 func (c *FakeNetworkServices) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
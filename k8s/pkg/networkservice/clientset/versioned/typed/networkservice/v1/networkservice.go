@@ -38,6 +38,7 @@ type NetworkServicesGetter interface {
 type NetworkServiceInterface interface {
 	Create(*v1.NetworkService) (*v1.NetworkService, error)
 	Update(*v1.NetworkService) (*v1.NetworkService, error)
+	UpdateStatus(*v1.NetworkService) (*v1.NetworkService, error)
 	Delete(name string, options *metav1.DeleteOptions) error
 	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
 	Get(name string, options metav1.GetOptions) (*v1.NetworkService, error)
@@ -131,6 +132,22 @@ func (c *networkServices) Update(networkService *v1.NetworkService) (result *v1.
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *networkServices) UpdateStatus(networkService *v1.NetworkService) (result *v1.NetworkService, err error) {
+	result = &v1.NetworkService{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("networkservices").
+		Name(networkService.Name).
+		SubResource("status").
+		Body(networkService).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the networkService and deletes it. Returns an error if one occurs.
 func (c *networkServices) Delete(name string, options *metav1.DeleteOptions) error {
 	return c.client.Delete().
@@ -9,31 +9,34 @@ import (
 
 type discoveryService struct {
 	cache RegistryCache
+	names *namespaces
 }
 
-func newDiscoveryService(cache RegistryCache) *discoveryService {
+func newDiscoveryService(cache RegistryCache, names *namespaces) *discoveryService {
 	return &discoveryService{
 		cache: cache,
+		names: names,
 	}
 }
 
 func (d *discoveryService) FindNetworkService(ctx context.Context, request *registry.FindNetworkServiceRequest) (*registry.FindNetworkServiceResponse, error) {
 	st := time.Now()
-	service, err := d.cache.GetNetworkService(request.NetworkServiceName)
+	name, namespace := d.names.split(request.NetworkServiceName)
+	service, err := d.cache.GetNetworkService(namespace, name)
 	if err != nil {
 		return nil, err
 	}
 	payload := service.Spec.Payload
 
 	t1 := time.Now()
-	endpointList := d.cache.GetEndpointsByNs(request.NetworkServiceName)
+	endpointList := d.cache.GetEndpointsByNs(namespace, name)
 	logrus.Infof("NSE found %d, retrieve time: %v", len(endpointList), time.Since(t1))
 	NSEs := make([]*registry.NetworkServiceEndpoint, len(endpointList))
 
 	NSMs := make(map[string]*registry.NetworkServiceManager)
 
 	for i, endpoint := range endpointList {
-		NSEs[i] = mapNseFromCustomResource(endpoint, payload)
+		NSEs[i] = mapNseFromCustomResource(endpoint, payload, d.names)
 		if nsm := d.cache.GetNetworkServiceManager(endpoint.Spec.NsmName); nsm != nil {
 			NSMs[endpoint.Spec.NsmName] = mapNsmFromCustomResource(nsm)
 		}
@@ -59,12 +62,22 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 		matches = append(matches, match)
 	}
 
+	var chain []*registry.ChainHop
+	for _, hop := range service.Spec.Chain {
+		chain = append(chain, &registry.ChainHop{
+			// Chain hops are resolved in the namespace of the chained service
+			NetworkService: d.names.relative(hop.NetworkService, service.Namespace),
+			Labels:         hop.Labels,
+		})
+	}
+
 	response := &registry.FindNetworkServiceResponse{
 		Payload: payload,
 		NetworkService: &registry.NetworkService{
-			Name:    service.ObjectMeta.Name,
+			Name:    d.names.join(service.Name, service.Namespace),
 			Payload: service.Spec.Payload,
 			Matches: matches,
+			Chain:   chain,
 		},
 		NetworkServiceManagers:  NSMs,
 		NetworkServiceEndpoints: NSEs,
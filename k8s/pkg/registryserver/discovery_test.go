@@ -0,0 +1,213 @@
+package registryserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testRegistryCache is an in memory RegistryCache, objects are keyed by namespace/name.
+type testRegistryCache struct {
+	services  map[string]*v1.NetworkService
+	endpoints map[string]*v1.NetworkServiceEndpoint
+	managers  map[string]*v1.NetworkServiceManager
+	generated int
+}
+
+func newTestRegistryCache() *testRegistryCache {
+	return &testRegistryCache{
+		services:  map[string]*v1.NetworkService{},
+		endpoints: map[string]*v1.NetworkServiceEndpoint{},
+		managers:  map[string]*v1.NetworkServiceManager{},
+	}
+}
+
+func (c *testRegistryCache) AddNetworkService(ns *v1.NetworkService) (*v1.NetworkService, error) {
+	key := ns.Namespace + "/" + ns.Name
+	if existing, ok := c.services[key]; ok {
+		return existing, nil
+	}
+	c.services[key] = ns
+	return ns, nil
+}
+
+func (c *testRegistryCache) GetNetworkService(namespace, name string) (*v1.NetworkService, error) {
+	if ns, ok := c.services[namespace+"/"+name]; ok {
+		return ns, nil
+	}
+	return nil, fmt.Errorf("no NetworkService with name: %v in namespace: %v", name, namespace)
+}
+
+func (c *testRegistryCache) GetNetworkServices() []*v1.NetworkService {
+	var rv []*v1.NetworkService
+	for _, ns := range c.services {
+		rv = append(rv, ns)
+	}
+	return rv
+}
+
+func (c *testRegistryCache) UpdateNetworkServiceStatus(ns *v1.NetworkService) (*v1.NetworkService, error) {
+	c.services[ns.Namespace+"/"+ns.Name] = ns
+	return ns, nil
+}
+
+func (c *testRegistryCache) AddNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error) {
+	c.managers[nsm.Name] = nsm
+	return nsm, nil
+}
+
+func (c *testRegistryCache) UpdateNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error) {
+	c.managers[nsm.Name] = nsm
+	return nsm, nil
+}
+
+func (c *testRegistryCache) GetNetworkServiceManager(name string) *v1.NetworkServiceManager {
+	return c.managers[name]
+}
+
+func (c *testRegistryCache) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	nse = nse.DeepCopy()
+	if nse.Name == "" {
+		c.generated++
+		nse.Name = fmt.Sprintf("%s-%d", nse.GenerateName, c.generated)
+	}
+	c.endpoints[nse.Namespace+"/"+nse.Name] = nse
+	return nse, nil
+}
+
+func (c *testRegistryCache) UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	c.endpoints[nse.Namespace+"/"+nse.Name] = nse
+	return nse, nil
+}
+
+func (c *testRegistryCache) GetNetworkServiceEndpoint(namespace, endpointName string) *v1.NetworkServiceEndpoint {
+	return c.endpoints[namespace+"/"+endpointName]
+}
+
+func (c *testRegistryCache) DeleteNetworkServiceEndpoint(namespace, endpointName string) error {
+	delete(c.endpoints, namespace+"/"+endpointName)
+	return nil
+}
+
+func (c *testRegistryCache) GetEndpointsByNs(namespace, networkServiceName string) []*v1.NetworkServiceEndpoint {
+	var rv []*v1.NetworkServiceEndpoint
+	for _, nse := range c.endpoints {
+		if nse.Namespace == namespace && nse.Spec.NetworkServiceName == networkServiceName {
+			rv = append(rv, nse)
+		}
+	}
+	return rv
+}
+
+func (c *testRegistryCache) GetEndpointsByNsm(nsmName string) []*v1.NetworkServiceEndpoint {
+	var rv []*v1.NetworkServiceEndpoint
+	for _, nse := range c.endpoints {
+		if nse.Spec.NsmName == nsmName {
+			rv = append(rv, nse)
+		}
+	}
+	return rv
+}
+
+func (c *testRegistryCache) Start() error {
+	return nil
+}
+
+func (c *testRegistryCache) Stop() {
+}
+
+func registerTestNse(rs *nseRegistryService, networkService string, labels map[string]string) *registry.NetworkServiceEndpoint {
+	registration, err := rs.RegisterNSE(context.Background(), &registry.NSERegistration{
+		NetworkService: &registry.NetworkService{
+			Name:    networkService,
+			Payload: "IP",
+		},
+		NetworkserviceEndpoint: &registry.NetworkServiceEndpoint{
+			Labels: labels,
+		},
+	})
+	Expect(err).To(BeNil())
+	return registration.GetNetworkserviceEndpoint()
+}
+
+func TestDiscoveryNamespaces(t *testing.T) {
+	RegisterTestingT(t)
+
+	cache := newTestRegistryCache()
+	names := newNamespaces("nsm-system", existingNamespaces("team-a", "team-b", "shared"))
+	nseRegistry := newNseRegistryService("nsm-1", cache, names)
+	discovery := newDiscoveryService(cache, names)
+
+	nseDefault := registerTestNse(nseRegistry, "secure-intranet", map[string]string{"team": "default"})
+	nseA := registerTestNse(nseRegistry, "secure-intranet.team-a", map[string]string{"team": "a"})
+	nseB := registerTestNse(nseRegistry, "secure-intranet.team-b", map[string]string{"team": "b"})
+
+	Expect(nseDefault.NetworkServiceName).To(Equal("secure-intranet"))
+	Expect(nseA.NetworkServiceName).To(Equal("secure-intranet.team-a"))
+	Expect(nseA.EndpointName).To(HaveSuffix(".team-a"))
+	Expect(cache.services).To(HaveKey("nsm-system/secure-intranet"))
+	Expect(cache.services).To(HaveKey("team-a/secure-intranet"))
+	Expect(cache.services).To(HaveKey("team-b/secure-intranet"))
+
+	for _, test := range []struct {
+		networkService string
+		endpoint       *registry.NetworkServiceEndpoint
+	}{
+		{"secure-intranet", nseDefault},
+		{"secure-intranet.nsm-system", nseDefault},
+		{"secure-intranet.team-a", nseA},
+		{"secure-intranet.team-b", nseB},
+	} {
+		response, err := discovery.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{
+			NetworkServiceName: test.networkService,
+		})
+		Expect(err).To(BeNil())
+		Expect(response.NetworkServiceEndpoints).To(HaveLen(1))
+		Expect(response.NetworkServiceEndpoints[0].EndpointName).To(Equal(test.endpoint.EndpointName))
+		Expect(response.NetworkServiceEndpoints[0].Labels).To(Equal(test.endpoint.Labels))
+	}
+
+	_, err := discovery.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{
+		NetworkServiceName: "secure-intranet.team-c",
+	})
+	Expect(err).NotTo(BeNil())
+
+	// Endpoints are removed by qualified names.
+	_, err = nseRegistry.RemoveNSE(context.Background(), &registry.RemoveNSERequest{EndpointName: nseA.EndpointName})
+	Expect(err).To(BeNil())
+	Expect(cache.GetEndpointsByNs("team-a", "secure-intranet")).To(BeEmpty())
+	Expect(cache.GetEndpointsByNs("team-b", "secure-intranet")).To(HaveLen(1))
+}
+
+func TestDiscoveryChainNamespace(t *testing.T) {
+	RegisterTestingT(t)
+
+	cache := newTestRegistryCache()
+	names := newNamespaces("nsm-system", existingNamespaces("team-a", "team-b", "shared"))
+	discovery := newDiscoveryService(cache, names)
+
+	_, _ = cache.AddNetworkService(&v1.NetworkService{
+		ObjectMeta: metav1.ObjectMeta{Name: "secure-intranet", Namespace: "team-a"},
+		Spec: v1.NetworkServiceSpec{
+			Payload: "IP",
+			Chain: []*v1.ChainHop{
+				{NetworkService: "firewall"},
+				{NetworkService: "vpn.shared"},
+			},
+		},
+	})
+
+	response, err := discovery.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{
+		NetworkServiceName: "secure-intranet.team-a",
+	})
+	Expect(err).To(BeNil())
+	Expect(response.NetworkService.Name).To(Equal("secure-intranet.team-a"))
+	Expect(response.NetworkService.Chain).To(HaveLen(2))
+	Expect(response.NetworkService.Chain[0].NetworkService).To(Equal("firewall.team-a"))
+	Expect(response.NetworkService.Chain[1].NetworkService).To(Equal("vpn.shared"))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"time"
 )
 
@@ -37,13 +38,73 @@ func mapNsmFromCustomResource(cr *v1.NetworkServiceManager) *registry.NetworkSer
 	}
 }
 
-func mapNseFromCustomResource(cr *v1.NetworkServiceEndpoint, payload string) *registry.NetworkServiceEndpoint {
+func mapNseToCustomResource(nse *registry.NetworkServiceEndpoint, networkServiceName, namespace, nsmName string) *v1.NetworkServiceEndpoint {
+	return &v1.NetworkServiceEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: networkServiceName,
+			Namespace:    namespace,
+			Labels:       nseObjectLabels(nse.GetLabels(), networkServiceName),
+		},
+		Spec: v1.NetworkServiceEndpointSpec{
+			NetworkServiceName: networkServiceName,
+			NsmName:            nsmName,
+			Capacity:           nse.GetCapacity(),
+			Payload:            nse.GetPayload(),
+			Labels:             nse.GetLabels(),
+			Metadata:           nse.GetMetadata(),
+		},
+		Status: v1.NetworkServiceEndpointStatus{
+			State: v1.RUNNING,
+		},
+	}
+}
+
+// mapNseFromCustomResource maps endpoint custom resource, payload is used if the endpoint has no own payload.
+// Endpoint and network service names are qualified with the endpoint namespace.
+func mapNseFromCustomResource(cr *v1.NetworkServiceEndpoint, payload string, names *namespaces) *registry.NetworkServiceEndpoint {
+	if cr.Spec.Payload != "" {
+		payload = cr.Spec.Payload
+	}
 	return &registry.NetworkServiceEndpoint{
-		EndpointName:              cr.Name,
-		NetworkServiceName:        cr.Spec.NetworkServiceName,
+		EndpointName:              names.join(cr.Name, cr.Namespace),
+		NetworkServiceName:        names.join(cr.Spec.NetworkServiceName, cr.Namespace),
 		NetworkServiceManagerName: cr.Spec.NsmName,
 		Payload:                   payload,
-		Labels:                    cr.ObjectMeta.Labels,
+		Labels:                    nseLabels(cr),
+		Metadata:                  cr.Spec.Metadata,
 		State:                     string(cr.Status.State),
+		Capacity:                  cr.Spec.Capacity,
+		Load:                      cr.Status.Load,
+	}
+}
+
+// nseLabels returns endpoint labels, object labels are used for endpoints registered before labels were kept in spec.
+func nseLabels(cr *v1.NetworkServiceEndpoint) map[string]string {
+	if cr.Spec.Labels != nil {
+		return cr.Spec.Labels
+	}
+	var rv map[string]string
+	for key, value := range cr.ObjectMeta.Labels {
+		if key == NetworkServiceNameLabelKey {
+			continue
+		}
+		if rv == nil {
+			rv = map[string]string{}
+		}
+		rv[key] = value
+	}
+	return rv
+}
+
+// nseObjectLabels returns Kubernetes object labels of the endpoint, to be able to select endpoints with kubectl.
+// Labels which are not valid Kubernetes labels are kept in spec only.
+func nseObjectLabels(labels map[string]string, networkServiceName string) map[string]string {
+	rv := map[string]string{}
+	for key, value := range labels {
+		if len(validation.IsQualifiedName(key)) == 0 && len(validation.IsValidLabelValue(value)) == 0 {
+			rv[key] = value
+		}
 	}
+	rv[NetworkServiceNameLabelKey] = networkServiceName
+	return rv
 }
@@ -0,0 +1,105 @@
+package registryserver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testNamespaces = newNamespaces(DefaultNamespace, nil)
+
+func TestNseCustomResourceRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	nse := &registry.NetworkServiceEndpoint{
+		NetworkServiceName:        "vpn",
+		NetworkServiceManagerName: "nsm-1",
+		Payload:                   "Ethernet",
+		Labels: map[string]string{
+			"app":    "vpn-gateway",
+			"url":    "https://vpn.example.com:8443/path?query=value",
+			"subnet": "10.20.0.0/16",
+			"long":   "a-label-value-which-is-longer-than-sixty-three-characters-allowed-by-kubernetes",
+		},
+		Metadata: map[string]string{
+			"description": "VPN gateway in zone A",
+			"owner":       "network team",
+		},
+		Capacity: 10,
+	}
+
+	cr := mapNseToCustomResource(nse, "vpn", DefaultNamespace, "nsm-1")
+
+	// The custom resource passes through the API server as JSON.
+	data, err := json.Marshal(cr)
+	Expect(err).To(BeNil())
+	stored := &v1.NetworkServiceEndpoint{}
+	Expect(json.Unmarshal(data, stored)).To(BeNil())
+	stored.Name = "vpn-abcde"
+
+	Expect(stored.ObjectMeta.Labels).To(Equal(map[string]string{
+		"app":                      "vpn-gateway",
+		NetworkServiceNameLabelKey: "vpn",
+	}))
+
+	result := mapNseFromCustomResource(stored, "IP", testNamespaces)
+
+	Expect(result).To(Equal(&registry.NetworkServiceEndpoint{
+		EndpointName:              "vpn-abcde",
+		NetworkServiceName:        nse.NetworkServiceName,
+		NetworkServiceManagerName: nse.NetworkServiceManagerName,
+		Payload:                   nse.Payload,
+		Labels:                    nse.Labels,
+		Metadata:                  nse.Metadata,
+		State:                     v1.RUNNING,
+		Capacity:                  nse.Capacity,
+	}))
+}
+
+func TestNseCustomResourceDefaults(t *testing.T) {
+	RegisterTestingT(t)
+
+	cr := mapNseToCustomResource(&registry.NetworkServiceEndpoint{}, "icmp-responder", DefaultNamespace, "nsm-1")
+	Expect(cr.ObjectMeta.Labels).To(Equal(map[string]string{NetworkServiceNameLabelKey: "icmp-responder"}))
+
+	// Network service payload is used if endpoint has no own one.
+	result := mapNseFromCustomResource(cr, "IP", testNamespaces)
+	Expect(result.Payload).To(Equal("IP"))
+	Expect(result.Labels).To(BeEmpty())
+}
+
+func TestNseCustomResourceLegacyLabels(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Endpoints registered before labels were kept in spec have them as object labels only.
+	cr := &v1.NetworkServiceEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "icmp-responder-abcde",
+			Labels: map[string]string{"app": "icmp", NetworkServiceNameLabelKey: "icmp-responder"},
+		},
+		Spec: v1.NetworkServiceEndpointSpec{
+			NetworkServiceName: "icmp-responder",
+			NsmName:            "nsm-1",
+		},
+	}
+
+	result := mapNseFromCustomResource(cr, "IP", testNamespaces)
+	Expect(result.Labels).To(Equal(map[string]string{"app": "icmp"}))
+}
+
+func TestNseCustomResourceNamespace(t *testing.T) {
+	RegisterTestingT(t)
+
+	cr := mapNseToCustomResource(&registry.NetworkServiceEndpoint{}, "secure-intranet", "team-a", "nsm-1")
+	Expect(cr.Namespace).To(Equal("team-a"))
+	Expect(cr.Spec.NetworkServiceName).To(Equal("secure-intranet"))
+
+	cr.Name = "secure-intranet-abcde"
+	result := mapNseFromCustomResource(cr, "IP", testNamespaces)
+	Expect(result.EndpointName).To(Equal("secure-intranet-abcde.team-a"))
+	Expect(result.NetworkServiceName).To(Equal("secure-intranet.team-a"))
+}
@@ -0,0 +1,90 @@
+package registryserver
+
+import (
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// NamespaceEnv is a namespace of network services referenced without a namespace
+	NamespaceEnv = "NSM_NAMESPACE"
+	// DefaultNamespace is used if NamespaceEnv is not set
+	DefaultNamespace = "default"
+)
+
+// GetNamespace returns a namespace of network services referenced without a namespace.
+func GetNamespace() string {
+	namespace := strings.TrimSpace(os.Getenv(NamespaceEnv))
+	if namespace == "" {
+		logrus.Infof("%s is not set, using %s namespace", NamespaceEnv, DefaultNamespace)
+		return DefaultNamespace
+	}
+	return namespace
+}
+
+// namespaces resolves network service and endpoint names to Kubernetes namespaced names.
+// A name could be qualified with a namespace as "name.namespace", names without namespace
+// belong to the default namespace. Names could contain dots too, so the last dot separates a namespace
+// only if the suffix is an existing namespace, "secure.intranet" is a name in the default namespace
+// unless "intranet" namespace exists.
+type namespaces struct {
+	defaultNamespace string
+	exists           func(namespace string) bool
+}
+
+// newNamespaces creates namespaces resolver, exists checks if a namespace other than the default one exists.
+func newNamespaces(defaultNamespace string, exists func(namespace string) bool) *namespaces {
+	return &namespaces{
+		defaultNamespace: defaultNamespace,
+		exists:           exists,
+	}
+}
+
+// split returns name and namespace of qualified name.
+func (n *namespaces) split(qualifiedName string) (name, namespace string) {
+	if i := strings.LastIndex(qualifiedName, "."); i > 0 && i < len(qualifiedName)-1 && n.isNamespace(qualifiedName[i+1:]) {
+		return qualifiedName[:i], qualifiedName[i+1:]
+	}
+	return qualifiedName, n.defaultNamespace
+}
+
+// join returns qualified name, names in the default namespace are not qualified.
+func (n *namespaces) join(name, namespace string) string {
+	if namespace == "" || namespace == n.defaultNamespace {
+		return name
+	}
+	return name + "." + namespace
+}
+
+// relative qualifies a name referenced from a resource in namespace, unqualified names are resolved to that namespace.
+func (n *namespaces) relative(qualifiedName, namespace string) string {
+	if name, qualifiedNamespace := n.split(qualifiedName); name != qualifiedName {
+		return n.join(name, qualifiedNamespace)
+	}
+	return n.join(qualifiedName, namespace)
+}
+
+func (n *namespaces) isNamespace(namespace string) bool {
+	return namespace == n.defaultNamespace || (n.exists != nil && n.exists(namespace))
+}
+
+// namespaceLister starts watching Kubernetes namespaces, returned function checks if a namespace exists.
+func namespaceLister(clientset kubernetes.Interface) func(namespace string) bool {
+	stopCh := make(chan struct{})
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	lister := factory.Core().V1().Namespaces().Lister()
+	factory.Start(stopCh)
+	for informer, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			logrus.Errorf("Failed to sync %v, names are resolved in the default namespace only", informer)
+		}
+	}
+	return func(namespace string) bool {
+		_, err := lister.Get(namespace)
+		return err == nil
+	}
+}
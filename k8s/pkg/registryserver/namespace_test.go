@@ -0,0 +1,81 @@
+package registryserver
+
+import (
+	"testing"
+)
+
+func existingNamespaces(namespaces ...string) func(string) bool {
+	return func(namespace string) bool {
+		for _, ns := range namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestNamespacesSplit(t *testing.T) {
+	names := newNamespaces("nsm-system", existingNamespaces("team-a", "team-b", "shared"))
+	tests := []struct {
+		qualifiedName string
+		name          string
+		namespace     string
+	}{
+		{"secure-intranet", "secure-intranet", "nsm-system"},
+		{"secure-intranet.team-a", "secure-intranet", "team-a"},
+		{"vpn.gateway.team-b", "vpn.gateway", "team-b"},
+		{"secure-intranet.", "secure-intranet.", "nsm-system"},
+		{".team-a", ".team-a", "nsm-system"},
+		{"secure.intranet", "secure.intranet", "nsm-system"},
+		{"secure-intranet.nsm-system", "secure-intranet", "nsm-system"},
+	}
+	for _, test := range tests {
+		name, namespace := names.split(test.qualifiedName)
+		if name != test.name || namespace != test.namespace {
+			t.Errorf("split(%q) = %q, %q, want %q, %q", test.qualifiedName, name, namespace, test.name, test.namespace)
+		}
+	}
+}
+
+func TestNamespacesJoin(t *testing.T) {
+	names := newNamespaces("nsm-system", existingNamespaces("team-a", "team-b", "shared"))
+	tests := []struct {
+		name          string
+		namespace     string
+		qualifiedName string
+	}{
+		{"secure-intranet", "nsm-system", "secure-intranet"},
+		{"secure-intranet", "", "secure-intranet"},
+		{"secure-intranet", "team-a", "secure-intranet.team-a"},
+	}
+	for _, test := range tests {
+		if qualifiedName := names.join(test.name, test.namespace); qualifiedName != test.qualifiedName {
+			t.Errorf("join(%q, %q) = %q, want %q", test.name, test.namespace, qualifiedName, test.qualifiedName)
+		}
+		if name, namespace := names.split(test.qualifiedName); name != test.name ||
+			(namespace != test.namespace && test.namespace != "") {
+			t.Errorf("split(%q) = %q, %q, want %q, %q", test.qualifiedName, name, namespace, test.name, test.namespace)
+		}
+	}
+}
+
+func TestNamespacesRelative(t *testing.T) {
+	names := newNamespaces("nsm-system", existingNamespaces("team-a", "team-b", "shared"))
+	tests := []struct {
+		reference string
+		namespace string
+		want      string
+	}{
+		{"firewall", "team-a", "firewall.team-a"},
+		{"firewall", "nsm-system", "firewall"},
+		{"firewall.shared", "team-a", "firewall.shared"},
+		{"vpn.gateway", "team-a", "vpn.gateway.team-a"},
+		{"firewall.nsm-system", "team-a", "firewall"},
+	}
+	for _, test := range tests {
+		if got := names.relative(test.reference, test.namespace); got != test.want {
+			t.Errorf("relative(%q, %q) = %q, want %q", test.reference, test.namespace, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package registryserver
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// statusUpdateInterval is a period of NetworkService status recalculation
+const statusUpdateInterval = 5 * time.Second
+
+// nsStatusUpdater keeps NetworkService status up to date with endpoints registered in the cache.
+type nsStatusUpdater struct {
+	cache RegistryCache
+	done  chan struct{}
+}
+
+func newNsStatusUpdater(cache RegistryCache) *nsStatusUpdater {
+	return &nsStatusUpdater{
+		cache: cache,
+		done:  make(chan struct{}),
+	}
+}
+
+func (u *nsStatusUpdater) start() {
+	go func() {
+		ticker := time.NewTicker(statusUpdateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				u.update()
+			case <-u.done:
+				return
+			}
+		}
+	}()
+}
+
+func (u *nsStatusUpdater) stop() {
+	close(u.done)
+}
+
+func (u *nsStatusUpdater) update() {
+	for _, ns := range u.cache.GetNetworkServices() {
+		status := networkServiceStatus(ns, u.cache.GetEndpointsByNs(ns.Namespace, ns.Name), time.Now())
+		if reflect.DeepEqual(status, ns.Status) {
+			continue
+		}
+		updNs := ns.DeepCopy()
+		updNs.Status = status
+		if _, err := u.cache.UpdateNetworkServiceStatus(updNs); err != nil {
+			// Status is updated by every registry server, a conflict means someone else has done it already.
+			if !apierrors.IsConflict(err) {
+				logrus.Errorf("Failed to update status of NetworkService %s: %v", ns.Name, err)
+			}
+			continue
+		}
+		logrus.Infof("NetworkService %s status updated: %v", ns.Name, status)
+	}
+}
+
+// networkServiceStatus calculates the network service status from its registered endpoints, connections are
+// counted from the endpoint load reported by nsmds for every local endpoint. Transition time of unchanged conditions is preserved.
+func networkServiceStatus(ns *v1.NetworkService, endpoints []*v1.NetworkServiceEndpoint, now time.Time) v1.NetworkServiceStatus {
+	status := v1.NetworkServiceStatus{
+		Endpoints: len(endpoints),
+	}
+	for _, endpoint := range endpoints {
+		if status.EndpointsByNsm == nil {
+			status.EndpointsByNsm = map[string]int{}
+		}
+		status.EndpointsByNsm[endpoint.Spec.NsmName]++
+		status.Connections += endpoint.Status.Load
+	}
+
+	unmatched, routes := 0, 0
+	for _, match := range ns.Spec.Matches {
+		if match == nil {
+			continue
+		}
+		matchStatus := &v1.MatchStatus{
+			SourceSelector: match.SourceSelector,
+		}
+		for _, route := range match.Routes {
+			if route == nil {
+				continue
+			}
+			routeStatus := &v1.RouteStatus{
+				DestinationSelector: route.DestinationSelector,
+			}
+			for _, endpoint := range endpoints {
+				if isSubset(nseLabels(endpoint), route.DestinationSelector) {
+					routeStatus.Endpoints++
+				}
+			}
+			if routeStatus.Endpoints == 0 {
+				unmatched++
+			}
+			routes++
+			matchStatus.Routes = append(matchStatus.Routes, routeStatus)
+		}
+		status.Matches = append(status.Matches, matchStatus)
+	}
+
+	if len(endpoints) == 0 {
+		status.Conditions = append(status.Conditions, newCondition(ns, v1.NoEndpoints, v1.ConditionTrue, now,
+			"NoEndpointsRegistered", "No endpoints are registered for the network service"))
+	} else {
+		status.Conditions = append(status.Conditions, newCondition(ns, v1.NoEndpoints, v1.ConditionFalse, now,
+			"EndpointsRegistered", fmt.Sprintf("%d endpoints are registered for the network service", len(endpoints))))
+	}
+	if unmatched > 0 {
+		status.Conditions = append(status.Conditions, newCondition(ns, v1.UnmatchedRoutes, v1.ConditionTrue, now,
+			"RoutesWithoutEndpoints", fmt.Sprintf("%d of %d routes do not match any endpoint", unmatched, routes)))
+	} else {
+		status.Conditions = append(status.Conditions, newCondition(ns, v1.UnmatchedRoutes, v1.ConditionFalse, now,
+			"AllRoutesMatched", "All routes match at least one endpoint"))
+	}
+	return status
+}
+
+// newCondition creates a condition, transition time is taken from the current condition of the same type and status.
+func newCondition(ns *v1.NetworkService, conditionType v1.NetworkServiceConditionType, conditionStatus v1.ConditionStatus,
+	now time.Time, reason, message string) *v1.NetworkServiceCondition {
+	condition := &v1.NetworkServiceCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.NewTime(now),
+		Reason:             reason,
+		Message:            message,
+	}
+	for _, current := range ns.Status.Conditions {
+		if current != nil && current.Type == conditionType && current.Status == conditionStatus {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+	}
+	return condition
+}
+
+func isSubset(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package registryserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestEndpoint(name string, labels map[string]string) *v1.NetworkServiceEndpoint {
+	return &v1.NetworkServiceEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.NetworkServiceEndpointSpec{
+			NetworkServiceName: "secure-intranet-connectivity",
+			NsmName:            "nsm-1",
+			Labels:             labels,
+		},
+	}
+}
+
+func TestNetworkServiceStatus(t *testing.T) {
+	RegisterTestingT(t)
+
+	ns := &v1.NetworkService{
+		ObjectMeta: metav1.ObjectMeta{Name: "secure-intranet-connectivity"},
+		Spec: v1.NetworkServiceSpec{
+			Payload: "IP",
+			Matches: []*v1.Match{
+				{
+					SourceSelector: map[string]string{"app": "firewall"},
+					Routes: []*v1.Destination{
+						{DestinationSelector: map[string]string{"app": "vpn-gateway"}},
+						{DestinationSelector: map[string]string{"app": "vpn-gateway", "zone": "b"}},
+					},
+				},
+				{
+					Routes: []*v1.Destination{
+						{DestinationSelector: map[string]string{"app": "firewall"}},
+						{DestinationSelector: map[string]string{"app": "missing"}},
+					},
+				},
+			},
+		},
+	}
+	endpoints := []*v1.NetworkServiceEndpoint{
+		newTestEndpoint("vpn-1", map[string]string{"app": "vpn-gateway", "zone": "a"}),
+		newTestEndpoint("vpn-2", map[string]string{"app": "vpn-gateway", "zone": "b"}),
+		newTestEndpoint("firewall-1", map[string]string{"app": "firewall"}),
+	}
+
+	status := networkServiceStatus(ns, endpoints, time.Now())
+
+	Expect(status.Matches).To(Equal([]*v1.MatchStatus{
+		{
+			SourceSelector: map[string]string{"app": "firewall"},
+			Routes: []*v1.RouteStatus{
+				{DestinationSelector: map[string]string{"app": "vpn-gateway"}, Endpoints: 2},
+				{DestinationSelector: map[string]string{"app": "vpn-gateway", "zone": "b"}, Endpoints: 1},
+			},
+		},
+		{
+			Routes: []*v1.RouteStatus{
+				{DestinationSelector: map[string]string{"app": "firewall"}, Endpoints: 1},
+				{DestinationSelector: map[string]string{"app": "missing"}, Endpoints: 0},
+			},
+		},
+	}))
+}
+
+func TestNetworkServiceStatusNoEndpoints(t *testing.T) {
+	RegisterTestingT(t)
+
+	ns := &v1.NetworkService{
+		Spec: v1.NetworkServiceSpec{
+			Matches: []*v1.Match{
+				{Routes: []*v1.Destination{{}}},
+			},
+		},
+	}
+
+	status := networkServiceStatus(ns, nil, time.Now())
+
+	Expect(status.Endpoints).To(Equal(0))
+	Expect(status.EndpointsByNsm).To(BeNil())
+	Expect(status.Matches).To(HaveLen(1))
+	Expect(status.Matches[0].Routes).To(HaveLen(1))
+	Expect(status.Matches[0].Routes[0].Endpoints).To(Equal(0))
+	Expect(condition(status, v1.NoEndpoints).Status).To(Equal(v1.ConditionTrue))
+	Expect(condition(status, v1.UnmatchedRoutes).Status).To(Equal(v1.ConditionTrue))
+}
+
+func TestNetworkServiceStatusCounters(t *testing.T) {
+	RegisterTestingT(t)
+
+	ns := &v1.NetworkService{
+		Spec: v1.NetworkServiceSpec{
+			Matches: []*v1.Match{
+				{Routes: []*v1.Destination{{}}},
+			},
+		},
+	}
+	endpoints := []*v1.NetworkServiceEndpoint{
+		newTestEndpoint("nse-1", nil),
+		newTestEndpoint("nse-2", nil),
+		newTestEndpoint("nse-3", nil),
+	}
+	endpoints[0].Status.Load = 2
+	endpoints[1].Status.Load = 3
+	endpoints[2].Spec.NsmName = "nsm-2"
+
+	status := networkServiceStatus(ns, endpoints, time.Now())
+
+	Expect(status.Endpoints).To(Equal(3))
+	Expect(status.EndpointsByNsm).To(Equal(map[string]int{"nsm-1": 2, "nsm-2": 1}))
+	Expect(status.Connections).To(Equal(uint32(5)))
+	Expect(condition(status, v1.NoEndpoints).Status).To(Equal(v1.ConditionFalse))
+	Expect(condition(status, v1.UnmatchedRoutes).Status).To(Equal(v1.ConditionFalse))
+}
+
+func TestNetworkServiceStatusTransitionTime(t *testing.T) {
+	RegisterTestingT(t)
+
+	ns := &v1.NetworkService{}
+	start := time.Now()
+
+	ns.Status = networkServiceStatus(ns, nil, start)
+	Expect(condition(ns.Status, v1.NoEndpoints).LastTransitionTime.Time).To(Equal(start))
+
+	// Unchanged condition keeps its transition time, so the status is not updated again.
+	status := networkServiceStatus(ns, nil, start.Add(time.Minute))
+	Expect(status).To(Equal(ns.Status))
+
+	ns.Status = networkServiceStatus(ns, []*v1.NetworkServiceEndpoint{newTestEndpoint("nse-1", nil)}, start.Add(time.Hour))
+	Expect(condition(ns.Status, v1.NoEndpoints).Status).To(Equal(v1.ConditionFalse))
+	Expect(condition(ns.Status, v1.NoEndpoints).LastTransitionTime.Time).To(Equal(start.Add(time.Hour)))
+}
+
+func condition(status v1.NetworkServiceStatus, conditionType v1.NetworkServiceConditionType) *v1.NetworkServiceCondition {
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			return c
+		}
+	}
+	return nil
+}
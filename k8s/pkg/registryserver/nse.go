@@ -1,6 +1,7 @@
 package registryserver
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -14,17 +15,21 @@ import (
 
 const (
 	NodeNameLabelKey = "nodeName"
+	// NetworkServiceNameLabelKey is an object label of endpoint custom resource with the network service name
+	NetworkServiceNameLabelKey = "networkservicename"
 )
 
 type nseRegistryService struct {
 	nsmName string
 	cache   RegistryCache
+	names   *namespaces
 }
 
-func newNseRegistryService(nsmName string, cache RegistryCache) *nseRegistryService {
+func newNseRegistryService(nsmName string, cache RegistryCache, names *namespaces) *nseRegistryService {
 	return &nseRegistryService{
 		nsmName: nsmName,
 		cache:   cache,
+		names:   names,
 	}
 }
 
@@ -33,15 +38,12 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 
 	logrus.Infof("Received RegisterNSE(%v)", request)
 
-	labels := request.GetNetworkserviceEndpoint().GetLabels()
-	if labels == nil {
-		labels = make(map[string]string)
-	}
-	labels["networkservicename"] = request.GetNetworkService().GetName()
 	if request.GetNetworkserviceEndpoint() != nil && request.GetNetworkService() != nil {
+		name, namespace := rs.names.split(request.GetNetworkService().GetName())
 		networkService, err := rs.cache.AddNetworkService(&v1.NetworkService{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: request.NetworkService.GetName(),
+				Name:      name,
+				Namespace: namespace,
 			},
 			Spec: v1.NetworkServiceSpec{
 				Payload: request.NetworkService.GetPayload(),
@@ -52,24 +54,13 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 			logrus.Errorf("Failed to register nsm: %s", err)
 			return nil, err
 		}
-		nseResponse, err := rs.cache.AddNetworkServiceEndpoint(&v1.NetworkServiceEndpoint{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: request.GetNetworkService().GetName(),
-				Labels:       labels,
-			},
-			Spec: v1.NetworkServiceEndpointSpec{
-				NetworkServiceName: request.GetNetworkService().GetName(),
-				NsmName:            rs.nsmName,
-			},
-			Status: v1.NetworkServiceEndpointStatus{
-				State: v1.RUNNING,
-			},
-		})
+		nseResponse, err := rs.cache.AddNetworkServiceEndpoint(
+			mapNseToCustomResource(request.GetNetworkserviceEndpoint(), name, namespace, rs.nsmName))
 		if err != nil {
 			return nil, err
 		}
 
-		request.NetworkserviceEndpoint = mapNseFromCustomResource(nseResponse, networkService.Spec.Payload)
+		request.NetworkserviceEndpoint = mapNseFromCustomResource(nseResponse, networkService.Spec.Payload, rs.names)
 		if nsm := rs.cache.GetNetworkServiceManager(rs.nsmName); nsm != nil {
 			request.NetworkServiceManager = mapNsmFromCustomResource(nsm)
 		}
@@ -79,12 +70,32 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 
 }
 
+func (rs *nseRegistryService) UpdateNSELoad(ctx context.Context, request *registry.UpdateNSELoadRequest) (*empty.Empty, error) {
+	name, namespace := rs.names.split(request.EndpointName)
+	nse := rs.cache.GetNetworkServiceEndpoint(namespace, name)
+	if nse == nil {
+		return nil, fmt.Errorf("no NetworkServiceEndpoint with name: %v", request.EndpointName)
+	}
+	if nse.Status.Load == request.Load {
+		return &empty.Empty{}, nil
+	}
+
+	logrus.Infof("Updating load of NSE %s: %d -> %d", request.EndpointName, nse.Status.Load, request.Load)
+	nse = nse.DeepCopy()
+	nse.Status.Load = request.Load
+	if _, err := rs.cache.UpdateNetworkServiceEndpoint(nse); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 func (rs *nseRegistryService) RemoveNSE(ctx context.Context, request *registry.RemoveNSERequest) (*empty.Empty, error) {
 	st := time.Now()
 
 	logrus.Infof("Received RemoveNSE(%v)", request)
 
-	if err := rs.cache.DeleteNetworkServiceEndpoint(request.EndpointName); err != nil {
+	name, namespace := rs.names.split(request.EndpointName)
+	if err := rs.cache.DeleteNetworkServiceEndpoint(namespace, name); err != nil {
 		return nil, err
 	}
 	logrus.Infof("RemoveNSE done: time %v", time.Since(st))
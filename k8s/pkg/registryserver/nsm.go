@@ -11,12 +11,14 @@ import (
 type nsmRegistryService struct {
 	nsmName string
 	cache   RegistryCache
+	names   *namespaces
 }
 
-func newNsmRegistryService(nsmName string, cache RegistryCache) *nsmRegistryService {
+func newNsmRegistryService(nsmName string, cache RegistryCache, names *namespaces) *nsmRegistryService {
 	return &nsmRegistryService{
 		nsmName: nsmName,
 		cache:   cache,
+		names:   names,
 	}
 }
 
@@ -38,12 +40,12 @@ func (n *nsmRegistryService) GetEndpoints(context.Context, *empty.Empty) (*regis
 	endpoints := n.cache.GetEndpointsByNsm(n.nsmName)
 	var response []*registry.NetworkServiceEndpoint
 	for _, endpoint := range endpoints {
-		ns, err := n.cache.GetNetworkService(endpoint.Spec.NetworkServiceName)
+		ns, err := n.cache.GetNetworkService(endpoint.Namespace, endpoint.Spec.NetworkServiceName)
 		if err != nil {
 			logrus.Error(err)
 			return nil, err
 		}
-		response = append(response, mapNseFromCustomResource(endpoint, ns.Spec.Payload))
+		response = append(response, mapNseFromCustomResource(endpoint, ns.Spec.Payload, n.names))
 	}
 
 	return &registry.NetworkServiceEndpointList{
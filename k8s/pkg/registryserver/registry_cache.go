@@ -12,15 +12,19 @@ import (
 
 type RegistryCache interface {
 	AddNetworkService(ns *v1.NetworkService) (*v1.NetworkService, error)
-	GetNetworkService(name string) (*v1.NetworkService, error)
+	GetNetworkService(namespace, name string) (*v1.NetworkService, error)
+	GetNetworkServices() []*v1.NetworkService
+	UpdateNetworkServiceStatus(ns *v1.NetworkService) (*v1.NetworkService, error)
 
 	AddNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error)
 	UpdateNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error)
 	GetNetworkServiceManager(name string) *v1.NetworkServiceManager
 
 	AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
-	DeleteNetworkServiceEndpoint(endpointName string) error
-	GetEndpointsByNs(networkServiceName string) []*v1.NetworkServiceEndpoint
+	UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
+	GetNetworkServiceEndpoint(namespace, endpointName string) *v1.NetworkServiceEndpoint
+	DeleteNetworkServiceEndpoint(namespace, endpointName string) error
+	GetEndpointsByNs(namespace, networkServiceName string) []*v1.NetworkServiceEndpoint
 	GetEndpointsByNsm(nsmName string) []*v1.NetworkServiceEndpoint
 
 	Start() error
@@ -73,11 +77,11 @@ func (rc *registryCacheImpl) Start() error {
 }
 
 func (rc *registryCacheImpl) AddNetworkService(ns *v1.NetworkService) (*v1.NetworkService, error) {
-	if existingNs := rc.networkServiceCache.Get(ns.GetName()); existingNs != nil {
+	if existingNs := rc.networkServiceCache.Get(ns.GetNamespace(), ns.GetName()); existingNs != nil {
 		return existingNs, nil
 	}
 
-	nsResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServices("default").Create(ns)
+	nsResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServices(ns.GetNamespace()).Create(ns)
 	if err == nil {
 		rc.networkServiceCache.Add(nsResponse)
 		return nsResponse, nil
@@ -90,20 +94,32 @@ func (rc *registryCacheImpl) AddNetworkService(ns *v1.NetworkService) (*v1.Netwo
 	return nil, err
 }
 
-func (rc *registryCacheImpl) GetNetworkService(name string) (*v1.NetworkService, error) {
-	if ns := rc.networkServiceCache.Get(name); ns == nil {
-		return nil, fmt.Errorf("no NetworkService with name: %v", name)
+func (rc *registryCacheImpl) GetNetworkService(namespace, name string) (*v1.NetworkService, error) {
+	if ns := rc.networkServiceCache.Get(namespace, name); ns == nil {
+		return nil, fmt.Errorf("no NetworkService with name: %v in namespace: %v", name, namespace)
 	} else {
 		return ns, nil
 	}
 }
 
+func (rc *registryCacheImpl) GetNetworkServices() []*v1.NetworkService {
+	return rc.networkServiceCache.GetAll()
+}
+
+func (rc *registryCacheImpl) UpdateNetworkServiceStatus(ns *v1.NetworkService) (*v1.NetworkService, error) {
+	updNs, err := rc.clientset.NetworkservicemeshV1().NetworkServices(ns.GetNamespace()).UpdateStatus(ns)
+	if err == nil {
+		rc.networkServiceCache.Update(updNs)
+	}
+	return updNs, err
+}
+
 func (rc *registryCacheImpl) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
-	if existingNse := rc.networkServiceEndpointCache.Get(nse.GetName()); existingNse != nil {
+	if existingNse := rc.networkServiceEndpointCache.Get(nse.GetNamespace(), nse.GetName()); existingNse != nil {
 		return existingNse, nil
 	}
 
-	nseResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Create(nse)
+	nseResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints(nse.GetNamespace()).Create(nse)
 	if err == nil {
 		rc.networkServiceEndpointCache.Add(nseResponse)
 		return nseResponse, nil
@@ -116,13 +132,25 @@ func (rc *registryCacheImpl) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEnd
 	return nil, err
 }
 
-func (rc *registryCacheImpl) DeleteNetworkServiceEndpoint(endpointName string) error {
-	rc.networkServiceEndpointCache.Delete(endpointName)
-	return rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Delete(endpointName, &metav1.DeleteOptions{})
+func (rc *registryCacheImpl) UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	updNse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints(nse.GetNamespace()).Update(nse)
+	if err == nil {
+		rc.networkServiceEndpointCache.Update(updNse)
+	}
+	return updNse, err
+}
+
+func (rc *registryCacheImpl) GetNetworkServiceEndpoint(namespace, endpointName string) *v1.NetworkServiceEndpoint {
+	return rc.networkServiceEndpointCache.Get(namespace, endpointName)
+}
+
+func (rc *registryCacheImpl) DeleteNetworkServiceEndpoint(namespace, endpointName string) error {
+	rc.networkServiceEndpointCache.Delete(namespace, endpointName)
+	return rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints(namespace).Delete(endpointName, &metav1.DeleteOptions{})
 }
 
-func (rc *registryCacheImpl) GetEndpointsByNs(networkServiceName string) []*v1.NetworkServiceEndpoint {
-	return rc.networkServiceEndpointCache.GetByNetworkService(networkServiceName)
+func (rc *registryCacheImpl) GetEndpointsByNs(namespace, networkServiceName string) []*v1.NetworkServiceEndpoint {
+	return rc.networkServiceEndpointCache.GetByNetworkService(namespace, networkServiceName)
 }
 
 func (rc *registryCacheImpl) GetEndpointsByNsm(nsmName string) []*v1.NetworkServiceEndpoint {
@@ -134,7 +162,7 @@ func (rc *registryCacheImpl) AddNetworkServiceManager(nsm *v1.NetworkServiceMana
 		return existingNsm, nil
 	}
 
-	nsmResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceManagers("default").Create(nsm)
+	nsmResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceManagers(metav1.NamespaceNone).Create(nsm)
 	if err == nil {
 		rc.networkServiceManagerCache.Add(nsmResponse)
 		return nsmResponse, nil
@@ -148,7 +176,7 @@ func (rc *registryCacheImpl) AddNetworkServiceManager(nsm *v1.NetworkServiceMana
 }
 
 func (rc *registryCacheImpl) UpdateNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error) {
-	updNsm, err := rc.clientset.NetworkservicemeshV1().NetworkServiceManagers("default").Update(nsm)
+	updNsm, err := rc.clientset.NetworkservicemeshV1().NetworkServiceManagers(metav1.NamespaceNone).Update(nsm)
 	if err == nil {
 		rc.networkServiceManagerCache.Update(updNsm)
 	}
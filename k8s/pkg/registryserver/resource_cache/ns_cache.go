@@ -1,11 +1,14 @@
 package resource_cache
 
 import (
+	"sync"
+
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/informers/externalversions"
 )
 
 type NetworkServiceCache struct {
+	sync.RWMutex
 	cache           abstractResourceCache
 	networkServices map[string]*v1.NetworkService
 }
@@ -18,22 +21,39 @@ func NewNetworkServiceCache() *NetworkServiceCache {
 		keyFunc:             getNsKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceUpdatedFunc: rv.resourceAdded,
 		resourceType:        NsResource,
 	}
 	rv.cache = newAbstractResourceCache(config)
 	return rv
 }
 
-func (c *NetworkServiceCache) Get(key string) *v1.NetworkService {
-	return c.networkServices[key]
+func (c *NetworkServiceCache) Get(namespace, name string) *v1.NetworkService {
+	c.RLock()
+	defer c.RUnlock()
+	return c.networkServices[namespacedKey(namespace, name)]
+}
+
+func (c *NetworkServiceCache) GetAll() []*v1.NetworkService {
+	c.RLock()
+	defer c.RUnlock()
+	rv := make([]*v1.NetworkService, 0, len(c.networkServices))
+	for _, ns := range c.networkServices {
+		rv = append(rv, ns)
+	}
+	return rv
 }
 
 func (c *NetworkServiceCache) Add(ns *v1.NetworkService) {
 	c.cache.add(ns)
 }
 
-func (c *NetworkServiceCache) Delete(key string) {
-	c.cache.delete(key)
+func (c *NetworkServiceCache) Update(ns *v1.NetworkService) {
+	c.cache.update(ns)
+}
+
+func (c *NetworkServiceCache) Delete(namespace, name string) {
+	c.cache.delete(namespacedKey(namespace, name))
 }
 
 func (c *NetworkServiceCache) Start(informerFactory externalversions.SharedInformerFactory) (func(), error) {
@@ -41,14 +61,19 @@ func (c *NetworkServiceCache) Start(informerFactory externalversions.SharedInfor
 }
 
 func (c *NetworkServiceCache) resourceAdded(obj interface{}) {
+	c.Lock()
+	defer c.Unlock()
 	ns := obj.(*v1.NetworkService)
 	c.networkServices[getNsKey(ns)] = ns
 }
 
 func (c *NetworkServiceCache) resourceDeleted(key string) {
+	c.Lock()
+	defer c.Unlock()
 	delete(c.networkServices, key)
 }
 
 func getNsKey(obj interface{}) string {
-	return obj.(*v1.NetworkService).Name
+	ns := obj.(*v1.NetworkService)
+	return namespacedKey(ns.Namespace, ns.Name)
 }
@@ -1,12 +1,15 @@
 package resource_cache
 
 import (
+	"sync"
+
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/informers/externalversions"
 	"github.com/sirupsen/logrus"
 )
 
 type NetworkServiceEndpointCache struct {
+	sync.RWMutex
 	cache                   abstractResourceCache
 	nseByNs                 map[string][]*v1.NetworkServiceEndpoint
 	networkServiceEndpoints map[string]*v1.NetworkServiceEndpoint
@@ -21,21 +24,34 @@ func NewNetworkServiceEndpointCache() *NetworkServiceEndpointCache {
 		keyFunc:             getNseKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceUpdatedFunc: rv.resourceAdded,
 		resourceType:        NseResource,
 	}
 	rv.cache = newAbstractResourceCache(config)
 	return rv
 }
 
-func (c *NetworkServiceEndpointCache) Get(key string) *v1.NetworkServiceEndpoint {
-	return c.networkServiceEndpoints[key]
+func (c *NetworkServiceEndpointCache) Get(namespace, name string) *v1.NetworkServiceEndpoint {
+	c.RLock()
+	defer c.RUnlock()
+	return c.networkServiceEndpoints[namespacedKey(namespace, name)]
 }
 
-func (c *NetworkServiceEndpointCache) GetByNetworkService(networkServiceName string) []*v1.NetworkServiceEndpoint {
-	return c.nseByNs[networkServiceName]
+// GetByNetworkService returns endpoints of the network service, endpoints are in the same namespace as their service.
+func (c *NetworkServiceEndpointCache) GetByNetworkService(namespace, networkServiceName string) []*v1.NetworkServiceEndpoint {
+	c.RLock()
+	defer c.RUnlock()
+	// Cached slice is changed in place on delete, so a copy is returned
+	endpoints := c.nseByNs[namespacedKey(namespace, networkServiceName)]
+	if endpoints == nil {
+		return nil
+	}
+	return append([]*v1.NetworkServiceEndpoint(nil), endpoints...)
 }
 
 func (c *NetworkServiceEndpointCache) GetByNetworkServiceManager(nsmName string) []*v1.NetworkServiceEndpoint {
+	c.RLock()
+	defer c.RUnlock()
 	var rv []*v1.NetworkServiceEndpoint
 
 	for _, endpoint := range c.networkServiceEndpoints {
@@ -52,8 +68,12 @@ func (c *NetworkServiceEndpointCache) Add(nse *v1.NetworkServiceEndpoint) {
 	c.cache.add(nse)
 }
 
-func (c *NetworkServiceEndpointCache) Delete(key string) {
-	c.cache.delete(key)
+func (c *NetworkServiceEndpointCache) Update(nse *v1.NetworkServiceEndpoint) {
+	c.cache.update(nse)
+}
+
+func (c *NetworkServiceEndpointCache) Delete(namespace, name string) {
+	c.cache.delete(namespacedKey(namespace, name))
 }
 
 func (c *NetworkServiceEndpointCache) Start(informerFactory externalversions.SharedInformerFactory) (func(), error) {
@@ -61,10 +81,13 @@ func (c *NetworkServiceEndpointCache) Start(informerFactory externalversions.Sha
 }
 
 func (c *NetworkServiceEndpointCache) resourceAdded(obj interface{}) {
+	c.Lock()
+	defer c.Unlock()
 	nse := obj.(*v1.NetworkServiceEndpoint)
-	endpoints := c.nseByNs[nse.Spec.NetworkServiceName]
+	nsKey := getNseServiceKey(nse)
+	endpoints := c.nseByNs[nsKey]
 	if _, exist := c.networkServiceEndpoints[getNseKey(nse)]; !exist {
-		c.nseByNs[nse.Spec.NetworkServiceName] = append(endpoints, nse)
+		c.nseByNs[nsKey] = append(endpoints, nse)
 	} else {
 		for i, e := range endpoints {
 			if getNseKey(nse) == getNseKey(e) {
@@ -77,12 +100,15 @@ func (c *NetworkServiceEndpointCache) resourceAdded(obj interface{}) {
 }
 
 func (c *NetworkServiceEndpointCache) resourceDeleted(key string) {
+	c.Lock()
+	defer c.Unlock()
 	nse, exist := c.networkServiceEndpoints[key]
 	if !exist {
 		return
 	}
 
-	endpoints := c.nseByNs[nse.Spec.NetworkServiceName]
+	nsKey := getNseServiceKey(nse)
+	endpoints := c.nseByNs[nsKey]
 	var index int
 	for i, e := range endpoints {
 		if getNseKey(nse) == getNseKey(e) {
@@ -92,13 +118,18 @@ func (c *NetworkServiceEndpointCache) resourceDeleted(key string) {
 	}
 	endpoints = append(endpoints[:index], endpoints[index+1:]...)
 	if len(endpoints) == 0 {
-		delete(c.nseByNs, nse.Spec.NetworkServiceName)
+		delete(c.nseByNs, nsKey)
 	} else {
-		c.nseByNs[nse.Spec.NetworkServiceName] = endpoints
+		c.nseByNs[nsKey] = endpoints
 	}
 	delete(c.networkServiceEndpoints, key)
 }
 
 func getNseKey(obj interface{}) string {
-	return obj.(*v1.NetworkServiceEndpoint).Name
+	nse := obj.(*v1.NetworkServiceEndpoint)
+	return namespacedKey(nse.Namespace, nse.Name)
+}
+
+func getNseServiceKey(nse *v1.NetworkServiceEndpoint) string {
+	return namespacedKey(nse.Namespace, nse.Spec.NetworkServiceName)
 }
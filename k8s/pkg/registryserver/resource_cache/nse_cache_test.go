@@ -119,10 +119,15 @@ func TestRegistryDelete(t *testing.T) {
 
 func getEndpoints(nseCache *resource_cache.NetworkServiceEndpointCache,
 	networkServiceName string, expectedLength int) []*v1.NetworkServiceEndpoint {
+	return getNamespacedEndpoints(nseCache, "", networkServiceName, expectedLength)
+}
+
+func getNamespacedEndpoints(nseCache *resource_cache.NetworkServiceEndpointCache,
+	namespace, networkServiceName string, expectedLength int) []*v1.NetworkServiceEndpoint {
 	var endpointList []*v1.NetworkServiceEndpoint
 	for attempt := 0; attempt < 10; <-time.Tick(300 * time.Millisecond) {
 		attempt++
-		endpointList = nseCache.GetByNetworkService(networkServiceName)
+		endpointList = nseCache.GetByNetworkService(namespace, networkServiceName)
 		if len(endpointList) == expectedLength {
 			logrus.Infof("Attempt: %v", attempt)
 			break
@@ -131,6 +136,38 @@ func getEndpoints(nseCache *resource_cache.NetworkServiceEndpointCache,
 	return endpointList
 }
 
+func TestRegistryNamespaces(t *testing.T) {
+	RegisterTestingT(t)
+
+	fakeRegistry := fakeRegistry{}
+	nseCache := resource_cache.NewNetworkServiceEndpointCache()
+
+	stopFunc, err := nseCache.Start(&fakeRegistry)
+
+	Expect(stopFunc).ToNot(BeNil())
+	Expect(err).To(BeNil())
+
+	nseA := newTestNse("nse1", "secure-intranet")
+	nseA.Namespace = "team-a"
+	nseB := newTestNse("nse1", "secure-intranet")
+	nseB.Namespace = "team-b"
+
+	fakeRegistry.Add(nseA)
+	fakeRegistry.Add(nseB)
+
+	endpointListA := getNamespacedEndpoints(nseCache, "team-a", "secure-intranet", 1)
+	Expect(len(endpointListA)).To(Equal(1))
+	Expect(endpointListA[0].Namespace).To(Equal("team-a"))
+	endpointListB := getNamespacedEndpoints(nseCache, "team-b", "secure-intranet", 1)
+	Expect(len(endpointListB)).To(Equal(1))
+	Expect(endpointListB[0].Namespace).To(Equal("team-b"))
+	Expect(nseCache.Get("team-a", "nse1")).To(Equal(nseA))
+
+	fakeRegistry.Delete(nseA)
+	Expect(len(getNamespacedEndpoints(nseCache, "team-a", "secure-intranet", 0))).To(Equal(0))
+	Expect(len(getNamespacedEndpoints(nseCache, "team-b", "secure-intranet", 1))).To(Equal(1))
+}
+
 func newTestNse(name string, networkServiceName string) *v1.NetworkServiceEndpoint {
 	return &v1.NetworkServiceEndpoint{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1,12 +1,15 @@
 package resource_cache
 
 import (
+	"sync"
+
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/informers/externalversions"
 	"github.com/sirupsen/logrus"
 )
 
 type NetworkServiceManagerCache struct {
+	sync.RWMutex
 	cache                  abstractResourceCache
 	networkServiceManagers map[string]*v1.NetworkServiceManager
 }
@@ -27,6 +30,8 @@ func NewNetworkServiceManagerCache() *NetworkServiceManagerCache {
 }
 
 func (c *NetworkServiceManagerCache) Get(key string) *v1.NetworkServiceManager {
+	c.RLock()
+	defer c.RUnlock()
 	return c.networkServiceManagers[key]
 }
 
@@ -49,18 +54,24 @@ func (c *NetworkServiceManagerCache) Start(informerFactory externalversions.Shar
 }
 
 func (c *NetworkServiceManagerCache) resourceAdded(obj interface{}) {
+	c.Lock()
+	defer c.Unlock()
 	nsm := obj.(*v1.NetworkServiceManager)
 	logrus.Infof("NetworkServiceManagerCache.Added(%v)", nsm)
 	c.networkServiceManagers[getNsmKey(nsm)] = nsm
 }
 
 func (c *NetworkServiceManagerCache) resourceUpdated(obj interface{}) {
+	c.Lock()
+	defer c.Unlock()
 	nsm := obj.(*v1.NetworkServiceManager)
 	logrus.Infof("NetworkServiceManagerCache.resourceUpdated(%v)", nsm)
 	c.networkServiceManagers[getNsmKey(nsm)] = nsm
 }
 
 func (c *NetworkServiceManagerCache) resourceDeleted(key string) {
+	c.Lock()
+	defer c.Unlock()
 	logrus.Infof("NetworkServiceManagerCache.Deleted(%v=%v)", key, c.networkServiceManagers[key])
 	delete(c.networkServiceManagers, key)
 }
@@ -31,6 +31,14 @@ type abstractResourceCache struct {
 
 const defaultChannelSize = 10
 
+// namespacedKey returns a cache key of namespaced resource, cluster scoped resources are keyed by name.
+func namespacedKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
 func newAbstractResourceCache(config cacheConfig) abstractResourceCache {
 	return abstractResourceCache{
 		addCh:    make(chan interface{}, defaultChannelSize),
@@ -114,11 +122,8 @@ func (c *abstractResourceCache) addEventHandlers(informer cache.SharedInformer)
 	if c.config.resourceUpdatedFunc != nil {
 		updateFunc = func(old interface{}, new interface{}) {
 			logrus.Infof("Update from k8s-registry: %v", reflect.TypeOf(old))
-			if _, ok := old.(*v1.NetworkServiceManager); !ok {
-				return
-			}
-			logrus.Infof("Old NSM: %v", old.(*v1.NetworkServiceManager))
-			logrus.Infof("New NSM: %v", new.(*v1.NetworkServiceManager))
+			logrus.Infof("Old: %v", old)
+			logrus.Infof("New: %v", new)
 			c.update(new)
 		}
 	}
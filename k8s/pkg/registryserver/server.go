@@ -1,28 +1,27 @@
 package registryserver
 
 import (
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	nsmClientset "github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
-	"github.com/opentracing/opentracing-go"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"k8s.io/client-go/kubernetes"
 )
 
-func New(clientset *nsmClientset.Clientset, nsmName string) *grpc.Server {
-	tracer := opentracing.GlobalTracer()
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+// New creates a registry server, network services referenced without a namespace belong to passed namespace.
+// Kubernetes clientset is used to check namespaces of qualified names. Returned stop function stops the registry
+// cache and the network service status updater.
+func New(clientset *nsmClientset.Clientset, kubeClientset kubernetes.Interface, nsmName, namespace string) (*grpc.Server, func()) {
+	server := tools.NewServer()
 
 	cache := NewRegistryCache(clientset)
 	logrus.Info("RegistryCache started")
 
-	nseRegistry := newNseRegistryService(nsmName, cache)
-	nsmRegistry := newNsmRegistryService(nsmName, cache)
-	discovery := newDiscoveryService(cache)
+	names := newNamespaces(namespace, namespaceLister(kubeClientset))
+	nseRegistry := newNseRegistryService(nsmName, cache, names)
+	nsmRegistry := newNsmRegistryService(nsmName, cache, names)
+	discovery := newDiscoveryService(cache, names)
 
 	registry.RegisterNetworkServiceRegistryServer(server, nseRegistry)
 	registry.RegisterNetworkServiceDiscoveryServer(server, discovery)
@@ -30,7 +29,13 @@ func New(clientset *nsmClientset.Clientset, nsmName string) *grpc.Server {
 
 	if err := cache.Start(); err != nil {
 		logrus.Error(err)
+		return server, func() {}
 	}
+	statusUpdater := newNsStatusUpdater(cache)
+	statusUpdater.start()
 
-	return server
+	return server, func() {
+		statusUpdater.stop()
+		cache.Stop()
+	}
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+)
+
+// NewServer creates a gRPC server with tracing and request id interceptors installed.
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	return NewServerWithTracer(opentracing.GlobalTracer(), opts...)
+}
+
+// NewServerWithTracer creates a gRPC server with passed tracer and request id interceptors installed.
+func NewServerWithTracer(tracer opentracing.Tracer, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(ChainUnaryServer(
+			RequestIDUnaryServerInterceptor(),
+			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads()))),
+		grpc.StreamInterceptor(ChainStreamServer(
+			RequestIDStreamServerInterceptor(),
+			otgrpc.OpenTracingStreamServerInterceptor(tracer))))
+	return grpc.NewServer(opts...)
+}
+
+// DialOptions returns gRPC dial options with tracing and request id interceptors installed.
+func DialOptions(opts ...grpc.DialOption) []grpc.DialOption {
+	tracer := opentracing.GlobalTracer()
+	return append(opts,
+		grpc.WithUnaryInterceptor(ChainUnaryClient(
+			RequestIDUnaryClientInterceptor(),
+			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads()))),
+		grpc.WithStreamInterceptor(ChainStreamClient(
+			RequestIDStreamClientInterceptor(),
+			otgrpc.OpenTracingStreamClientInterceptor(tracer))))
+}
+
+// ChainUnaryServer creates a single interceptor out of a chain of many, first one is the outermost.
+func ChainUnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// ChainStreamServer creates a single interceptor out of a chain of many, first one is the outermost.
+func ChainStreamServer(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return chained(srv, ss)
+	}
+}
+
+// ChainUnaryClient creates a single interceptor out of a chain of many, first one is the outermost.
+func ChainUnaryClient(interceptors ...grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		chained := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, next, opts...)
+			}
+		}
+		return chained(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// ChainStreamClient creates a single interceptor out of a chain of many, first one is the outermost.
+func ChainStreamClient(interceptors ...grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		chained := streamer
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return interceptor(ctx, desc, cc, method, next, opts...)
+			}
+		}
+		return chained(ctx, desc, cc, method, opts...)
+	}
+}
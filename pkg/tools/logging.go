@@ -0,0 +1,155 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// LogFormatEnv selects the log output format, "json" or "text" (default)
+	LogFormatEnv = "NSM_LOG_FORMAT"
+	// LogFormatJSON is a value of LogFormatEnv to produce JSON log output
+	LogFormatJSON = "json"
+
+	// RequestIDMetadataKey is a gRPC metadata key used to pass request id between NSM components
+	RequestIDMetadataKey = "nsm-request-id"
+
+	// Structured log field names used across NSM components
+	LogFieldRequestID      = "request_id"
+	LogFieldConnectionID   = "connection_id"
+	LogFieldNetworkService = "network_service"
+	LogFieldEndpoint       = "endpoint"
+	LogFieldDataplane      = "dataplane"
+)
+
+type requestIDKey struct{}
+
+// InitLogging configures logrus output format from the NSM_LOG_FORMAT env variable.
+func InitLogging() {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv(LogFormatEnv)))
+	switch format {
+	case LogFormatJSON:
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	case "", "text":
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	default:
+		logrus.Warnf("Unknown %s value %s, using text log format", LogFormatEnv, format)
+	}
+}
+
+// NewRequestID generates a new random request id.
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		logrus.Errorf("Error generating request id: %v", err)
+		return ""
+	}
+	return fmt.Sprintf("%X", b)
+}
+
+// WithRequestID returns a copy of ctx carrying the passed request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns a request id stored in context, or passed in incoming gRPC metadata.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// EnsureRequestID returns a context with request id set, a new one is generated if context has no request id.
+func EnsureRequestID(ctx context.Context) (context.Context, string) {
+	requestID := RequestID(ctx)
+	if requestID == "" {
+		requestID = NewRequestID()
+	}
+	return WithRequestID(ctx, requestID), requestID
+}
+
+// Log returns a log entry with request id field set if context has one.
+func Log(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if requestID := RequestID(ctx); requestID != "" {
+		entry = entry.WithField(LogFieldRequestID, requestID)
+	}
+	return entry
+}
+
+// RequestIDUnaryServerInterceptor puts request id from incoming metadata into context, or generates a new one.
+func RequestIDUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, _ = EnsureRequestID(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// RequestIDStreamServerInterceptor puts request id from incoming metadata into stream context, or generates a new one.
+func RequestIDStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, _ := EnsureRequestID(ss.Context())
+		return handler(srv, &requestIDServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIDUnaryClientInterceptor passes request id from context into outgoing metadata.
+func RequestIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIDStreamClientInterceptor passes request id from context into outgoing metadata.
+func RequestIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoingRequestID(ctx context.Context) context.Context {
+	requestID := RequestID(ctx)
+	if requestID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,72 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+func TestRequestIDServerInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "id-1"))
+
+	var received string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		received = RequestID(ctx)
+		return nil, nil
+	}
+	interceptor := ChainUnaryServer(RequestIDUnaryServerInterceptor())
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if received != "id-1" {
+		t.Errorf("RequestID() = %v, want %v", received, "id-1")
+	}
+
+	// A new request id should be generated if none is passed.
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if received == "" {
+		t.Errorf("RequestID() is empty, want generated one")
+	}
+}
+
+func TestRequestIDClientInterceptor(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "id-2")
+
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(RequestIDMetadataKey)
+		return nil
+	}
+	interceptor := ChainUnaryClient(RequestIDUnaryClientInterceptor())
+	if err := interceptor(ctx, "method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0] != "id-2" {
+		t.Errorf("outgoing %s = %v, want [%v]", RequestIDMetadataKey, sent, "id-2")
+	}
+}
+
+func TestChainUnaryServerOrder(t *testing.T) {
+	var order []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			order = append(order, name)
+			return handler(ctx, req)
+		}
+	}
+	chain := ChainUnaryServer(interceptor("first"), interceptor("second"))
+	_, _ = chain(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		order = append(order, "handler")
+		return nil, nil
+	})
+	if len(order) != 3 || order[0] != "first" || order[1] != "second" || order[2] != "handler" {
+		t.Errorf("order = %v, want [first second handler]", order)
+	}
+}
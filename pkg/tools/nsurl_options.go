@@ -0,0 +1,247 @@
+// Copyright (c) 2019 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reserved NsUrl parameters, they are not passed as labels but configure the connection request.
+const (
+	// NsUrlMechanismParam selects a mechanism of the connection: kernel, vhost, mem, sriov or hw
+	NsUrlMechanismParam = "mechanism"
+	// NsUrlExtraPrefixesParam requests extra prefixes from NSE in <number>/<prefix_len>[/ipv4|ipv6] format, could be repeated
+	NsUrlExtraPrefixesParam = "extra_prefixes"
+	// NsUrlSrcIpRequiredParam is a bool requiring source ip address from NSE, true by default
+	NsUrlSrcIpRequiredParam = "src_ip_required"
+	// NsUrlDstIpRequiredParam is a bool requiring destination ip address from NSE, true by default
+	NsUrlDstIpRequiredParam = "dst_ip_required"
+	// NsUrlMtuParam is an MTU of the connection interface
+	NsUrlMtuParam = "mtu"
+	// NsUrlTimeoutParam is a timeout of a single connection request attempt, in Go duration format
+	NsUrlTimeoutParam = "timeout"
+)
+
+var nsUrlReservedParams = []string{
+	NsUrlMechanismParam, NsUrlExtraPrefixesParam, NsUrlSrcIpRequiredParam, NsUrlDstIpRequiredParam, NsUrlMtuParam, NsUrlTimeoutParam,
+}
+
+var nsUrlMechanisms = []string{"kernel", "vhost", "mem", "sriov", "hw"}
+
+const (
+	nsUrlMinMtu = 68
+	nsUrlMaxMtu = 65535
+	// nsUrlMinTypoLen is a min length of the reserved parameter name, typos of which are detected
+	nsUrlMinTypoLen = 6
+)
+
+// NsUrlExtraPrefixes is a request for extra prefixes from NSE.
+type NsUrlExtraPrefixes struct {
+	IPv6      bool
+	Number    uint32
+	PrefixLen uint32
+}
+
+// NsUrlOptions are connection options requested by reserved NsUrl parameters, unset options have zero values.
+type NsUrlOptions struct {
+	Mechanism     string
+	ExtraPrefixes []*NsUrlExtraPrefixes
+	SrcIpRequired bool
+	DstIpRequired bool
+	Mtu           uint32
+	Timeout       time.Duration
+}
+
+// IsReservedNsUrlParam checks if parameter configures the connection request, and should not be passed as a label.
+func IsReservedNsUrlParam(name string) bool {
+	for _, param := range nsUrlReservedParams {
+		if name == param {
+			return true
+		}
+	}
+	return false
+}
+
+// misspelledNsUrlParam returns a reserved parameter the name looks like, e.g. "extra-prefixes", "MTU" or "mechansim".
+// Such parameters are rejected, otherwise a typo silently turns an option into a label.
+func misspelledNsUrlParam(name string) (string, bool) {
+	if IsReservedNsUrlParam(name) {
+		return "", false
+	}
+	normalized := normalizeNsUrlParam(name)
+	for _, param := range nsUrlReservedParams {
+		reserved := normalizeNsUrlParam(param)
+		if normalized == reserved || (len(reserved) >= nsUrlMinTypoLen && isSingleEdit(normalized, reserved)) {
+			return param, true
+		}
+	}
+	return "", false
+}
+
+func normalizeNsUrlParam(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// isSingleEdit checks if strings differ by a single inserted, deleted or replaced character, or by two swapped
+// adjacent characters.
+func isSingleEdit(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if len(a) < len(b) {
+		return a[i:] == b[i+1:]
+	}
+	if i == len(a) {
+		return false
+	}
+	if a[i+1:] == b[i+1:] {
+		return true
+	}
+	return i+1 < len(a) && a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+}
+
+// Labels returns NsUrl parameters which are not reserved.
+func (u *NsUrl) Labels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range u.Params {
+		if !IsReservedNsUrlParam(k) && len(v) > 0 {
+			labels[k] = v[0]
+		}
+	}
+	return labels
+}
+
+// Options parses reserved NsUrl parameters, parameters looking like misspelled reserved ones are rejected.
+func (u *NsUrl) Options() (*NsUrlOptions, error) {
+	options := &NsUrlOptions{
+		SrcIpRequired: true,
+		DstIpRequired: true,
+	}
+
+	names := make([]string, 0, len(u.Params))
+	for name := range u.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if param, ok := misspelledNsUrlParam(name); ok {
+			return nil, fmt.Errorf("%s: unknown parameter %q, did you mean %q", u.NsName, name, param)
+		}
+	}
+
+	if mechanism := u.Params.Get(NsUrlMechanismParam); len(mechanism) > 0 {
+		valid := false
+		for _, m := range nsUrlMechanisms {
+			valid = valid || m == mechanism
+		}
+		if !valid {
+			return nil, fmt.Errorf("%s: invalid %s %q, expected one of %v", u.NsName, NsUrlMechanismParam, mechanism, nsUrlMechanisms)
+		}
+		options.Mechanism = mechanism
+	}
+
+	for _, value := range u.Params[NsUrlExtraPrefixesParam] {
+		extraPrefixes, err := parseNsUrlExtraPrefixes(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s %q: %v", u.NsName, NsUrlExtraPrefixesParam, value, err)
+		}
+		options.ExtraPrefixes = append(options.ExtraPrefixes, extraPrefixes)
+	}
+
+	var err error
+	if options.SrcIpRequired, err = parseNsUrlBool(u, NsUrlSrcIpRequiredParam, options.SrcIpRequired); err != nil {
+		return nil, err
+	}
+	if options.DstIpRequired, err = parseNsUrlBool(u, NsUrlDstIpRequiredParam, options.DstIpRequired); err != nil {
+		return nil, err
+	}
+
+	if value := u.Params.Get(NsUrlMtuParam); len(value) > 0 {
+		mtu, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || mtu < nsUrlMinMtu || mtu > nsUrlMaxMtu {
+			return nil, fmt.Errorf("%s: invalid %s %q, expected a number in [%d, %d]", u.NsName, NsUrlMtuParam, value, nsUrlMinMtu, nsUrlMaxMtu)
+		}
+		options.Mtu = uint32(mtu)
+	}
+
+	if value := u.Params.Get(NsUrlTimeoutParam); len(value) > 0 {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("%s: invalid %s %q, expected a positive duration like 30s", u.NsName, NsUrlTimeoutParam, value)
+		}
+		options.Timeout = timeout
+	}
+
+	return options, nil
+}
+
+func parseNsUrlBool(u *NsUrl, name string, defaultValue bool) (bool, error) {
+	value := u.Params.Get(name)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid %s %q, expected true or false", u.NsName, name, value)
+	}
+	return result, nil
+}
+
+func parseNsUrlExtraPrefixes(value string) (*NsUrlExtraPrefixes, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("expected <number>/<prefix_len>[/ipv4|ipv6]")
+	}
+	number, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil || number == 0 {
+		return nil, fmt.Errorf("number of prefixes should be a positive number")
+	}
+	prefixLen, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("prefix length should be a number")
+	}
+	result := &NsUrlExtraPrefixes{
+		Number:    uint32(number),
+		PrefixLen: uint32(prefixLen),
+	}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "ipv4":
+		case "ipv6":
+			result.IPv6 = true
+		default:
+			return nil, fmt.Errorf("address family should be ipv4 or ipv6")
+		}
+	}
+	maxLen := uint32(32)
+	if result.IPv6 {
+		maxLen = 128
+	}
+	if result.PrefixLen < 1 || result.PrefixLen > maxLen {
+		return nil, fmt.Errorf("prefix length should be in [1, %d]", maxLen)
+	}
+	return result, nil
+}
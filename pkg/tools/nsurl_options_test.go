@@ -0,0 +1,81 @@
+package tools_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+func TestNsUrlOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *NsUrlOptions
+		labels  map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "Defaults",
+			value:  "icmp?app=icmp",
+			want:   &NsUrlOptions{SrcIpRequired: true, DstIpRequired: true},
+			labels: map[string]string{"app": "icmp"},
+		},
+		{
+			name:  "AllOptions",
+			value: "icmp/eth1?app=icmp&mechanism=mem&extra_prefixes=2/30&extra_prefixes=1/64/ipv6&src_ip_required=false&dst_ip_required=true&mtu=1450&timeout=30s",
+			want: &NsUrlOptions{
+				Mechanism: "mem",
+				ExtraPrefixes: []*NsUrlExtraPrefixes{
+					{Number: 2, PrefixLen: 30},
+					{IPv6: true, Number: 1, PrefixLen: 64},
+				},
+				SrcIpRequired: false,
+				DstIpRequired: true,
+				Mtu:           1450,
+				Timeout:       30 * time.Second,
+			},
+			labels: map[string]string{"app": "icmp"},
+		},
+		{name: "BadMechanism", value: "icmp?mechanism=kernal", wantErr: true},
+		{name: "BadExtraPrefixesFormat", value: "icmp?extra_prefixes=2", wantErr: true},
+		{name: "BadExtraPrefixesNumber", value: "icmp?extra_prefixes=0/32", wantErr: true},
+		{name: "BadExtraPrefixesLen", value: "icmp?extra_prefixes=1/33", wantErr: true},
+		{name: "BadExtraPrefixesFamily", value: "icmp?extra_prefixes=1/32/ipx", wantErr: true},
+		{name: "BadSrcIpRequired", value: "icmp?src_ip_required=yes", wantErr: true},
+		{name: "BadMtu", value: "icmp?mtu=10", wantErr: true},
+		{name: "BadTimeout", value: "icmp?timeout=30", wantErr: true},
+		{name: "MisspelledMechanism", value: "icmp?mechansim=mem", wantErr: true},
+		{name: "MisspelledExtraPrefixes", value: "icmp?extra-prefixes=2/30", wantErr: true},
+		{name: "MisspelledMtu", value: "icmp?MTU=1450", wantErr: true},
+		{name: "MisspelledTimeout", value: "icmp?timout=30s", wantErr: true},
+		{
+			name:   "LabelsNotLikeReserved",
+			value:  "icmp?app=icmp&mt=1&timeouts_total=2",
+			want:   &NsUrlOptions{SrcIpRequired: true, DstIpRequired: true},
+			labels: map[string]string{"app": "icmp", "mt": "1", "timeouts_total": "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, err := ParseAnnotationValue(tt.value)
+			if err != nil {
+				t.Fatalf("ParseAnnotationValue() error = %v", err)
+			}
+			got, err := urls[0].Options()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Options() = %+v, want %+v", got, tt.want)
+			}
+			if labels := urls[0].Labels(); !reflect.DeepEqual(labels, tt.labels) {
+				t.Errorf("Labels() = %v, want %v", labels, tt.labels)
+			}
+		})
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"time"
 
 	"github.com/go-errors/errors"
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"github.com/uber/jaeger-client-go"
@@ -99,15 +98,10 @@ func SocketOperationCheckContext(ctx context.Context, listenEndpoint net.Addr) (
 }
 
 func dial(ctx context.Context, endpoint net.Addr) (*grpc.ClientConn, error) {
-	tracer := opentracing.GlobalTracer()
-	c, err := grpc.DialContext(ctx, endpoint.String(), grpc.WithInsecure(), grpc.WithBlock(),
+	c, err := grpc.DialContext(ctx, endpoint.String(), DialOptions(grpc.WithInsecure(), grpc.WithBlock(),
 		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
 			return net.DialTimeout(endpoint.Network(), addr, timeout)
-		}),
-		grpc.WithUnaryInterceptor(
-			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
-		grpc.WithStreamInterceptor(
-			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+		}))...)
 
 	return c, err
 }
@@ -17,6 +17,7 @@ package client
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
@@ -30,7 +31,7 @@ import (
 const (
 	connectRetries = 10
 	connectSleep   = 5 * time.Second
-	connectTimeout   = 10 * time.Second
+	connectTimeout = 10 * time.Second
 )
 
 // NsmClient is the NSM client struct
@@ -39,56 +40,127 @@ type NsmClient struct {
 	OutgoingNscName     string
 	OutgoingNscLabels   map[string]string
 	OutgoingConnections []*connection.Connection
+	// Options customize outgoing requests, nil to use defaults
+	Options  *tools.NsUrlOptions
+	requests map[string]*networkservice.NetworkServiceRequest
+	// connectRetries, connectSleep and connectTimeout configure outgoing request attempts
+	connectRetries int
+	connectSleep   time.Duration
+	connectTimeout time.Duration
 }
 
 // Connect implements the business logic
 func (nsmc *NsmClient) Connect(name, mechanism, description string) (*connection.Connection, error) {
+	return nsmc.ConnectToService(nsmc.Configuration.OutgoingNscName, nsmc.OutgoingNscLabels, name, mechanism, description)
+}
+
+// ConnectToService requests a connection to the passed network service with the passed labels
+func (nsmc *NsmClient) ConnectToService(networkService string, labels map[string]string, name, mechanism, description string) (*connection.Connection, error) {
 	logrus.Infof("Initiating an outgoing connection.")
 	nsmc.Lock()
 	defer nsmc.Unlock()
 
+	options := nsmc.Options
+	if options == nil {
+		options = &tools.NsUrlOptions{
+			SrcIpRequired: true,
+			DstIpRequired: true,
+		}
+	}
+	if len(options.Mechanism) > 0 {
+		mechanism = options.Mechanism
+	}
+
 	mechanismType := common.MechanismFromString(mechanism)
 	outgoingMechanism, err := connection.NewMechanism(mechanismType, name, description)
 	if err != nil {
 		logrus.Errorf("Failure to prepare the outgoing mechanism preference with error: %+v", err)
 		return nil, err
 	}
+	if options.Mtu > 0 {
+		outgoingMechanism.GetParameters()[connection.MtuKey] = strconv.FormatUint(uint64(options.Mtu), 10)
+	}
 
 	outgoingRequest := &networkservice.NetworkServiceRequest{
 		Connection: &connection.Connection{
-			NetworkService: nsmc.Configuration.OutgoingNscName,
+			NetworkService: networkService,
 			Context: &connectioncontext.ConnectionContext{
-				SrcIpRequired: true,
-				DstIpRequired: true,
+				SrcIpRequired:      options.SrcIpRequired,
+				DstIpRequired:      options.DstIpRequired,
+				ExtraPrefixRequest: extraPrefixRequests(options.ExtraPrefixes),
 			},
-			Labels: nsmc.OutgoingNscLabels,
+			Labels: labels,
 		},
 		MechanismPreferences: []*connection.Mechanism{
 			outgoingMechanism,
 		},
 	}
+	return nsmc.request(outgoingRequest)
+}
+
+func extraPrefixRequests(extraPrefixes []*tools.NsUrlExtraPrefixes) []*connectioncontext.ExtraPrefixRequest {
+	var result []*connectioncontext.ExtraPrefixRequest
+	for _, p := range extraPrefixes {
+		family := connectioncontext.IpFamily_IPV4
+		if p.IPv6 {
+			family = connectioncontext.IpFamily_IPV6
+		}
+		result = append(result, &connectioncontext.ExtraPrefixRequest{
+			AddrFamily:      &connectioncontext.IpFamily{Family: family},
+			PrefixLen:       p.PrefixLen,
+			RequiredNumber:  p.Number,
+			RequestedNumber: p.Number,
+		})
+	}
+	return result
+}
+
+// request sends the outgoing request with retries and remembers the connection, the caller should hold the lock
+func (nsmc *NsmClient) request(outgoingRequest *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	outgoingConnection, err := nsmc.send(outgoingRequest)
+	if err != nil {
+		return nil, err
+	}
+	nsmc.addConnection(outgoingConnection, outgoingRequest)
+	return outgoingConnection, nil
+}
+
+// addConnection remembers the outgoing connection and its request, the caller should hold the lock
+func (nsmc *NsmClient) addConnection(outgoingConnection *connection.Connection, outgoingRequest *networkservice.NetworkServiceRequest) {
+	nsmc.OutgoingConnections = append(nsmc.OutgoingConnections, outgoingConnection)
+	nsmc.requests[outgoingConnection.GetId()] = outgoingRequest
+}
+
+// send sends the outgoing request with retries, it does not change the client state, so the lock is not required
+func (nsmc *NsmClient) send(outgoingRequest *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	// All the retries share a single request id, so they could be found in NSM logs.
+	requestCtx, _ := tools.EnsureRequestID(nsmc.Context)
+	logger := tools.Log(requestCtx).WithField(tools.LogFieldNetworkService, outgoingRequest.GetConnection().GetNetworkService())
 
 	var outgoingConnection *connection.Connection
-	for iteration := connectRetries; true; <-time.After(connectSleep) {
+	for iteration := nsmc.connectRetries; true; <-time.After(nsmc.connectSleep) {
 		var err error
-		logrus.Infof("Sending outgoing request %v", outgoingRequest)
+		logger.Infof("Sending outgoing request %v", outgoingRequest)
 
-		ctx, cancel := context.WithTimeout(nsmc.Context, connectTimeout)
+		timeout := nsmc.connectTimeout
+		if nsmc.Options != nil && nsmc.Options.Timeout > 0 {
+			timeout = nsmc.Options.Timeout
+		}
+		ctx, cancel := context.WithTimeout(requestCtx, timeout)
 		defer cancel()
 		outgoingConnection, err = nsmc.NsClient.Request(ctx, outgoingRequest)
 
 		if err != nil {
-			logrus.Errorf("failure to request connection with error: %+v", err)
+			logger.Errorf("failure to request connection with error: %+v", err)
 			iteration--
 			if iteration > 0 {
 				continue
 			}
-			logrus.Errorf("Connect failed after %v iterations", connectRetries)
+			logger.Errorf("Connect failed after %v iterations", nsmc.connectRetries)
 			return nil, err
 		}
 
-		nsmc.OutgoingConnections = append(nsmc.OutgoingConnections, outgoingConnection)
-		logrus.Infof("Received outgoing connection: %v", outgoingConnection)
+		logger.WithField(tools.LogFieldConnectionID, outgoingConnection.GetId()).Infof("Received outgoing connection: %v", outgoingConnection)
 		break
 	}
 
@@ -101,16 +173,22 @@ func (nsmc *NsmClient) Close(outgoingConnection *connection.Connection) error {
 	defer nsmc.Unlock()
 
 	nsmc.NsClient.Close(nsmc.Context, outgoingConnection)
+	nsmc.removeConnection(outgoingConnection.GetId())
+	return nil
+}
 
+// removeConnection forgets the outgoing connection, the caller should hold the lock
+func (nsmc *NsmClient) removeConnection(id string) {
 	arr := nsmc.OutgoingConnections
 	for i, c := range arr {
-		if c == outgoingConnection {
+		if c.GetId() == id {
 			copy(arr[i:], arr[i+1:])
 			arr[len(arr)-1] = nil
-			arr = arr[:len(arr)-1]
+			nsmc.OutgoingConnections = arr[:len(arr)-1]
+			break
 		}
 	}
-	return nil
+	delete(nsmc.requests, id)
 }
 
 // Destroy stops the whole module
@@ -142,6 +220,10 @@ func NewNSMClient(ctx context.Context, configuration *common.NSConfiguration) (*
 		NsmConnection:     nsmConnection,
 		OutgoingNscName:   configuration.OutgoingNscName,
 		OutgoingNscLabels: tools.ParseKVStringToMap(configuration.OutgoingNscLabels, ",", "="),
+		requests:          map[string]*networkservice.NetworkServiceRequest{},
+		connectRetries:    connectRetries,
+		connectSleep:      connectSleep,
+		connectTimeout:    connectTimeout,
 	}
 
 	return client, nil
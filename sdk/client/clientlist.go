@@ -17,11 +17,13 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 )
@@ -31,8 +33,13 @@ const (
 )
 
 type NsmClientList struct {
-	clients     []*NsmClient
-	connections []*connection.Connection
+	entries []*nsmClientListEntry
+}
+
+type nsmClientListEntry struct {
+	client *NsmClient
+	// intf is an interface name requested in NsUrl, empty to use a name passed to Connect
+	intf string
 }
 
 func configFromUrl(configuration *common.NSConfiguration, url *tools.NsUrl) *common.NSConfiguration {
@@ -43,7 +50,7 @@ func configFromUrl(configuration *common.NSConfiguration, url *tools.NsUrl) *com
 	conf.OutgoingNscName = url.NsName
 	var labels strings.Builder
 	separator := false
-	for k, v := range url.Params {
+	for k, v := range url.Labels() {
 		if separator {
 			labels.WriteRune(',')
 		} else {
@@ -51,7 +58,7 @@ func configFromUrl(configuration *common.NSConfiguration, url *tools.NsUrl) *com
 		}
 		labels.WriteString(k)
 		labels.WriteRune('=')
-		labels.WriteString(v[0])
+		labels.WriteString(v)
 	}
 	conf.OutgoingNscLabels = labels.String()
 	return &conf
@@ -65,7 +72,7 @@ func NewNSMClientList(ctx context.Context, configuration *common.NSConfiguration
 			return nil, err
 		}
 		return &NsmClientList{
-			clients: []*NsmClient{client},
+			entries: []*nsmClientListEntry{{client: client}},
 		}, nil
 	}
 
@@ -75,26 +82,135 @@ func NewNSMClientList(ctx context.Context, configuration *common.NSConfiguration
 		return nil, err
 	}
 
-	var clients []*NsmClient
+	var entries []*nsmClientListEntry
 	for _, url := range urls {
+		options, err := url.Options()
+		if err != nil {
+			logrus.Errorf("Bad annotation value: %v", err)
+			return nil, err
+		}
 		client, err := NewNSMClient(ctx, configFromUrl(configuration, url))
 		if err != nil {
+			for _, entry := range entries {
+				entry.client.Destroy()
+			}
 			return nil, err
 		}
-		clients = append(clients, client)
+		client.Options = options
+		entries = append(entries, &nsmClientListEntry{
+			client: client,
+			intf:   url.Intf,
+		})
 	}
 	return &NsmClientList{
-		clients: clients,
+		entries: entries,
 	}, nil
 }
 
+// Connect connects all the network services one by one in the annotation order. If any of connections fails,
+// already established ones are closed.
 func (nsmc *NsmClientList) Connect(name, mechanism, description string) error {
-	for _, client := range nsmc.clients {
-		conn, err := client.Connect(name, mechanism, description)
-		if err != nil {
-			return err
+	return nsmc.connect(name, mechanism, description, false)
+}
+
+// ConnectParallel connects all the network services at the same time. If any of connections fails,
+// established ones are closed.
+func (nsmc *NsmClientList) ConnectParallel(name, mechanism, description string) error {
+	return nsmc.connect(name, mechanism, description, true)
+}
+
+func (nsmc *NsmClientList) connect(name, mechanism, description string, parallel bool) error {
+	connections := make([]*connection.Connection, len(nsmc.entries))
+	errs := make([]error, len(nsmc.entries))
+
+	intfs := nsmc.interfaceNames(name)
+	var wg sync.WaitGroup
+	for i, entry := range nsmc.entries {
+		intf := intfs[i]
+		if !parallel {
+			connections[i], errs[i] = entry.client.Connect(intf, mechanism, description)
+			if errs[i] != nil {
+				break
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, client *NsmClient, intf string) {
+			defer wg.Done()
+			connections[i], errs[i] = client.Connect(intf, mechanism, description)
+		}(i, entry.client, intf)
+	}
+	wg.Wait()
+
+	var err error
+	for i, e := range errs {
+		if e != nil {
+			logrus.Errorf("Failed to connect to %s: %v", nsmc.entries[i].client.OutgoingNscName, e)
+			err = e
+			break
+		}
+	}
+	if err != nil {
+		// Rollback connections established by this call
+		for i, conn := range connections {
+			if conn != nil {
+				logrus.Infof("Closing connection %s to %s", conn.GetId(), nsmc.entries[i].client.OutgoingNscName)
+				nsmc.entries[i].client.Close(conn)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// interfaceNames returns interface names of the entries: the one requested in NsUrl or the passed name. Interface
+// names should be unique, so index is added for all but the first connection, names requested in NsUrl are skipped.
+func (nsmc *NsmClientList) interfaceNames(name string) []string {
+	used := map[string]bool{}
+	for _, entry := range nsmc.entries {
+		if len(entry.intf) > 0 {
+			used[entry.intf] = true
+		}
+	}
+	intfs := make([]string, len(nsmc.entries))
+	for i, entry := range nsmc.entries {
+		if len(entry.intf) > 0 {
+			intfs[i] = entry.intf
+			continue
 		}
-		nsmc.connections = append(nsmc.connections, conn)
+		for index := i; len(intfs[i]) == 0; index++ {
+			intf := name
+			if index > 0 {
+				intf = fmt.Sprintf("%s%d", name, index)
+			}
+			if !used[intf] {
+				used[intf] = true
+				intfs[i] = intf
+			}
+		}
+	}
+	return intfs
+}
+
+// Monitor monitors connections of all the clients, see NsmClient.Monitor. It blocks until ctx is done.
+func (nsmc *NsmClientList) Monitor(ctx context.Context, handler ConnectionEventHandler) error {
+	errs := make(chan error, len(nsmc.entries))
+	for _, entry := range nsmc.entries {
+		go func(client *NsmClient) {
+			errs <- client.Monitor(ctx, handler)
+		}(entry.client)
+	}
+	var err error
+	for range nsmc.entries {
+		err = <-errs
+	}
+	return err
+}
+
+// Destroy closes all the connections and clients
+func (nsmc *NsmClientList) Destroy() error {
+	for _, entry := range nsmc.entries {
+		entry.client.Destroy()
 	}
 	return nil
 }
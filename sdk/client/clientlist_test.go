@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+type testNetworkServiceClient struct {
+	sync.Mutex
+	failing  map[string]bool
+	requests []*networkservice.NetworkServiceRequest
+	closed   []string
+}
+
+func (c *testNetworkServiceClient) Request(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*connection.Connection, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.requests = append(c.requests, in)
+	if c.failing[in.GetConnection().GetNetworkService()] {
+		return nil, fmt.Errorf("no endpoints for %s", in.GetConnection().GetNetworkService())
+	}
+	return &connection.Connection{
+		Id:             in.GetConnection().GetNetworkService(),
+		NetworkService: in.GetConnection().GetNetworkService(),
+		Mechanism:      in.GetMechanismPreferences()[0],
+	}, nil
+}
+
+func (c *testNetworkServiceClient) Close(ctx context.Context, in *connection.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.closed = append(c.closed, in.GetId())
+	return &empty.Empty{}, nil
+}
+
+func newTestClientList(t *testing.T, nsClient networkservice.NetworkServiceClient, annotation string) *NsmClientList {
+	urls, err := tools.ParseAnnotationValue(annotation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := &NsmClientList{}
+	for _, url := range urls {
+		conf := configFromUrl(nil, url)
+		options, err := url.Options()
+		if err != nil {
+			t.Fatal(err)
+		}
+		list.entries = append(list.entries, &nsmClientListEntry{
+			client: &NsmClient{
+				NsmConnection: &common.NsmConnection{
+					Context:       context.Background(),
+					Configuration: conf,
+					NsClient:      nsClient,
+				},
+				OutgoingNscName:   conf.OutgoingNscName,
+				OutgoingNscLabels: tools.ParseKVStringToMap(conf.OutgoingNscLabels, ",", "="),
+				Options:           options,
+				requests:          map[string]*networkservice.NetworkServiceRequest{},
+				connectRetries:    1,
+				connectTimeout:    connectTimeout,
+			},
+			intf: url.Intf,
+		})
+	}
+	return list
+}
+
+func connectionCount(list *NsmClientList) int {
+	count := 0
+	for _, entry := range list.entries {
+		count += len(entry.client.OutgoingConnections)
+	}
+	return count
+}
+
+func TestNsmClientListConnect(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		nsClient := &testNetworkServiceClient{}
+		list := newTestClientList(t, nsClient, "icmp/eth1?app=icmp&mechanism=mem,vpn,web")
+		if err := list.connect("nsm", "kernel", "Primary interface", parallel); err != nil {
+			t.Fatalf("parallel %v: Connect() error: %v", parallel, err)
+		}
+		if count := connectionCount(list); count != 3 {
+			t.Fatalf("parallel %v: got %d connections, want 3", parallel, count)
+		}
+
+		want := map[string]struct {
+			intf      string
+			mechanism connection.MechanismType
+			labels    map[string]string
+		}{
+			"icmp": {"eth1", connection.MechanismType_MEM_INTERFACE, map[string]string{"app": "icmp"}},
+			"vpn":  {"nsm1", connection.MechanismType_KERNEL_INTERFACE, map[string]string{}},
+			"web":  {"nsm2", connection.MechanismType_KERNEL_INTERFACE, map[string]string{}},
+		}
+		for _, request := range nsClient.requests {
+			w := want[request.GetConnection().GetNetworkService()]
+			mechanism := request.GetMechanismPreferences()[0]
+			if intf := mechanism.GetParameters()[connection.InterfaceNameKey]; intf != w.intf {
+				t.Errorf("parallel %v: %s interface = %s, want %s", parallel, request.GetConnection().GetNetworkService(), intf, w.intf)
+			}
+			if mechanism.GetType() != w.mechanism {
+				t.Errorf("parallel %v: %s mechanism = %v, want %v", parallel, request.GetConnection().GetNetworkService(), mechanism.GetType(), w.mechanism)
+			}
+			labels := request.GetConnection().GetLabels()
+			if _, ok := labels[tools.NsUrlMechanismParam]; ok || labels["app"] != w.labels["app"] {
+				t.Errorf("parallel %v: %s labels = %v, want %v", parallel, request.GetConnection().GetNetworkService(), request.GetConnection().GetLabels(), w.labels)
+			}
+		}
+	}
+}
+
+func TestNsmClientListConnectRollback(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		nsClient := &testNetworkServiceClient{failing: map[string]bool{"vpn": true}}
+		list := newTestClientList(t, nsClient, "icmp,vpn,web")
+		if err := list.connect("nsm", "kernel", "Primary interface", parallel); err == nil {
+			t.Fatalf("parallel %v: Connect() should fail", parallel)
+		}
+		if count := connectionCount(list); count != 0 {
+			t.Errorf("parallel %v: got %d connections, want 0", parallel, count)
+		}
+		// All the established connections should be closed
+		established := map[string]bool{}
+		for _, request := range nsClient.requests {
+			if !nsClient.failing[request.GetConnection().GetNetworkService()] {
+				established[request.GetConnection().GetNetworkService()] = true
+			}
+		}
+		if len(nsClient.closed) != len(established) {
+			t.Errorf("parallel %v: closed %v, want all of %v", parallel, nsClient.closed, established)
+		}
+		for _, id := range nsClient.closed {
+			if !established[id] {
+				t.Errorf("parallel %v: closed %s, which is not established", parallel, id)
+			}
+		}
+		for _, entry := range list.entries {
+			if len(entry.client.OutgoingConnections) != 0 {
+				t.Errorf("parallel %v: client %s has connections %v", parallel, entry.client.OutgoingNscName, entry.client.OutgoingConnections)
+			}
+		}
+		if !parallel && established["web"] {
+			t.Errorf("sequential connect should stop on the first failure")
+		}
+	}
+}
+
+func TestNsmClientListConnectOptions(t *testing.T) {
+	nsClient := &testNetworkServiceClient{}
+	list := newTestClientList(t, nsClient, "icmp/eth1?app=icmp&mtu=1400&extra_prefixes=2/32&extra_prefixes=1/64/ipv6&src_ip_required=false&timeout=1s")
+	if err := list.Connect("nsm", "kernel", "Primary interface"); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+
+	request := nsClient.requests[0]
+	if labels := request.GetConnection().GetLabels(); len(labels) != 1 || labels["app"] != "icmp" {
+		t.Errorf("labels = %v, want only app=icmp", labels)
+	}
+	if mtu := request.GetMechanismPreferences()[0].GetMtu(); mtu != 1400 {
+		t.Errorf("mtu = %d, want 1400", mtu)
+	}
+	ctx := request.GetConnection().GetContext()
+	if ctx.GetSrcIpRequired() || !ctx.GetDstIpRequired() {
+		t.Errorf("src_ip_required = %v, dst_ip_required = %v, want false, true", ctx.GetSrcIpRequired(), ctx.GetDstIpRequired())
+	}
+	want := []*connectioncontext.ExtraPrefixRequest{
+		{AddrFamily: &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV4}, PrefixLen: 32, RequiredNumber: 2, RequestedNumber: 2},
+		{AddrFamily: &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV6}, PrefixLen: 64, RequiredNumber: 1, RequestedNumber: 1},
+	}
+	if len(ctx.GetExtraPrefixRequest()) != len(want) {
+		t.Fatalf("extra prefix requests = %v, want %v", ctx.GetExtraPrefixRequest(), want)
+	}
+	for i := range want {
+		if !proto.Equal(ctx.GetExtraPrefixRequest()[i], want[i]) {
+			t.Errorf("extra prefix request %d = %v, want %v", i, ctx.GetExtraPrefixRequest()[i], want[i])
+		}
+		if err := ctx.GetExtraPrefixRequest()[i].IsValid(); err != nil {
+			t.Errorf("extra prefix request %d is invalid: %v", i, err)
+		}
+	}
+}
+
+func TestNsmClientListInterfaceNames(t *testing.T) {
+	for annotation, want := range map[string][]string{
+		"a,b,c":         {"nsm", "nsm1", "nsm2"},
+		"a/nsm2,b,c":    {"nsm2", "nsm1", "nsm3"},
+		"a,b/nsm,c/eth": {"nsm1", "nsm", "eth"},
+	} {
+		list := newTestClientList(t, &testNetworkServiceClient{}, annotation)
+		if intfs := list.interfaceNames("nsm"); !reflect.DeepEqual(intfs, want) {
+			t.Errorf("%s: interface names = %v, want %v", annotation, intfs, want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2019 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	monitorRetryDelay = 1 * time.Second
+)
+
+// ConnectionState is a state of an outgoing connection observed by the monitoring client
+type ConnectionState int
+
+const (
+	// ConnectionUp - connection is established
+	ConnectionUp ConnectionState = iota
+	// ConnectionDown - connection is down, NSM is healing it
+	ConnectionDown
+	// ConnectionLost - connection is deleted by NSM, client is going to request it again
+	ConnectionLost
+	// ConnectionRestored - lost connection is requested again
+	ConnectionRestored
+	// ConnectionFailed - lost connection could not be requested again, client will retry
+	ConnectionFailed
+	// ConnectionClosed - connection is closed
+	ConnectionClosed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionUp:
+		return "UP"
+	case ConnectionDown:
+		return "DOWN"
+	case ConnectionLost:
+		return "LOST"
+	case ConnectionRestored:
+		return "RESTORED"
+	case ConnectionFailed:
+		return "FAILED"
+	case ConnectionClosed:
+		return "CLOSED"
+	}
+	return "UNKNOWN"
+}
+
+// ConnectionEvent is a change of an outgoing connection state
+type ConnectionEvent struct {
+	State      ConnectionState
+	Connection *connection.Connection
+	Error      error
+}
+
+// ConnectionEventHandler is called for every outgoing connection state change
+type ConnectionEventHandler func(event *ConnectionEvent)
+
+// Monitor subscribes to the connection monitor of NSM workspace and calls handler on outgoing connection
+// state changes. Connections deleted by NSM are requested again with the same connection id, so NSM could heal them.
+// Monitor blocks until ctx is done, monitor stream is re-established if it is broken and resumed from the last received event.
+func (nsmc *NsmClient) Monitor(ctx context.Context, handler ConnectionEventHandler) error {
+	if handler == nil {
+		handler = func(*ConnectionEvent) {}
+	}
+	monitorClient := connection.NewMonitorConnectionClient(nsmc.GrpcClient)
+	// The last received event, the stream is resumed from it
+	resume := &connection.MonitorRequest{}
+	for {
+		err := nsmc.monitor(ctx, monitorClient, handler, resume)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logrus.Errorf("Connection monitor failed: %v, re-establishing in %v", err, monitorRetryDelay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(monitorRetryDelay):
+		}
+	}
+}
+
+// MonitorEvents is same as Monitor, but passes connection events to the returned channel, which is closed once ctx is done
+func (nsmc *NsmClient) MonitorEvents(ctx context.Context) <-chan *ConnectionEvent {
+	events := make(chan *ConnectionEvent, 10)
+	go func() {
+		defer close(events)
+		_ = nsmc.Monitor(ctx, func(event *ConnectionEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+func (nsmc *NsmClient) monitor(ctx context.Context, monitorClient connection.MonitorConnectionClient, handler ConnectionEventHandler, resume *connection.MonitorRequest) error {
+	stream, err := monitorClient.MonitorConnections(ctx, resume)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Connection monitor event: %v", event)
+		resume.ResumeFromServerId = event.GetServerId()
+		resume.ResumeFromSequence = event.GetSequence()
+
+		switch event.GetType() {
+		case connection.ConnectionEventType_INITIAL_STATE_TRANSFER:
+			nsmc.updateConnections(event.GetConnections(), handler)
+			// Connections deleted while we were not monitoring
+			for _, c := range nsmc.outgoingConnections() {
+				if _, ok := event.GetConnections()[c.GetId()]; !ok {
+					nsmc.reconnect(ctx, c, handler)
+				}
+			}
+		case connection.ConnectionEventType_UPDATE:
+			nsmc.updateConnections(event.GetConnections(), handler)
+		case connection.ConnectionEventType_DELETE:
+			for _, c := range event.GetConnections() {
+				if outgoing := nsmc.outgoingConnection(c.GetId()); outgoing != nil {
+					nsmc.reconnect(ctx, outgoing, handler)
+				}
+			}
+		}
+	}
+}
+
+func (nsmc *NsmClient) updateConnections(connections map[string]*connection.Connection, handler ConnectionEventHandler) {
+	for _, c := range connections {
+		if nsmc.outgoingConnection(c.GetId()) == nil {
+			continue
+		}
+		state := ConnectionUp
+		if c.GetState() == connection.State_DOWN {
+			state = ConnectionDown
+		}
+		handler(&ConnectionEvent{
+			State:      state,
+			Connection: c,
+		})
+	}
+}
+
+func (nsmc *NsmClient) reconnect(ctx context.Context, lost *connection.Connection, handler ConnectionEventHandler) {
+	handler(&ConnectionEvent{
+		State:      ConnectionLost,
+		Connection: lost,
+	})
+	for {
+		restored, err := nsmc.reRequest(lost)
+		if err == nil {
+			handler(&ConnectionEvent{
+				State:      ConnectionRestored,
+				Connection: restored,
+			})
+			return
+		}
+		handler(&ConnectionEvent{
+			State:      ConnectionFailed,
+			Connection: lost,
+			Error:      err,
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(nsmc.connectSleep):
+		}
+	}
+}
+
+// reRequest requests the lost connection again, the lock is not held while NSM is requested, so Close and
+// the monitor are not blocked by the request retries.
+func (nsmc *NsmClient) reRequest(lost *connection.Connection) (*connection.Connection, error) {
+	nsmc.Lock()
+	request, ok := nsmc.requests[lost.GetId()]
+	if ok {
+		// The request is kept while NSM is requested, Close removes it if the connection is closed meanwhile
+		nsmc.removeConnection(lost.GetId())
+		nsmc.requests[lost.GetId()] = request
+	}
+	nsmc.Unlock()
+	if !ok {
+		// Connection is closed by us meanwhile
+		return lost, nil
+	}
+
+	request = proto.Clone(request).(*networkservice.NetworkServiceRequest)
+	request.Connection.Id = lost.GetId()
+	restored, err := nsmc.send(request)
+
+	nsmc.Lock()
+	defer nsmc.Unlock()
+
+	if _, ok := nsmc.requests[lost.GetId()]; !ok {
+		// Connection is closed by us meanwhile
+		if err == nil {
+			nsmc.NsClient.Close(nsmc.Context, restored)
+		}
+		return lost, nil
+	}
+	if err != nil {
+		// Keep the request to try again later
+		nsmc.OutgoingConnections = append(nsmc.OutgoingConnections, lost)
+		nsmc.requests[lost.GetId()] = request
+		return nil, err
+	}
+	delete(nsmc.requests, lost.GetId())
+	nsmc.addConnection(restored, request)
+	return restored, nil
+}
+
+func (nsmc *NsmClient) outgoingConnection(id string) *connection.Connection {
+	nsmc.RLock()
+	defer nsmc.RUnlock()
+	for _, c := range nsmc.OutgoingConnections {
+		if c.GetId() == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (nsmc *NsmClient) outgoingConnections() []*connection.Connection {
+	nsmc.RLock()
+	defer nsmc.RUnlock()
+	return append([]*connection.Connection{}, nsmc.OutgoingConnections...)
+}
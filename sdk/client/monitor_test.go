@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+	. "github.com/onsi/gomega"
+)
+
+// blockingNetworkServiceClient blocks Request until unblocked
+type blockingNetworkServiceClient struct {
+	testNetworkServiceClient
+	entered chan struct{}
+	unblock chan struct{}
+}
+
+func (c *blockingNetworkServiceClient) Request(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*connection.Connection, error) {
+	c.entered <- struct{}{}
+	<-c.unblock
+	return c.testNetworkServiceClient.Request(ctx, in, opts...)
+}
+
+func TestReRequestDoesNotBlockClose(t *testing.T) {
+	RegisterTestingT(t)
+
+	nsClient := &blockingNetworkServiceClient{
+		entered: make(chan struct{}, 1),
+		unblock: make(chan struct{}),
+	}
+	lost := &connection.Connection{Id: "ns1", NetworkService: "ns1"}
+	nsmc := &NsmClient{
+		NsmConnection: &common.NsmConnection{
+			Context:  context.Background(),
+			NsClient: nsClient,
+		},
+		OutgoingConnections: []*connection.Connection{lost},
+		requests: map[string]*networkservice.NetworkServiceRequest{
+			"ns1": {
+				Connection:           &connection.Connection{NetworkService: "ns1"},
+				MechanismPreferences: []*connection.Mechanism{{}},
+			},
+		},
+		connectRetries: 1,
+		connectTimeout: connectTimeout,
+	}
+
+	type result struct {
+		conn *connection.Connection
+		err  error
+	}
+	done := make(chan result)
+	go func() {
+		conn, err := nsmc.reRequest(lost)
+		done <- result{conn, err}
+	}()
+	<-nsClient.entered
+
+	// Connection is closed while it is requested again
+	closed := make(chan error)
+	go func() {
+		closed <- nsmc.Close(lost)
+	}()
+	Eventually(closed, time.Second).Should(Receive(BeNil()))
+
+	close(nsClient.unblock)
+	var r result
+	Eventually(done, time.Second).Should(Receive(&r))
+	Expect(r.err).To(BeNil())
+	Expect(r.conn).To(Equal(lost))
+
+	// Restored connection is closed as well and is not remembered
+	Expect(nsmc.outgoingConnections()).To(BeEmpty())
+	Expect(nsmc.requests).To(BeEmpty())
+	Expect(nsClient.closed).To(Equal([]string{"ns1", "ns1"}))
+}
@@ -19,30 +19,39 @@ import (
 	"strconv"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/sirupsen/logrus"
 )
 
 const (
-	advertiseNseNameEnv   = "ADVERTISE_NSE_NAME"
-	advertiseNseLabelsEnv = "ADVERTISE_NSE_LABELS"
-	outgoingNscNameEnv    = "OUTGOING_NSC_NAME"
-	outgoingNscLabelsEnv  = "OUTGOING_NSC_LABELS"
-	tracerEnabled         = "TRACER_ENABLED"
-	mechanismTypeEnv      = "MECHANISM_TYPE"
-	ipAddressEnv          = "IP_ADDRESS"
+	advertiseNseNameEnv      = "ADVERTISE_NSE_NAME"
+	advertiseNseLabelsEnv    = "ADVERTISE_NSE_LABELS"
+	advertiseNseCapacityEnv  = "ADVERTISE_NSE_CAPACITY"
+	advertiseNseMetadataEnv  = "ADVERTISE_NSE_METADATA"
+	outgoingNscNameEnv       = "OUTGOING_NSC_NAME"
+	outgoingNscLabelsEnv     = "OUTGOING_NSC_LABELS"
+	tracerEnabled            = "TRACER_ENABLED"
+	mechanismTypeEnv         = "MECHANISM_TYPE"
+	ipAddressEnv             = "IP_ADDRESS"
+	aclRulesFileEnv          = "ACL_RULES_FILE"
+	monitorOutgoingActionEnv = "MONITOR_OUTGOING_ACTION"
 )
 
 // NSConfiguration contains the full configuration used in the SDK
 type NSConfiguration struct {
-	NsmServerSocket    string
-	NsmClientSocket    string
-	Workspace          string
-	AdvertiseNseName   string
-	OutgoingNscName    string
-	AdvertiseNseLabels string
-	OutgoingNscLabels  string
-	TracerEnabled      bool
-	MechanismType      string
-	IPAddress          string
+	NsmServerSocket       string
+	NsmClientSocket       string
+	Workspace             string
+	AdvertiseNseName      string
+	OutgoingNscName       string
+	AdvertiseNseLabels    string
+	OutgoingNscLabels     string
+	TracerEnabled         bool
+	MechanismType         string
+	IPAddress             string
+	AdvertiseNseCapacity  uint32
+	AdvertiseNseMetadata  string
+	AclRulesFile          string
+	MonitorOutgoingAction string
 }
 
 // CompleteNSConfiguration fills all unset options from the env variables
@@ -72,6 +81,20 @@ func (configuration *NSConfiguration) CompleteNSConfiguration() {
 		configuration.AdvertiseNseLabels = getEnv(advertiseNseLabelsEnv, "Advertise labels", false)
 	}
 
+	if configuration.AdvertiseNseCapacity == 0 {
+		if capacity := getEnv(advertiseNseCapacityEnv, "Advertise capacity", false); len(capacity) > 0 {
+			value, err := strconv.ParseUint(capacity, 10, 32)
+			if err != nil {
+				logrus.Fatalf("Invalid %s value: %v", advertiseNseCapacityEnv, err)
+			}
+			configuration.AdvertiseNseCapacity = uint32(value)
+		}
+	}
+
+	if len(configuration.AdvertiseNseMetadata) == 0 {
+		configuration.AdvertiseNseMetadata = getEnv(advertiseNseMetadataEnv, "Advertise metadata", false)
+	}
+
 	if len(configuration.OutgoingNscLabels) == 0 {
 		configuration.OutgoingNscLabels = getEnv(outgoingNscLabelsEnv, "Outgoing labels", false)
 	}
@@ -85,4 +108,12 @@ func (configuration *NSConfiguration) CompleteNSConfiguration() {
 	if len(configuration.IPAddress) == 0 {
 		configuration.IPAddress = getEnv(ipAddressEnv, "IP Address", false)
 	}
+
+	if len(configuration.AclRulesFile) == 0 {
+		configuration.AclRulesFile = getEnv(aclRulesFileEnv, "ACL rules file", false)
+	}
+
+	if len(configuration.MonitorOutgoingAction) == 0 {
+		configuration.MonitorOutgoingAction = getEnv(monitorOutgoingActionEnv, "Monitor outgoing connection action", false)
+	}
 }
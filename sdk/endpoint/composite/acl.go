@@ -0,0 +1,410 @@
+// Copyright 2018, 2019 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultVPPAgentEndpoint = "localhost:9112"
+	// aclReloadInterval is an interval of checking the ACL rules file for changes
+	aclReloadInterval = 5 * time.Second
+)
+
+// AclRules are ACL rules keyed by the rule name, rules have the format of converter.NewAclConverter,
+// e.g. "action=reflect,tcplowport=80,tcpupport=80"
+type AclRules map[string]string
+
+// ConnectionAclConfig are ACL rules applied to connections requested with labels matching the selector
+type ConnectionAclConfig struct {
+	Selector map[string]string `json:"selector"`
+	Ingress  AclRules          `json:"ingress,omitempty"`
+	Egress   AclRules          `json:"egress,omitempty"`
+}
+
+// AclConfig is a configuration of the ACL composite
+type AclConfig struct {
+	// Ingress rules are applied to the traffic coming from the client
+	Ingress AclRules `json:"ingress,omitempty"`
+	// Egress rules are applied to the traffic going to the client
+	Egress AclRules `json:"egress,omitempty"`
+	// Connections are additional per connection rules
+	Connections []*ConnectionAclConfig `json:"connections,omitempty"`
+}
+
+// ParseAclConfig parses and validates YAML or JSON ACL configuration
+func ParseAclConfig(data []byte) (*AclConfig, error) {
+	config := &AclConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	for _, rules := range config.allRules() {
+		if _, err := converter.NewAclConverter("validate", "validate", rules).ToDataRequest(nil, true); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+func (c *AclConfig) allRules() []AclRules {
+	rv := []AclRules{c.Ingress, c.Egress}
+	for _, connectionConfig := range c.Connections {
+		rv = append(rv, connectionConfig.Ingress, connectionConfig.Egress)
+	}
+	return rv
+}
+
+// rules returns ingress and egress rules of the connection requested with labels
+func (c *AclConfig) rules(labels map[string]string) (ingress, egress AclRules) {
+	ingress, egress = AclRules{}, AclRules{}
+	merge := func(to, from AclRules) {
+		for name, rule := range from {
+			to[name] = rule
+		}
+	}
+	merge(ingress, c.Ingress)
+	merge(egress, c.Egress)
+	for _, connectionConfig := range c.Connections {
+		if isSubset(connectionConfig.Selector, labels) {
+			merge(ingress, connectionConfig.Ingress)
+			merge(egress, connectionConfig.Egress)
+		}
+	}
+	return ingress, egress
+}
+
+func isSubset(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// aclConnection keeps ACL rules applied to the connection interface, the lock is held while vppagent is updated,
+// so the endpoint lock is not held during gRPC calls.
+type aclConnection struct {
+	sync.Mutex
+	ifName  string
+	labels  map[string]string
+	ingress AclRules
+	egress  AclRules
+	closed  bool
+}
+
+// AclCompositeEndpoint applies VPP ACLs to the interface of the incoming connection.
+// The interface name is expected from the next composite GetOpaque.
+type AclCompositeEndpoint struct {
+	endpoint.BaseCompositeEndpoint
+	vppAgentEndpoint string
+	rulesFile        string
+	rulesData        []byte
+	// dataChange sends the data request to vppagent, it is replaced in tests
+	dataChange func(ctx context.Context, dataRequest *rpc.DataRequest, put bool) error
+
+	sync.Mutex
+	config      *AclConfig
+	connections map[string]*aclConnection
+}
+
+// Request imeplements the request handler
+func (ace *AclCompositeEndpoint) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	if ace.GetNext() == nil {
+		err := fmt.Errorf("ACL needs next")
+		tools.Log(ctx).Errorf("%v", err)
+		return nil, err
+	}
+
+	incoming, err := ace.GetNext().Request(ctx, request)
+	if err != nil {
+		tools.Log(ctx).Errorf("Next request failed: %v", err)
+		return nil, err
+	}
+
+	ifName, ok := ace.GetNext().GetOpaque(incoming).(string)
+	if !ok {
+		err := fmt.Errorf("ACL: unable to find the interface name of connection %s", incoming.GetId())
+		tools.Log(ctx).Errorf("%v", err)
+		ace.closeNext(ctx, incoming)
+		return nil, err
+	}
+
+	aclConn := ace.connection(incoming.GetId(), ifName)
+	aclConn.Lock()
+	defer aclConn.Unlock()
+
+	if aclConn.closed {
+		err := fmt.Errorf("ACL: connection %s is closed during the request", incoming.GetId())
+		tools.Log(ctx).Errorf("%v", err)
+		return nil, err
+	}
+	aclConn.labels = request.GetConnection().GetLabels()
+	if err := ace.apply(ctx, incoming.GetId(), aclConn); err != nil {
+		tools.Log(ctx).Errorf("Failed to apply ACL: %v", err)
+		if err := ace.remove(ctx, incoming.GetId(), aclConn); err != nil {
+			tools.Log(ctx).Errorf("Failed to remove ACL: %v", err)
+		}
+		aclConn.closed = true
+		ace.deleteConnection(incoming.GetId(), aclConn)
+		ace.closeNext(ctx, incoming)
+		return nil, err
+	}
+
+	return incoming, nil
+}
+
+// closeNext closes the connection requested from the next composite when the request fails afterwards
+func (ace *AclCompositeEndpoint) closeNext(ctx context.Context, incoming *connection.Connection) {
+	if _, err := ace.GetNext().Close(ctx, incoming); err != nil {
+		tools.Log(ctx).Errorf("Failed to close connection %s: %v", incoming.GetId(), err)
+	}
+}
+
+// Close imeplements the close handler
+func (ace *AclCompositeEndpoint) Close(ctx context.Context, conn *connection.Connection) (*empty.Empty, error) {
+	ace.Lock()
+	aclConn, ok := ace.connections[conn.GetId()]
+	delete(ace.connections, conn.GetId())
+	ace.Unlock()
+
+	if ok {
+		aclConn.Lock()
+		if err := ace.remove(ctx, conn.GetId(), aclConn); err != nil {
+			tools.Log(ctx).Errorf("Failed to remove ACL: %v", err)
+		}
+		aclConn.closed = true
+		aclConn.Unlock()
+	}
+
+	if ace.GetNext() != nil {
+		return ace.GetNext().Close(ctx, conn)
+	}
+	return &empty.Empty{}, nil
+}
+
+// GetOpaque passes the next composite opaque data through
+func (ace *AclCompositeEndpoint) GetOpaque(incoming interface{}) interface{} {
+	if ace.GetNext() != nil {
+		return ace.GetNext().GetOpaque(incoming)
+	}
+	return nil
+}
+
+// SetConfig replaces the ACL configuration and updates ACLs of all the existing connections
+func (ace *AclCompositeEndpoint) SetConfig(config *AclConfig) {
+	ace.Lock()
+	ace.config = config
+	connections := make(map[string]*aclConnection, len(ace.connections))
+	for id, aclConn := range ace.connections {
+		connections[id] = aclConn
+	}
+	ace.Unlock()
+
+	for id, aclConn := range connections {
+		aclConn.Lock()
+		if !aclConn.closed {
+			if err := ace.apply(context.Background(), id, aclConn); err != nil {
+				logrus.Errorf("Failed to update ACL of connection %s: %v", id, err)
+			}
+		}
+		aclConn.Unlock()
+	}
+}
+
+// connection returns the connection with id, a new one is created if the interface is changed.
+func (ace *AclCompositeEndpoint) connection(id, ifName string) *aclConnection {
+	ace.Lock()
+	defer ace.Unlock()
+
+	if existing, ok := ace.connections[id]; ok && existing.ifName == ifName {
+		return existing
+	}
+	aclConn := &aclConnection{
+		ifName: ifName,
+	}
+	ace.connections[id] = aclConn
+	return aclConn
+}
+
+// deleteConnection deletes the connection with id, if it is not replaced by another one.
+func (ace *AclCompositeEndpoint) deleteConnection(id string, aclConn *aclConnection) {
+	ace.Lock()
+	defer ace.Unlock()
+
+	if ace.connections[id] == aclConn {
+		delete(ace.connections, id)
+	}
+}
+
+func (ace *AclCompositeEndpoint) getConfig() *AclConfig {
+	ace.Lock()
+	defer ace.Unlock()
+	return ace.config
+}
+
+func ingressAclName(id string) string {
+	return "ingress-" + id
+}
+
+func egressAclName(id string) string {
+	return "egress-" + id
+}
+
+// apply updates ACLs of the connection with the current configuration if rules are changed, connection stores
+// the applied rules. It is called with the connection locked.
+func (ace *AclCompositeEndpoint) apply(ctx context.Context, id string, aclConn *aclConnection) error {
+	ingress, egress := ace.getConfig().rules(aclConn.labels)
+	if err := ace.update(ctx, converter.NewAclConverter, ingressAclName(id), aclConn.ifName, aclConn.ingress, ingress); err != nil {
+		return err
+	}
+	aclConn.ingress = ingress
+	if err := ace.update(ctx, converter.NewEgressAclConverter, egressAclName(id), aclConn.ifName, aclConn.egress, egress); err != nil {
+		return err
+	}
+	aclConn.egress = egress
+	return nil
+}
+
+func (ace *AclCompositeEndpoint) remove(ctx context.Context, id string, aclConn *aclConnection) error {
+	if err := ace.update(ctx, converter.NewAclConverter, ingressAclName(id), aclConn.ifName, aclConn.ingress, nil); err != nil {
+		return err
+	}
+	return ace.update(ctx, converter.NewEgressAclConverter, egressAclName(id), aclConn.ifName, aclConn.egress, nil)
+}
+
+func (ace *AclCompositeEndpoint) update(ctx context.Context, newConverter func(name, ifName string, rules map[string]string) converter.Converter,
+	name, ifName string, applied, rules AclRules) error {
+	if len(applied) == 0 && len(rules) == 0 || reflect.DeepEqual(applied, rules) {
+		return nil
+	}
+	if len(rules) == 0 {
+		dataRequest, err := newConverter(name, ifName, applied).ToDataRequest(nil, false)
+		if err != nil {
+			return err
+		}
+		return ace.dataChange(ctx, dataRequest, false)
+	}
+	dataRequest, err := newConverter(name, ifName, rules).ToDataRequest(nil, true)
+	if err != nil {
+		return err
+	}
+	return ace.dataChange(ctx, dataRequest, true)
+}
+
+func (ace *AclCompositeEndpoint) sendDataChange(ctx context.Context, dataRequest *rpc.DataRequest, put bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+	tools.WaitForPortAvailable(ctx, "tcp", ace.vppAgentEndpoint, 100*time.Millisecond)
+	tracer := opentracing.GlobalTracer()
+	conn, err := grpc.Dial(ace.vppAgentEndpoint, grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(
+			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
+		grpc.WithStreamInterceptor(
+			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	if err != nil {
+		logrus.Errorf("can't dial grpc server: %v", err)
+		return err
+	}
+	defer conn.Close()
+	client := rpc.NewDataChangeServiceClient(conn)
+
+	logrus.Infof("Sending DataChange to vppagent: %v", dataRequest)
+	if put {
+		_, err = client.Put(ctx, dataRequest)
+	} else {
+		_, err = client.Del(ctx, dataRequest)
+	}
+	return err
+}
+
+// reload checks the rules file and applies the changed rules, invalid rules are ignored.
+func (ace *AclCompositeEndpoint) reload() {
+	data, err := ioutil.ReadFile(ace.rulesFile)
+	if err != nil {
+		logrus.Errorf("Failed to read ACL rules file %s: %v", ace.rulesFile, err)
+		return
+	}
+	if bytes.Equal(data, ace.rulesData) {
+		return
+	}
+	ace.rulesData = data
+	config, err := ParseAclConfig(data)
+	if err != nil {
+		logrus.Errorf("Invalid ACL rules file %s: %v", ace.rulesFile, err)
+		return
+	}
+	logrus.Infof("ACL rules are loaded from %s: %v", ace.rulesFile, config)
+	ace.SetConfig(config)
+}
+
+func (ace *AclCompositeEndpoint) watchRulesFile() {
+	for range time.Tick(aclReloadInterval) {
+		ace.reload()
+	}
+}
+
+// NewAclCompositeEndpoint creates an AclCompositeEndpoint.
+// Rules are loaded from the configured rules file, which could be a mounted ConfigMap, and are reloaded on change.
+// defaultConfig is used if the rules file is not configured.
+func NewAclCompositeEndpoint(configuration *common.NSConfiguration, defaultConfig *AclConfig) *AclCompositeEndpoint {
+	// ensure the env variables are processed
+	if configuration == nil {
+		configuration = &common.NSConfiguration{}
+	}
+	configuration.CompleteNSConfiguration()
+
+	if defaultConfig == nil {
+		defaultConfig = &AclConfig{}
+	}
+
+	self := &AclCompositeEndpoint{
+		vppAgentEndpoint: defaultVPPAgentEndpoint,
+		rulesFile:        configuration.AclRulesFile,
+		config:           defaultConfig,
+		connections:      map[string]*aclConnection{},
+	}
+	self.dataChange = self.sendDataChange
+	self.SetSelf(self)
+
+	if self.rulesFile != "" {
+		self.reload()
+		go self.watchRulesFile()
+	}
+
+	return self
+}
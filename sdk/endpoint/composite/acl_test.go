@@ -0,0 +1,242 @@
+package composite
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/acl"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
+	. "github.com/onsi/gomega"
+)
+
+const testAclConfig = `
+ingress:
+  Allow ICMP: action=reflect,icmptype=8
+egress:
+  Deny all: action=deny
+connections:
+  - selector:
+      app: web
+    ingress:
+      Allow TCP 80: action=reflect,tcplowport=80,tcpupport=80
+`
+
+type testInterfaceEndpoint struct {
+	endpoint.BaseCompositeEndpoint
+	closed []string
+}
+
+func (e *testInterfaceEndpoint) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	return request.GetConnection(), nil
+}
+
+func (e *testInterfaceEndpoint) Close(ctx context.Context, conn *connection.Connection) (*empty.Empty, error) {
+	e.closed = append(e.closed, conn.GetId())
+	return &empty.Empty{}, nil
+}
+
+func (e *testInterfaceEndpoint) GetOpaque(incoming interface{}) interface{} {
+	return "SRC-" + incoming.(*connection.Connection).GetId()
+}
+
+type testDataChange struct {
+	put bool
+	acl *acl.AccessLists_Acl
+}
+
+func newTestAclEndpoint(config *AclConfig) (*AclCompositeEndpoint, *testInterfaceEndpoint, *[]testDataChange) {
+	changes := &[]testDataChange{}
+	ace := &AclCompositeEndpoint{
+		config:      config,
+		connections: map[string]*aclConnection{},
+		dataChange: func(ctx context.Context, dataRequest *rpc.DataRequest, put bool) error {
+			for _, accessList := range dataRequest.AccessLists {
+				*changes = append(*changes, testDataChange{put: put, acl: accessList})
+			}
+			return nil
+		},
+	}
+	ace.SetSelf(ace)
+	next := &testInterfaceEndpoint{}
+	next.SetSelf(next)
+	ace.SetNext(next)
+	return ace, next, changes
+}
+
+func request(id string, labels map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{
+			Id:     id,
+			Labels: labels,
+		},
+	}
+}
+
+func TestParseAclConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	config, err := ParseAclConfig([]byte(testAclConfig))
+	Expect(err).To(BeNil())
+	Expect(config.Ingress).To(Equal(AclRules{"Allow ICMP": "action=reflect,icmptype=8"}))
+	Expect(config.Egress).To(Equal(AclRules{"Deny all": "action=deny"}))
+	Expect(config.Connections).To(HaveLen(1))
+
+	jsonConfig, err := ParseAclConfig([]byte(`{"ingress": {"Allow ICMP": "action=reflect,icmptype=8"}}`))
+	Expect(err).To(BeNil())
+	Expect(jsonConfig.Ingress).To(Equal(config.Ingress))
+
+	_, err = ParseAclConfig([]byte(`{"egress": {"Bad": "action=drop"}}`))
+	Expect(err).NotTo(BeNil())
+}
+
+func TestAclConfigRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	config, err := ParseAclConfig([]byte(testAclConfig))
+	Expect(err).To(BeNil())
+
+	ingress, egress := config.rules(map[string]string{"app": "web", "zone": "a"})
+	Expect(ingress).To(HaveLen(2))
+	Expect(ingress).To(HaveKey("Allow TCP 80"))
+	Expect(egress).To(Equal(AclRules{"Deny all": "action=deny"}))
+
+	ingress, _ = config.rules(map[string]string{"app": "db"})
+	Expect(ingress).To(Equal(AclRules{"Allow ICMP": "action=reflect,icmptype=8"}))
+}
+
+func TestAclCompositeRequestClose(t *testing.T) {
+	RegisterTestingT(t)
+
+	config, err := ParseAclConfig([]byte(testAclConfig))
+	Expect(err).To(BeNil())
+	ace, next, changes := newTestAclEndpoint(config)
+
+	_, err = ace.Request(context.Background(), request("1", map[string]string{"app": "web"}))
+	Expect(err).To(BeNil())
+	Expect(*changes).To(HaveLen(2))
+	Expect((*changes)[0].put).To(BeTrue())
+	Expect((*changes)[0].acl.AclName).To(Equal("ingress-1"))
+	Expect((*changes)[0].acl.Rules).To(HaveLen(2))
+	Expect((*changes)[0].acl.Interfaces.Ingress).To(Equal([]string{"SRC-1"}))
+	Expect((*changes)[1].acl.AclName).To(Equal("egress-1"))
+	Expect((*changes)[1].acl.Interfaces.Egress).To(Equal([]string{"SRC-1"}))
+	Expect((*changes)[1].acl.Interfaces.Ingress).To(BeEmpty())
+
+	*changes = nil
+	_, err = ace.Close(context.Background(), &connection.Connection{Id: "1"})
+	Expect(err).To(BeNil())
+	Expect(*changes).To(HaveLen(2))
+	Expect((*changes)[0].put).To(BeFalse())
+	Expect((*changes)[1].put).To(BeFalse())
+	Expect(ace.connections).To(BeEmpty())
+	Expect(next.closed).To(Equal([]string{"1"}))
+}
+
+func TestAclCompositeApplyFailureClosesNext(t *testing.T) {
+	RegisterTestingT(t)
+
+	config, err := ParseAclConfig([]byte(testAclConfig))
+	Expect(err).To(BeNil())
+	ace, next, _ := newTestAclEndpoint(config)
+	ace.dataChange = func(ctx context.Context, dataRequest *rpc.DataRequest, put bool) error {
+		if put {
+			return fmt.Errorf("vppagent is not available")
+		}
+		return nil
+	}
+
+	_, err = ace.Request(context.Background(), request("1", map[string]string{"app": "web"}))
+	Expect(err).NotTo(BeNil())
+	Expect(ace.connections).To(BeEmpty())
+	Expect(next.closed).To(Equal([]string{"1"}))
+}
+
+func TestAclCompositeReload(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "acl")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	ace, _, changes := newTestAclEndpoint(&AclConfig{})
+	ace.rulesFile = path.Join(dir, "config.yaml")
+
+	_, err = ace.Request(context.Background(), request("1", map[string]string{"app": "web"}))
+	Expect(err).To(BeNil())
+	_, err = ace.Request(context.Background(), request("2", nil))
+	Expect(err).To(BeNil())
+	Expect(*changes).To(BeEmpty())
+
+	Expect(ioutil.WriteFile(ace.rulesFile, []byte(testAclConfig), 0644)).To(BeNil())
+	ace.reload()
+	Expect(*changes).To(HaveLen(4))
+
+	// Only connections with changed rules are updated.
+	*changes = nil
+	Expect(ioutil.WriteFile(ace.rulesFile, []byte(`
+ingress:
+  Allow ICMP: action=reflect,icmptype=8
+connections:
+  - selector:
+      app: web
+    ingress:
+      Allow TCP 80: action=reflect,tcplowport=80,tcpupport=80
+`), 0644)).To(BeNil())
+	ace.reload()
+	Expect(*changes).To(HaveLen(2))
+	for _, change := range *changes {
+		Expect(change.put).To(BeFalse())
+		Expect(change.acl.AclName).To(HavePrefix("egress-"))
+	}
+
+	// Invalid rules are ignored.
+	*changes = nil
+	Expect(ioutil.WriteFile(ace.rulesFile, []byte(`{"ingress": {"Bad": "action=drop"}}`), 0644)).To(BeNil())
+	ace.reload()
+	Expect(*changes).To(BeEmpty())
+	Expect(ace.config.Ingress).To(HaveKey("Allow ICMP"))
+}
+
+func TestAclCompositeDataChangeDoesNotBlockOtherConnections(t *testing.T) {
+	RegisterTestingT(t)
+
+	config, err := ParseAclConfig([]byte(testAclConfig))
+	Expect(err).To(BeNil())
+	ace, _, _ := newTestAclEndpoint(config)
+
+	blocked := make(chan struct{})
+	unblock := make(chan struct{})
+	ace.dataChange = func(ctx context.Context, dataRequest *rpc.DataRequest, put bool) error {
+		if dataRequest.AccessLists[0].AclName == "ingress-1" {
+			blocked <- struct{}{}
+			<-unblock
+		}
+		return nil
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := ace.Request(context.Background(), request("1", nil))
+		done <- err
+	}()
+	<-blocked
+
+	// vppagent update of connection 1 is in progress, other connections are not blocked by it.
+	_, err = ace.Request(context.Background(), request("2", nil))
+	Expect(err).To(BeNil())
+	_, err = ace.Close(context.Background(), &connection.Connection{Id: "2"})
+	Expect(err).To(BeNil())
+
+	close(unblock)
+	Expect(<-done).To(BeNil())
+	Expect(ace.connections).To(HaveKey("1"))
+	Expect(ace.connections).NotTo(HaveKey("2"))
+}
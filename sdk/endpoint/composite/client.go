@@ -17,18 +17,26 @@ package composite
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/client"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/sirupsen/logrus"
 )
 
+// OutgoingEventHandler is called on the outgoing connection state changes with the id of the matching incoming connection
+type OutgoingEventHandler func(incomingID string, event *client.ConnectionEvent)
+
 type ClientCompositeEndpoint struct {
 	endpoint.BaseCompositeEndpoint
+	sync.RWMutex
 	nsmClient     *client.NsmClient
 	mechanismType string
 	ioConnMap     map[string]*connection.Connection
@@ -38,35 +46,63 @@ type ClientCompositeEndpoint struct {
 func (cce *ClientCompositeEndpoint) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
 
 	if cce.GetNext() == nil {
-		logrus.Fatal("The connection composite requires that there is Next set.")
+		tools.Log(ctx).Fatal("The connection composite requires that there is Next set.")
 	}
 
 	incomingConnection, err := cce.GetNext().Request(ctx, request)
 	if err != nil {
-		logrus.Errorf("Next request failed: %v", err)
+		tools.Log(ctx).Errorf("Next request failed: %v", err)
 		return nil, err
 	}
 
-	var outgoingConnection *connection.Connection
-	name := request.GetConnection().GetId()
-	outgoingConnection, err = cce.nsmClient.Connect(name, cce.mechanismType, "Describe "+name)
+	outgoingConnection, err := cce.connect(request.GetConnection())
 	if err != nil {
-		logrus.Errorf("Error when creating the connection %v", err)
+		tools.Log(ctx).Errorf("Error when creating the connection %v", err)
 		return nil, err
 	}
 
-	// TODO: check this. Hack??
-	outgoingConnection.GetMechanism().GetParameters()[connection.Workspace] = ""
-
+	cce.Lock()
 	cce.ioConnMap[incomingConnection.GetId()] = outgoingConnection
-	logrus.Infof("outgoingConnection: %v", outgoingConnection)
+	cce.Unlock()
+	tools.Log(ctx).Infof("outgoingConnection: %v", outgoingConnection)
 
 	return incomingConnection, nil
 }
 
+func (cce *ClientCompositeEndpoint) connect(incoming *connection.Connection) (*connection.Connection, error) {
+	var outgoingConnection *connection.Connection
+	var err error
+	name := incoming.GetId()
+	if chainService, ok := incoming.GetLabels()[registry.ChainServiceLabel]; ok {
+		// NSM asks us to request the next hop of service chain, labels of the incoming connection are passed
+		// to the next hop along with the chain labels, so the hop endpoints are selected by the client labels.
+		labels := map[string]string{}
+		for k, v := range cce.nsmClient.OutgoingNscLabels {
+			labels[k] = v
+		}
+		for k, v := range incoming.GetLabels() {
+			labels[k] = v
+		}
+		outgoingConnection, err = cce.nsmClient.ConnectToService(chainService, labels, name, cce.mechanismType, "Describe "+name)
+	} else {
+		outgoingConnection, err = cce.nsmClient.Connect(name, cce.mechanismType, "Describe "+name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: check this. Hack??
+	outgoingConnection.GetMechanism().GetParameters()[connection.Workspace] = ""
+	return outgoingConnection, nil
+}
+
 // Close imeplements the close handler
 func (cce *ClientCompositeEndpoint) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
-	if outgoingConnection, ok := cce.ioConnMap[connection.GetId()]; ok {
+	cce.Lock()
+	outgoingConnection, ok := cce.ioConnMap[connection.GetId()]
+	delete(cce.ioConnMap, connection.GetId())
+	cce.Unlock()
+	if ok {
 		cce.nsmClient.Close(outgoingConnection)
 	}
 	if cce.GetNext() != nil {
@@ -78,6 +114,8 @@ func (cce *ClientCompositeEndpoint) Close(ctx context.Context, connection *conne
 // GetOpaque will return the corresponding outgoing connection
 func (cce *ClientCompositeEndpoint) GetOpaque(incoming interface{}) interface{} {
 	incomingConnection := incoming.(*connection.Connection)
+	cce.RLock()
+	defer cce.RUnlock()
 	if outgoingConnection, ok := cce.ioConnMap[incomingConnection.GetId()]; ok {
 		return outgoingConnection
 	}
@@ -85,6 +123,62 @@ func (cce *ClientCompositeEndpoint) GetOpaque(incoming interface{}) interface{}
 	return nil
 }
 
+// MonitorOutgoing monitors the outgoing connections and blocks until ctx is done.
+// Lost outgoing connections are requested again by the NSM client.
+func (cce *ClientCompositeEndpoint) MonitorOutgoing(ctx context.Context, handler OutgoingEventHandler) error {
+	return cce.nsmClient.Monitor(ctx, func(event *client.ConnectionEvent) {
+		incomingID, ok := cce.incomingID(event.Connection.GetId())
+		if !ok {
+			return
+		}
+		if event.State == client.ConnectionRestored {
+			cce.Lock()
+			cce.ioConnMap[incomingID] = event.Connection
+			cce.Unlock()
+		}
+		handler(incomingID, event)
+	})
+}
+
+// RequestOutgoing closes the outgoing connection of the incoming one and requests a new one
+func (cce *ClientCompositeEndpoint) RequestOutgoing(ctx context.Context, incoming *connection.Connection) (*connection.Connection, error) {
+	cce.RLock()
+	outgoingConnection, ok := cce.ioConnMap[incoming.GetId()]
+	cce.RUnlock()
+	if ok {
+		if err := cce.nsmClient.Close(outgoingConnection); err != nil {
+			tools.Log(ctx).Warnf("Error closing the outgoing connection %s: %v", outgoingConnection.GetId(), err)
+		}
+	}
+
+	outgoingConnection, err := cce.connect(incoming)
+	if err != nil {
+		tools.Log(ctx).Errorf("Error when creating the connection %v", err)
+		return nil, err
+	}
+
+	cce.Lock()
+	defer cce.Unlock()
+	if _, ok := cce.ioConnMap[incoming.GetId()]; !ok {
+		// Incoming connection is closed meanwhile
+		cce.nsmClient.Close(outgoingConnection)
+		return nil, fmt.Errorf("incoming connection %s is closed", incoming.GetId())
+	}
+	cce.ioConnMap[incoming.GetId()] = outgoingConnection
+	return outgoingConnection, nil
+}
+
+func (cce *ClientCompositeEndpoint) incomingID(outgoingID string) (string, bool) {
+	cce.RLock()
+	defer cce.RUnlock()
+	for incomingID, outgoingConnection := range cce.ioConnMap {
+		if outgoingConnection.GetId() == outgoingID {
+			return incomingID, true
+		}
+	}
+	return "", false
+}
+
 // NewClientCompositeEndpoint creates a ClientCompositeEndpoint
 func NewClientCompositeEndpoint(configuration *common.NSConfiguration) *ClientCompositeEndpoint {
 	// ensure the env variables are processed
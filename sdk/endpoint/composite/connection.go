@@ -27,9 +27,9 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
-	"github.com/sirupsen/logrus"
 	"github.com/teris-io/shortid"
 )
 
@@ -44,13 +44,13 @@ func (cce *ConnectionCompositeEndpoint) Request(ctx context.Context, request *ne
 
 	err := request.IsValid()
 	if err != nil {
-		logrus.Errorf("Request is not valid: %v", err)
+		tools.Log(ctx).Errorf("Request is not valid: %v", err)
 		return nil, err
 	}
 
 	mechanism, err := connection.NewMechanism(cce.mechanismType, cce.generateIfName(), "NSM Endpoint")
 	if err != nil {
-		logrus.Errorf("Mechanism not created: %v", err)
+		tools.Log(ctx).Errorf("Mechanism not created: %v", err)
 		return nil, err
 	}
 
@@ -58,7 +58,7 @@ func (cce *ConnectionCompositeEndpoint) Request(ctx context.Context, request *ne
 	if cce.GetNext() != nil {
 		newConnection, err = cce.GetNext().Request(ctx, request)
 		if err != nil {
-			logrus.Errorf("Next request failed: %v", err)
+			tools.Log(ctx).Errorf("Next request failed: %v", err)
 			return nil, err
 		}
 	} else {
@@ -72,11 +72,11 @@ func (cce *ConnectionCompositeEndpoint) Request(ctx context.Context, request *ne
 
 	if newConnection == nil {
 		err := fmt.Errorf("Unabel to create a new connection")
-		logrus.Errorf("%v", err)
+		tools.Log(ctx).Errorf("%v", err)
 		return nil, err
 	}
 
-	logrus.Infof("New connection created: %v", newConnection)
+	tools.Log(ctx).Infof("New connection created: %v", newConnection)
 	return newConnection, nil
 }
 
@@ -27,9 +27,9 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/prefix_pool"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
-	"github.com/sirupsen/logrus"
 )
 
 type IpamCompositeEndpoint struct {
@@ -42,13 +42,13 @@ func (ice *IpamCompositeEndpoint) Request(ctx context.Context, request *networks
 
 	if ice.GetNext() == nil {
 		err := fmt.Errorf("IPAM needs next")
-		logrus.Errorf("%v", err)
+		tools.Log(ctx).Errorf("%v", err)
 		return nil, err
 	}
 
 	newConnection, err := ice.GetNext().Request(ctx, request)
 	if err != nil {
-		logrus.Errorf("Next request failed: %v", err)
+		tools.Log(ctx).Errorf("Next request failed: %v", err)
 		return nil, err
 	}
 
@@ -93,20 +93,20 @@ func (ice *IpamCompositeEndpoint) Request(ctx context.Context, request *networks
 
 	err = newConnection.IsComplete()
 	if err != nil {
-		logrus.Errorf("New connection is not complete: %v", err)
+		tools.Log(ctx).Errorf("New connection is not complete: %v", err)
 		return nil, err
 	}
 
-	logrus.Infof("IPAM completed on connection: %v", newConnection)
+	tools.Log(ctx).Infof("IPAM completed on connection: %v", newConnection)
 	return newConnection, nil
 }
 
 // Close imeplements the close handler
 func (ice *IpamCompositeEndpoint) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
 	prefix, requests, err := ice.prefixPool.GetConnectionInformation(connection.GetId())
-	logrus.Infof("Release connection prefixes network: %s extra requests: %v", prefix, requests)
+	tools.Log(ctx).Infof("Release connection prefixes network: %s extra requests: %v", prefix, requests)
 	if err != nil {
-		logrus.Errorf("Error: %v", err)
+		tools.Log(ctx).Errorf("Error: %v", err)
 	}
 	err = ice.prefixPool.Release(connection.GetId())
 	if ice.GetNext() != nil {
@@ -18,20 +18,94 @@ package composite
 import (
 	"context"
 	"fmt"
-	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/local_connection_monitor"
+	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/local_connection_monitor"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/sirupsen/logrus"
 )
 
-// MonitorCompositeEndpoint is a monitoring composite
+const monitorEventsBufferSize = 100
+
+// ConnectionDirection tells whether the connection is requested from the endpoint or by the endpoint
+type ConnectionDirection int
+
+const (
+	// Incoming - connection requested by NSM from the endpoint
+	Incoming ConnectionDirection = iota
+	// Outgoing - connection requested by the endpoint, e.g. by ClientCompositeEndpoint
+	Outgoing
+)
+
+func (d ConnectionDirection) String() string {
+	if d == Outgoing {
+		return "OUTGOING"
+	}
+	return "INCOMING"
+}
+
+// MonitorAction is an action taken when an outgoing connection goes DOWN
+type MonitorAction int
+
+const (
+	// MonitorActionNone - the state change is only reported
+	MonitorActionNone MonitorAction = iota
+	// MonitorActionCloseIncoming - the incoming connection is closed
+	MonitorActionCloseIncoming
+	// MonitorActionRequestOutgoing - the outgoing connection is closed and requested again
+	MonitorActionRequestOutgoing
+)
+
+// ParseMonitorAction parses the MONITOR_OUTGOING_ACTION value: "none" (or empty), "close" or "request"
+func ParseMonitorAction(action string) (MonitorAction, error) {
+	switch action {
+	case "", "none":
+		return MonitorActionNone, nil
+	case "close":
+		return MonitorActionCloseIncoming, nil
+	case "request":
+		return MonitorActionRequestOutgoing, nil
+	}
+	return MonitorActionNone, fmt.Errorf("unknown monitor action: %s", action)
+}
+
+// MonitorEvent is a state change of an incoming or an outgoing connection of the endpoint.
+// Incoming connections are UP once requested and CLOSED once closed, outgoing connections
+// have the states reported by the NSM client.
+type MonitorEvent struct {
+	Direction ConnectionDirection
+	State     client.ConnectionState
+	// Incoming is the incoming connection, it is set for the outgoing connection events as well
+	Incoming *connection.Connection
+	// Outgoing is the outgoing connection of the incoming one, nil for the incoming connection events
+	Outgoing *connection.Connection
+	Error    error
+}
+
+// outgoingConnectionMonitor is implemented by composites making outgoing connections, e.g. ClientCompositeEndpoint
+type outgoingConnectionMonitor interface {
+	MonitorOutgoing(ctx context.Context, handler OutgoingEventHandler) error
+	RequestOutgoing(ctx context.Context, incoming *connection.Connection) (*connection.Connection, error)
+}
+
+// MonitorCompositeEndpoint is a monitoring composite. If there is a composite making outgoing connections
+// further in the chain, their states are monitored as well and the configured action is taken when they go DOWN.
 type MonitorCompositeEndpoint struct {
 	endpoint.BaseCompositeEndpoint
+	sync.RWMutex
 	monitorConnectionServer *local_connection_monitor.LocalConnectionMonitor
+	outgoingAction          MonitorAction
+	incoming                map[string]*connection.Connection
+	subscribers             []chan *MonitorEvent
+	outgoingOnce            sync.Once
+	outgoing                outgoingConnectionMonitor
 }
 
 // Request imeplements the request handler
@@ -39,32 +113,168 @@ func (mce *MonitorCompositeEndpoint) Request(ctx context.Context, request *netwo
 
 	if mce.GetNext() == nil {
 		err := fmt.Errorf("Monitor needs next")
-		logrus.Errorf("%v", err)
+		tools.Log(ctx).Errorf("%v", err)
 		return nil, err
 	}
 
 	incomingConnection, err := mce.GetNext().Request(ctx, request)
 	if err != nil {
-		logrus.Errorf("Next request failed: %v", err)
+		tools.Log(ctx).Errorf("Next request failed: %v", err)
 		return nil, err
 	}
 
-	logrus.Infof("Monitor UpdateConnection: %v", incomingConnection)
+	tools.Log(ctx).Infof("Monitor UpdateConnection: %v", incomingConnection)
 	mce.monitorConnectionServer.Update(incomingConnection)
 
+	mce.Lock()
+	mce.incoming[incomingConnection.GetId()] = incomingConnection
+	mce.Unlock()
+	mce.publish(&MonitorEvent{
+		Direction: Incoming,
+		State:     client.ConnectionUp,
+		Incoming:  incomingConnection,
+	})
+	mce.outgoingOnce.Do(mce.monitorOutgoing)
+
 	return incomingConnection, nil
 }
 
 // Close imeplements the close handler
 func (mce *MonitorCompositeEndpoint) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
-	logrus.Infof("Monitor DeleteConnection: %v", connection)
+	tools.Log(ctx).Infof("Monitor DeleteConnection: %v", connection)
 	mce.monitorConnectionServer.Delete(connection)
+
+	mce.Lock()
+	delete(mce.incoming, connection.GetId())
+	mce.Unlock()
+	mce.publish(&MonitorEvent{
+		Direction: Incoming,
+		State:     client.ConnectionClosed,
+		Incoming:  connection,
+	})
+
 	if mce.GetNext() != nil {
 		return mce.GetNext().Close(ctx, connection)
 	}
 	return &empty.Empty{}, nil
 }
 
+// Events returns a unified stream of the incoming and outgoing connection events, the channel is closed once ctx is done.
+// Events are dropped if the channel is not read fast enough.
+func (mce *MonitorCompositeEndpoint) Events(ctx context.Context) <-chan *MonitorEvent {
+	events := make(chan *MonitorEvent, monitorEventsBufferSize)
+	mce.Lock()
+	mce.subscribers = append(mce.subscribers, events)
+	mce.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		mce.Lock()
+		defer mce.Unlock()
+		for i, subscriber := range mce.subscribers {
+			if subscriber == events {
+				mce.subscribers = append(mce.subscribers[:i], mce.subscribers[i+1:]...)
+				break
+			}
+		}
+		close(events)
+	}()
+	return events
+}
+
+// SetOutgoingAction sets the action taken when an outgoing connection goes DOWN
+func (mce *MonitorCompositeEndpoint) SetOutgoingAction(action MonitorAction) {
+	mce.Lock()
+	defer mce.Unlock()
+	mce.outgoingAction = action
+}
+
+func (mce *MonitorCompositeEndpoint) publish(event *MonitorEvent) {
+	mce.RLock()
+	defer mce.RUnlock()
+	for _, subscriber := range mce.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logrus.Warnf("Monitor event subscriber is too slow, dropping event: %v", event)
+		}
+	}
+}
+
+// monitorOutgoing starts monitoring of the first composite in the chain making outgoing connections
+func (mce *MonitorCompositeEndpoint) monitorOutgoing() {
+	for next := mce.GetNext(); next != nil; next = next.GetNext() {
+		if outgoing, ok := next.(outgoingConnectionMonitor); ok {
+			mce.outgoing = outgoing
+			go func() {
+				_ = outgoing.MonitorOutgoing(context.Background(), mce.outgoingEvent)
+			}()
+			return
+		}
+	}
+}
+
+func (mce *MonitorCompositeEndpoint) outgoingEvent(incomingID string, event *client.ConnectionEvent) {
+	mce.RLock()
+	incoming, ok := mce.incoming[incomingID]
+	action := mce.outgoingAction
+	mce.RUnlock()
+	if !ok {
+		return
+	}
+
+	mce.publish(&MonitorEvent{
+		Direction: Outgoing,
+		State:     event.State,
+		Incoming:  incoming,
+		Outgoing:  event.Connection,
+		Error:     event.Error,
+	})
+
+	switch event.State {
+	case client.ConnectionUp, client.ConnectionRestored:
+		mce.monitorConnectionServer.Update(incoming)
+	case client.ConnectionDown, client.ConnectionLost:
+		// Incoming connection is not usable while the outgoing one is down
+		down := proto.Clone(incoming).(*connection.Connection)
+		down.State = connection.State_DOWN
+		mce.monitorConnectionServer.Update(down)
+
+		ctx := context.Background()
+		switch action {
+		case MonitorActionCloseIncoming:
+			logrus.Infof("Outgoing connection %s is %v, closing incoming connection %s", event.Connection.GetId(), event.State, incomingID)
+			if _, err := mce.Close(ctx, incoming); err != nil {
+				logrus.Errorf("Error closing incoming connection %s: %v", incomingID, err)
+			}
+		case MonitorActionRequestOutgoing:
+			if event.State == client.ConnectionLost {
+				// Lost connections are requested again by the NSM client
+				return
+			}
+			logrus.Infof("Outgoing connection %s is %v, requesting it again", event.Connection.GetId(), event.State)
+			outgoing, err := mce.outgoing.RequestOutgoing(ctx, incoming)
+			if err != nil {
+				mce.publish(&MonitorEvent{
+					Direction: Outgoing,
+					State:     client.ConnectionFailed,
+					Incoming:  incoming,
+					Outgoing:  event.Connection,
+					Error:     err,
+				})
+				return
+			}
+			mce.monitorConnectionServer.Update(incoming)
+			mce.publish(&MonitorEvent{
+				Direction: Outgoing,
+				State:     client.ConnectionRestored,
+				Incoming:  incoming,
+				Outgoing:  outgoing,
+			})
+		}
+	}
+}
+
 // NewMonitorCompositeEndpoint creates a MonitorCompositeEndpoint
 func NewMonitorCompositeEndpoint(configuration *common.NSConfiguration) *MonitorCompositeEndpoint {
 	// ensure the env variables are processed
@@ -73,8 +283,19 @@ func NewMonitorCompositeEndpoint(configuration *common.NSConfiguration) *Monitor
 	}
 	configuration.CompleteNSConfiguration()
 
+	action, err := ParseMonitorAction(configuration.MonitorOutgoingAction)
+	if err != nil {
+		logrus.Fatalf("Invalid monitor outgoing action: %v", err)
+	}
+
+	return newMonitorCompositeEndpoint(action)
+}
+
+func newMonitorCompositeEndpoint(action MonitorAction) *MonitorCompositeEndpoint {
 	self := &MonitorCompositeEndpoint{
 		monitorConnectionServer: local_connection_monitor.NewLocalConnectionMonitor(),
+		outgoingAction:          action,
+		incoming:                map[string]*connection.Connection{},
 	}
 	self.SetSelf(self)
 
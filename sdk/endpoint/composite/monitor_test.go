@@ -0,0 +1,166 @@
+package composite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	. "github.com/onsi/gomega"
+)
+
+type testOutgoingEndpoint struct {
+	testInterfaceEndpoint
+	handler    chan OutgoingEventHandler
+	requested  []string
+	requestErr error
+}
+
+func (e *testOutgoingEndpoint) MonitorOutgoing(ctx context.Context, handler OutgoingEventHandler) error {
+	e.handler <- handler
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *testOutgoingEndpoint) RequestOutgoing(ctx context.Context, incoming *connection.Connection) (*connection.Connection, error) {
+	e.requested = append(e.requested, incoming.GetId())
+	if e.requestErr != nil {
+		return nil, e.requestErr
+	}
+	return &connection.Connection{Id: "out-" + incoming.GetId() + "-2"}, nil
+}
+
+func newTestMonitorEndpoint(action MonitorAction) (*MonitorCompositeEndpoint, *testOutgoingEndpoint, <-chan *MonitorEvent, context.CancelFunc) {
+	mce := newMonitorCompositeEndpoint(action)
+	outgoing := &testOutgoingEndpoint{
+		handler: make(chan OutgoingEventHandler, 1),
+	}
+	outgoing.SetSelf(outgoing)
+	// Outgoing composite is looked up through the whole chain
+	mce.SetNext(newTestAclPassThrough().SetNext(outgoing))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return mce, outgoing, mce.Events(ctx), cancel
+}
+
+func newTestAclPassThrough() *AclCompositeEndpoint {
+	ace, _, _ := newTestAclEndpoint(&AclConfig{})
+	return ace
+}
+
+func receiveMonitorEvent(events <-chan *MonitorEvent) *MonitorEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func TestParseMonitorAction(t *testing.T) {
+	RegisterTestingT(t)
+
+	for value, expected := range map[string]MonitorAction{
+		"":        MonitorActionNone,
+		"none":    MonitorActionNone,
+		"close":   MonitorActionCloseIncoming,
+		"request": MonitorActionRequestOutgoing,
+	} {
+		action, err := ParseMonitorAction(value)
+		Expect(err).To(BeNil())
+		Expect(action).To(Equal(expected))
+	}
+	_, err := ParseMonitorAction("restart")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestMonitorCompositeEvents(t *testing.T) {
+	RegisterTestingT(t)
+
+	mce, outgoing, events, cancel := newTestMonitorEndpoint(MonitorActionNone)
+
+	_, err := mce.Request(context.Background(), request("1", nil))
+	Expect(err).To(BeNil())
+	event := receiveMonitorEvent(events)
+	Expect(event.Direction).To(Equal(Incoming))
+	Expect(event.State).To(Equal(client.ConnectionUp))
+	Expect(event.Incoming.GetId()).To(Equal("1"))
+
+	handler := <-outgoing.handler
+	handler("1", &client.ConnectionEvent{State: client.ConnectionDown, Connection: &connection.Connection{Id: "out-1"}})
+	event = receiveMonitorEvent(events)
+	Expect(event.Direction).To(Equal(Outgoing))
+	Expect(event.State).To(Equal(client.ConnectionDown))
+	Expect(event.Incoming.GetId()).To(Equal("1"))
+	Expect(event.Outgoing.GetId()).To(Equal("out-1"))
+	Expect(outgoing.requested).To(BeEmpty())
+	Expect(outgoing.closed).To(BeEmpty())
+
+	// Events of unknown incoming connections are ignored
+	handler("2", &client.ConnectionEvent{State: client.ConnectionDown, Connection: &connection.Connection{Id: "out-2"}})
+
+	_, err = mce.Close(context.Background(), &connection.Connection{Id: "1"})
+	Expect(err).To(BeNil())
+	event = receiveMonitorEvent(events)
+	Expect(event.Direction).To(Equal(Incoming))
+	Expect(event.State).To(Equal(client.ConnectionClosed))
+
+	cancel()
+	Eventually(events).Should(BeClosed())
+}
+
+func TestMonitorCompositeCloseIncoming(t *testing.T) {
+	RegisterTestingT(t)
+
+	mce, outgoing, events, cancel := newTestMonitorEndpoint(MonitorActionCloseIncoming)
+	defer cancel()
+
+	_, err := mce.Request(context.Background(), request("1", nil))
+	Expect(err).To(BeNil())
+	receiveMonitorEvent(events)
+
+	handler := <-outgoing.handler
+	handler("1", &client.ConnectionEvent{State: client.ConnectionDown, Connection: &connection.Connection{Id: "out-1"}})
+	Expect(receiveMonitorEvent(events).State).To(Equal(client.ConnectionDown))
+
+	event := receiveMonitorEvent(events)
+	Expect(event.Direction).To(Equal(Incoming))
+	Expect(event.State).To(Equal(client.ConnectionClosed))
+	Expect(outgoing.closed).To(Equal([]string{"1"}))
+	Expect(mce.incoming).To(BeEmpty())
+}
+
+func TestMonitorCompositeRequestOutgoing(t *testing.T) {
+	RegisterTestingT(t)
+
+	mce, outgoing, events, cancel := newTestMonitorEndpoint(MonitorActionRequestOutgoing)
+	defer cancel()
+
+	_, err := mce.Request(context.Background(), request("1", nil))
+	Expect(err).To(BeNil())
+	receiveMonitorEvent(events)
+
+	handler := <-outgoing.handler
+	handler("1", &client.ConnectionEvent{State: client.ConnectionDown, Connection: &connection.Connection{Id: "out-1"}})
+	Expect(receiveMonitorEvent(events).State).To(Equal(client.ConnectionDown))
+
+	event := receiveMonitorEvent(events)
+	Expect(event.Direction).To(Equal(Outgoing))
+	Expect(event.State).To(Equal(client.ConnectionRestored))
+	Expect(event.Outgoing.GetId()).To(Equal("out-1-2"))
+	Expect(outgoing.requested).To(Equal([]string{"1"}))
+
+	// Lost connections are requested again by the NSM client
+	handler("1", &client.ConnectionEvent{State: client.ConnectionLost, Connection: &connection.Connection{Id: "out-1-2"}})
+	Expect(receiveMonitorEvent(events).State).To(Equal(client.ConnectionLost))
+	Expect(outgoing.requested).To(HaveLen(1))
+
+	outgoing.requestErr = fmt.Errorf("no endpoints")
+	handler("1", &client.ConnectionEvent{State: client.ConnectionDown, Connection: &connection.Connection{Id: "out-1-2"}})
+	Expect(receiveMonitorEvent(events).State).To(Equal(client.ConnectionDown))
+	event = receiveMonitorEvent(events)
+	Expect(event.State).To(Equal(client.ConnectionFailed))
+	Expect(event.Error).To(Equal(outgoing.requestErr))
+}
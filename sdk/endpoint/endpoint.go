@@ -17,12 +17,13 @@ package endpoint
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 
 	opentracing "github.com/opentracing/opentracing-go"
 
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
@@ -64,22 +65,34 @@ func (nsme *nsmEndpoint) serve(listener net.Listener) {
 	}()
 }
 
+// parseMetadata parses endpoint metadata from a JSON object of strings, so values could contain any characters.
+func parseMetadata(metadata string) (map[string]string, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	result := map[string]string{}
+	if err := json.Unmarshal([]byte(metadata), &result); err != nil {
+		return nil, fmt.Errorf("invalid endpoint metadata %q, a JSON object of strings is expected: %v", metadata, err)
+	}
+	return result, nil
+}
+
 func (nsme *nsmEndpoint) Start() error {
+	metadata, err := parseMetadata(nsme.Configuration.AdvertiseNseMetadata)
+	if err != nil {
+		return err
+	}
 
-	var grpcOptions []grpc.ServerOption
+	var tracer opentracing.Tracer = opentracing.NoopTracer{}
 	if nsme.Configuration.TracerEnabled {
-		tracer, closer := tools.InitJaeger(nsme.Configuration.AdvertiseNseName)
-		grpcOptions = append(grpcOptions,
-			grpc.UnaryInterceptor(
-				otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())))
-		grpcOptions = append(grpcOptions,
-			grpc.StreamInterceptor(
-				otgrpc.OpenTracingStreamServerInterceptor(tracer)))
-		opentracing.SetGlobalTracer(tracer)
+		jaegerTracer, closer := tools.InitJaeger(nsme.Configuration.AdvertiseNseName)
+		opentracing.SetGlobalTracer(jaegerTracer)
+		tracer = jaegerTracer
 		nsme.tracerCloser = closer
 	}
 
-	nsme.grpcServer = grpc.NewServer(grpcOptions...)
+	// Request id interceptors are always installed, so NSE logs could be correlated with NSM ones.
+	nsme.grpcServer = tools.NewServerWithTracer(tracer)
 	networkservice.RegisterNetworkServiceServer(nsme.grpcServer, nsme)
 
 	listener, err := nsme.setupNSEServerConnection()
@@ -98,6 +111,8 @@ func (nsme *nsmEndpoint) Start() error {
 		NetworkServiceName: nsme.Configuration.AdvertiseNseName,
 		Payload:            "IP",
 		Labels:             tools.ParseKVStringToMap(nsme.Configuration.AdvertiseNseLabels, ",", "="),
+		Capacity:           nsme.Configuration.AdvertiseNseCapacity,
+		Metadata:           metadata,
 	}
 	registration := &registry.NSERegistration{
 		NetworkService: &registry.NetworkService{
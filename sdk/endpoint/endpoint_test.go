@@ -0,0 +1,28 @@
+package endpoint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	metadata, err := parseMetadata("")
+	Expect(err).To(BeNil())
+	Expect(metadata).To(BeNil())
+
+	metadata, err = parseMetadata(`{"owner":"team-a","location":"rack=1,row=2"}`)
+	Expect(err).To(BeNil())
+	Expect(metadata).To(Equal(map[string]string{
+		"owner":    "team-a",
+		"location": "rack=1,row=2",
+	}))
+
+	_, err = parseMetadata("owner=team-a,location=rack")
+	Expect(err).NotTo(BeNil())
+
+	_, err = parseMetadata(`{"replicas":3}`)
+	Expect(err).NotTo(BeNil())
+}
@@ -21,23 +21,29 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 )
 
 func (nsme *nsmEndpoint) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
-	logrus.Infof("Request for Network Service received %v", request)
+	logger := tools.Log(ctx).WithFields(logrus.Fields{
+		tools.LogFieldConnectionID:   request.GetConnection().GetId(),
+		tools.LogFieldNetworkService: request.GetConnection().GetNetworkService(),
+	})
+	logger.Infof("Request for Network Service received %v", request)
 
 	incomingConnection, err := nsme.composite.Request(ctx, request)
 	if err != nil {
-		logrus.Errorf("The composite returned an error: %v", err)
+		logger.Errorf("The composite returned an error: %v", err)
 		return nil, err
 	}
 
-	logrus.Infof("Responding to NetworkService.Request(%v): %v", request, incomingConnection)
+	logger.Infof("Responding to NetworkService.Request(%v): %v", request, incomingConnection)
 	return incomingConnection, nil
 }
 
 func (nsme *nsmEndpoint) Close(ctx context.Context, incomingConnection *connection.Connection) (*empty.Empty, error) {
+	tools.Log(ctx).WithField(tools.LogFieldConnectionID, incomingConnection.GetId()).Infof("Close for Network Service received %v", incomingConnection)
 
 	nsme.composite.Close(ctx, incomingConnection)
 	nsme.NsClient.Close(ctx, incomingConnection)
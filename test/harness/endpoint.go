@@ -0,0 +1,136 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
+	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint/composite"
+)
+
+// Endpoint is an SDK based Network Service Endpoint running in the node workspace.
+type Endpoint struct {
+	Name           string
+	NetworkService string
+	Workspace      string
+	Configuration  *common.NSConfiguration
+	node           *Node
+	nse            interface{ Delete() error }
+}
+
+// NewEndpoint starts an SDK endpoint providing the network service, IPAM and connection composites are used if
+// composite is nil.
+func (n *Node) NewEndpoint(networkService string, composite endpoint.CompositeEndpoint) (*Endpoint, error) {
+	n.Lock()
+	n.endpointCounter++
+	workspace := fmt.Sprintf("nse-%d", n.endpointCounter)
+	ipAddress := fmt.Sprintf("10.%d.%d.0/24", 60+n.index, n.endpointCounter)
+	n.Unlock()
+
+	configuration, err := n.configuration(workspace)
+	if err != nil {
+		return nil, err
+	}
+	configuration.AdvertiseNseName = networkService
+	configuration.IPAddress = ipAddress
+
+	if composite == nil {
+		composite = newDefaultComposite(configuration)
+	}
+	nse, err := endpoint.NewNSMEndpoint(context.Background(), configuration, composite)
+	if err != nil {
+		return nil, err
+	}
+	if err := nse.Start(); err != nil {
+		return nil, err
+	}
+
+	rv := &Endpoint{
+		NetworkService: networkService,
+		Workspace:      workspace,
+		Configuration:  configuration,
+		node:           n,
+		nse:            nse,
+	}
+	for _, ep := range n.Model.GetNetworkServiceEndpoints(networkService) {
+		if ep.Workspace == workspace {
+			rv.Name = ep.EndpointName()
+		}
+	}
+	if rv.Name == "" {
+		_ = nse.Delete()
+		return nil, fmt.Errorf("endpoint of %s is not registered in %s", networkService, n.Name)
+	}
+
+	n.Lock()
+	n.endpoints = append(n.endpoints, rv)
+	n.Unlock()
+	return rv, nil
+}
+
+func newDefaultComposite(configuration *common.NSConfiguration) endpoint.CompositeEndpoint {
+	return composite.NewIpamCompositeEndpoint(configuration).SetNext(
+		composite.NewConnectionCompositeEndpoint(configuration))
+}
+
+// Kill stops the endpoint and makes the node dataplane to report destinations of the endpoint DOWN.
+func (e *Endpoint) Kill() error {
+	if err := e.Delete(); err != nil {
+		return err
+	}
+	if dp := e.node.Dataplane; dp != nil {
+		dp.DestinationDown(e.Name)
+	}
+	return nil
+}
+
+// Delete unregisters and stops the endpoint.
+func (e *Endpoint) Delete() error {
+	e.node.Lock()
+	for i, ep := range e.node.endpoints {
+		if ep == e {
+			e.node.endpoints = append(e.node.endpoints[:i], e.node.endpoints[i+1:]...)
+			break
+		}
+	}
+	e.node.Unlock()
+	return e.nse.Delete()
+}
+
+// NewClient creates an SDK client in a new workspace of the node.
+func (n *Node) NewClient() (*client.NsmClient, error) {
+	n.Lock()
+	n.clientCounter++
+	workspace := fmt.Sprintf("nsc-%d", n.clientCounter)
+	n.Unlock()
+
+	configuration, err := n.configuration(workspace)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewNSMClient(context.Background(), configuration)
+}
+
+// configuration requests the new workspace and returns SDK configuration to use it.
+func (n *Node) configuration(workspace string) (*common.NSConfiguration, error) {
+	nsmdClient, conn, err := n.serviceRegistry.NSMDApiClient()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	reply, err := nsmdClient.RequestClientConnection(context.Background(), &nsmdapi.ClientConnectionRequest{Workspace: workspace})
+	if err != nil {
+		return nil, err
+	}
+	workspaceDir := path.Join(reply.HostBasedir, reply.Workspace)
+	return &common.NSConfiguration{
+		NsmServerSocket: path.Join(workspaceDir, reply.NsmServerSocket),
+		NsmClientSocket: path.Join(workspaceDir, reply.NsmClientSocket),
+		Workspace:       workspaceDir,
+	}, nil
+}
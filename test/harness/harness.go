@@ -0,0 +1,257 @@
+// Package harness runs several NSMDs in a single process for tests: the NSMDs share an in-memory registry,
+// use fake dataplanes and SDK endpoints and clients connected over unix sockets in a temporary directory.
+// Faults could be injected by killing endpoints and dataplanes or by partitioning NSMD public API, so heal
+// scenarios could be covered by go test without Kubernetes cluster.
+package harness
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	nsmimpl "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/fake"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StartTimeout is a time to wait for NSMD and dataplane to start
+	StartTimeout = 10 * time.Second
+)
+
+// Harness is a set of in-process NSMD nodes sharing the registry.
+type Harness struct {
+	sync.Mutex
+	RootDir  string
+	Registry *Registry
+	nodes    []*Node
+}
+
+// Node is a single NSMD with its dataplane, endpoints and clients.
+type Node struct {
+	sync.Mutex
+	Name            string
+	RootDir         string
+	Model           model.Model
+	Manager         nsm.NetworkServiceManager
+	Server          nsmd.NSMServer
+	Dataplane       *fake.Dataplane
+	index           int
+	registrar       stopper
+	public          *partitionListener
+	serviceRegistry *serviceRegistry
+	endpoints       []*Endpoint
+	endpointCounter int
+	clientCounter   int
+}
+
+// stopper is a server started by the node.
+type stopper interface {
+	Stop()
+}
+
+// New starts a harness with the passed number of nodes.
+func New(nodes int) (*Harness, error) {
+	rootDir, err := ioutil.TempDir("", "nsm_harness")
+	if err != nil {
+		return nil, err
+	}
+	h := &Harness{
+		RootDir:  rootDir,
+		Registry: NewRegistry(),
+	}
+	for i := 0; i < nodes; i++ {
+		if _, err := h.AddNode(); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Node returns node by index.
+func (h *Harness) Node(index int) *Node {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.nodes[index]
+}
+
+// Nodes returns all the nodes.
+func (h *Harness) Nodes() []*Node {
+	h.Lock()
+	defer h.Unlock()
+
+	return append([]*Node{}, h.nodes...)
+}
+
+// AddNode starts a new NSMD with a dataplane.
+func (h *Harness) AddNode() (*Node, error) {
+	h.Lock()
+	index := len(h.nodes)
+	node := &Node{
+		Name:    fmt.Sprintf("nsm-%d", index+1),
+		RootDir: path.Join(h.RootDir, fmt.Sprintf("nsm-%d", index+1)),
+		Model:   model.NewModel(),
+		index:   index,
+	}
+	h.nodes = append(h.nodes, node)
+	h.Unlock()
+
+	if err := node.start(h.Registry); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// Close stops all the nodes and removes the harness directory.
+func (h *Harness) Close() {
+	for _, node := range h.Nodes() {
+		node.Close()
+	}
+	if err := os.RemoveAll(h.RootDir); err != nil {
+		logrus.Errorf("Failed to remove harness directory %s: %v", h.RootDir, err)
+	}
+}
+
+func (n *Node) start(registry *Registry) error {
+	logrus.Infof("Harness: starting %s at %s", n.Name, n.RootDir)
+	if err := os.MkdirAll(n.RootDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	var err error
+	n.public, err = newPartitionListener("127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	n.serviceRegistry = newServiceRegistry(n, registry.clientFor(n.Name))
+
+	n.Manager = nsmimpl.NewNetworkServiceManager(n.Model, n.serviceRegistry, nil)
+	setHealProperties(n.Manager.GetHealProperties())
+
+	n.Server, err = nsmd.StartNSMServer(n.Model, n.Manager, n.serviceRegistry, &apiRegistry{node: n}, nil)
+	if err != nil {
+		return err
+	}
+	// Every node has own dataplane registrar socket, the default one is shared by all NSMDs of the host.
+	registrar, err := nsmd.StartDataplaneRegistrarServerAt(n.Model, path.Join(n.RootDir, nsmd.DataplaneRegistrarSocket))
+	if err != nil {
+		return err
+	}
+	n.registrar = registrar
+	if err := n.StartDataplane(); err != nil {
+		return err
+	}
+	if err := n.Manager.WaitForDataplane(StartTimeout); err != nil {
+		return err
+	}
+	return nsmd.StartAPIServerAt(n.Server, n.public)
+}
+
+// setHealProperties shortens heal timeouts, so heal scenarios take seconds.
+func setHealProperties(properties *nsm.HealTimeouts) {
+	properties.HealTimeout = 5 * time.Second
+	properties.HealRequestTimeout = 5 * time.Second
+	properties.HealDataplaneTimeout = 5 * time.Second
+	properties.HealDSTNSEWaitTimeout = 2 * time.Second
+	properties.HealDSTNSEWaitTick = waitInterval
+}
+
+func (n *Node) nsmServerSocket() string {
+	return path.Join(n.RootDir, nsmServerSocket)
+}
+
+// StartDataplane starts the node dataplane and waits for NSMD to receive its mechanisms.
+func (n *Node) StartDataplane() error {
+	n.Lock()
+	if n.Dataplane == nil {
+		n.Dataplane = fake.NewDataplane(n.Name+"-dataplane",
+			path.Join(n.RootDir, "dataplane.io.sock"),
+			path.Join(n.RootDir, nsmd.DataplaneRegistrarSocket),
+			fmt.Sprintf("127.0.0.%d", n.index+1))
+	}
+	dp := n.Dataplane
+	n.Unlock()
+
+	if err := dp.Start(); err != nil {
+		return err
+	}
+	return WaitFor(StartTimeout, func() bool {
+		registered := n.Model.GetDataplane(dp.Name)
+		return registered != nil && len(registered.LocalMechanisms) > 0
+	})
+}
+
+// KillDataplane stops the node dataplane and waits for NSMD to notice it.
+func (n *Node) KillDataplane() error {
+	n.Lock()
+	dp := n.Dataplane
+	n.Unlock()
+
+	if dp == nil {
+		return nil
+	}
+	dp.Stop()
+	return WaitFor(StartTimeout, func() bool {
+		return n.Model.GetDataplane(dp.Name) == nil
+	})
+}
+
+// Partition makes NSMD public API unavailable for other nodes and closes all the remote connections to it,
+// the node is not able to connect other nodes as well.
+func (n *Node) Partition() {
+	logrus.Infof("Harness: partition %s", n.Name)
+	n.public.Partition()
+}
+
+// Reconnect makes NSMD public API available again.
+func (n *Node) Reconnect() error {
+	logrus.Infof("Harness: reconnect %s", n.Name)
+	return n.public.Reconnect()
+}
+
+// Endpoints returns the running endpoints of the node.
+func (n *Node) Endpoints() []*Endpoint {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]*Endpoint{}, n.endpoints...)
+}
+
+// Connections returns copies of the NSMD client connections, since the connections are changed by NSMD concurrently.
+func (n *Node) Connections() []*model.ClientConnection {
+	var connections []*model.ClientConnection
+	for _, clientConnection := range n.Model.GetAllClientConnections() {
+		connections = append(connections, n.Model.CopyClientConnection(clientConnection))
+	}
+	return connections
+}
+
+// Close stops endpoints, dataplane and NSMD.
+func (n *Node) Close() {
+	logrus.Infof("Harness: stopping %s", n.Name)
+	for _, ep := range n.Endpoints() {
+		if err := ep.Delete(); err != nil {
+			logrus.Errorf("Harness: failed to delete endpoint %s: %v", ep.Name, err)
+		}
+	}
+	if n.Dataplane != nil {
+		n.Dataplane.Stop()
+	}
+	if n.registrar != nil {
+		n.registrar.Stop()
+	}
+	if n.Server != nil {
+		n.Server.Stop()
+	}
+	if n.public != nil {
+		_ = n.public.Close()
+	}
+}
@@ -0,0 +1,191 @@
+package harness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/fake"
+	"github.com/networkservicemesh/networkservicemesh/sdk/client"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	networkService = "golden_network"
+	healTimeout    = 15 * time.Second
+)
+
+func connect(nsc *client.NsmClient) *connection.Connection {
+	conn, err := nsc.ConnectToService(networkService, nil, "nsm", "kernel", "harness client")
+	Expect(err).To(BeNil())
+	return conn
+}
+
+// waitHealed waits for the connection of the node to be ready and served by the endpoint.
+func waitHealed(node *Node, endpointName string) {
+	Expect(WaitFor(healTimeout, func() bool {
+		connections := node.Connections()
+		return len(connections) == 1 &&
+			connections[0].ConnectionState == model.ClientConnection_Ready &&
+			connections[0].Endpoint.GetNetworkserviceEndpoint().GetEndpointName() == endpointName
+	})).To(BeNil())
+}
+
+func waitCrossConnects(dp *fake.Dataplane, count int) []*crossconnect.CrossConnect {
+	var xcons []*crossconnect.CrossConnect
+	Expect(WaitFor(healTimeout, func() bool {
+		xcons = dp.CrossConnects()
+		return len(xcons) == count
+	})).To(BeNil())
+	return xcons
+}
+
+func TestLocalConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(1)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	node := h.Node(0)
+
+	nse, err := node.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+
+	nsc, err := node.NewClient()
+	Expect(err).To(BeNil())
+	conn := connect(nsc)
+	Expect(conn.GetContext().GetSrcIpAddr()).NotTo(BeEmpty())
+
+	waitHealed(node, nse.Name)
+	xcons := waitCrossConnects(node.Dataplane, 1)
+	Expect(xcons[0].GetLocalSource().GetId()).To(Equal(conn.GetId()))
+
+	Expect(nsc.Close(conn)).To(BeNil())
+	Expect(WaitFor(healTimeout, func() bool { return len(node.Connections()) == 0 })).To(BeNil())
+	waitCrossConnects(node.Dataplane, 0)
+}
+
+func TestHealDataplane(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(1)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	node := h.Node(0)
+
+	nse, err := node.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	nsc, err := node.NewClient()
+	Expect(err).To(BeNil())
+	connect(nsc)
+	waitCrossConnects(node.Dataplane, 1)
+
+	Expect(node.KillDataplane()).To(BeNil())
+	Expect(node.StartDataplane()).To(BeNil())
+
+	// Restarted dataplane has no cross connects, they are programmed again by heal.
+	waitCrossConnects(node.Dataplane, 1)
+	waitHealed(node, nse.Name)
+}
+
+func TestHealLocalEndpoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(1)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	node := h.Node(0)
+
+	nse1, err := node.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	nsc, err := node.NewClient()
+	Expect(err).To(BeNil())
+	connect(nsc)
+	waitHealed(node, nse1.Name)
+
+	nse2, err := node.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	Expect(nse1.Kill()).To(BeNil())
+
+	waitHealed(node, nse2.Name)
+	xcons := waitCrossConnects(node.Dataplane, 1)
+	Expect(xcons[0].GetLocalDestination().GetMechanism().GetParameters()[connection.WorkspaceNSEName]).To(Equal(nse2.Name))
+}
+
+func TestHealRemoteEndpoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(2)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	local, remote := h.Node(0), h.Node(1)
+
+	nse1, err := remote.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	nsc, err := local.NewClient()
+	Expect(err).To(BeNil())
+	connect(nsc)
+	waitHealed(local, nse1.Name)
+	waitHealed(remote, nse1.Name)
+
+	nse2, err := remote.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	Expect(nse1.Kill()).To(BeNil())
+
+	waitHealed(local, nse2.Name)
+	waitHealed(remote, nse2.Name)
+	xcons := waitCrossConnects(local.Dataplane, 1)
+	Expect(xcons[0].GetRemoteDestination().GetNetworkServiceEndpointName()).To(Equal(nse2.Name))
+}
+
+// Connection with DOWN source is closed, the update reporting it should not add the connection back to the model.
+func TestSourceDown(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(1)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	node := h.Node(0)
+
+	nse, err := node.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	nsc, err := node.NewClient()
+	Expect(err).To(BeNil())
+	connect(nsc)
+	waitHealed(node, nse.Name)
+	xcons := waitCrossConnects(node.Dataplane, 1)
+
+	Expect(node.Dataplane.SourceDown(xcons[0].GetId())).To(BeTrue())
+	waitCrossConnects(node.Dataplane, 0)
+	Expect(WaitFor(healTimeout, func() bool { return len(node.Connections()) == 0 })).To(BeNil())
+	Consistently(func() int { return len(node.Connections()) }, time.Second).Should(Equal(0))
+}
+
+func TestPartitionRemote(t *testing.T) {
+	RegisterTestingT(t)
+
+	h, err := New(2)
+	Expect(err).To(BeNil())
+	defer h.Close()
+	local, remote := h.Node(0), h.Node(1)
+
+	nse, err := remote.NewEndpoint(networkService, nil)
+	Expect(err).To(BeNil())
+	nsc, err := local.NewClient()
+	Expect(err).To(BeNil())
+	conn := connect(nsc)
+	waitHealed(local, nse.Name)
+
+	remote.Partition()
+	Expect(WaitFor(healTimeout, func() bool {
+		connections := local.Connections()
+		return len(connections) == 1 && connections[0].ConnectionState == model.ClientConnection_Healing
+	})).To(BeNil())
+	Expect(remote.Reconnect()).To(BeNil())
+
+	waitHealed(local, nse.Name)
+	waitHealed(remote, nse.Name)
+	Expect(local.Connections()[0].GetId()).To(Equal(conn.GetId()))
+}
@@ -0,0 +1,156 @@
+package harness
+
+import (
+	"errors"
+	"net"
+	"sync"
+)
+
+var errListenerClosed = errors.New("listener is closed")
+
+// partitionListener is a tcp listener which could be partitioned from the network: the port is closed together
+// with all the accepted connections until the listener is reconnected on the same address. Accept blocks
+// while the listener is partitioned, so gRPC server continues to serve after the reconnect.
+type partitionListener struct {
+	sync.Mutex
+	addr     net.Addr
+	listener net.Listener
+	conns    map[*partitionConn]struct{}
+	resumed  chan struct{}
+	closed   bool
+}
+
+type partitionConn struct {
+	net.Conn
+	listener *partitionListener
+}
+
+func (c *partitionConn) Close() error {
+	c.listener.Lock()
+	delete(c.listener.conns, c)
+	c.listener.Unlock()
+	return c.Conn.Close()
+}
+
+func newPartitionListener(address string) (*partitionListener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &partitionListener{
+		addr:     listener.Addr(),
+		listener: listener,
+		conns:    map[*partitionConn]struct{}{},
+	}, nil
+}
+
+func (l *partitionListener) Accept() (net.Conn, error) {
+	for {
+		l.Lock()
+		if l.closed {
+			l.Unlock()
+			return nil, errListenerClosed
+		}
+		listener, resumed := l.listener, l.resumed
+		l.Unlock()
+
+		if listener == nil {
+			<-resumed
+			continue
+		}
+		conn, err := listener.Accept()
+
+		l.Lock()
+		if l.closed {
+			l.Unlock()
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return nil, errListenerClosed
+		}
+		if l.listener != listener {
+			// Partitioned while accepting.
+			l.Unlock()
+			if conn != nil {
+				_ = conn.Close()
+			}
+			continue
+		}
+		if err != nil {
+			l.Unlock()
+			return nil, err
+		}
+		rv := &partitionConn{Conn: conn, listener: l}
+		l.conns[rv] = struct{}{}
+		l.Unlock()
+		return rv, nil
+	}
+}
+
+// Partition closes the port and all the accepted connections.
+func (l *partitionListener) Partition() {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.listener == nil || l.closed {
+		return
+	}
+	_ = l.listener.Close()
+	l.listener = nil
+	l.resumed = make(chan struct{})
+	l.closeConns()
+}
+
+// Reconnect opens the port again.
+func (l *partitionListener) Reconnect() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.listener != nil || l.closed {
+		return nil
+	}
+	listener, err := net.Listen("tcp", l.addr.String())
+	if err != nil {
+		return err
+	}
+	l.listener = listener
+	close(l.resumed)
+	return nil
+}
+
+// Partitioned returns true if the listener is partitioned.
+func (l *partitionListener) Partitioned() bool {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.listener == nil
+}
+
+func (l *partitionListener) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.listener != nil {
+		_ = l.listener.Close()
+	} else {
+		close(l.resumed)
+	}
+	l.closeConns()
+	return nil
+}
+
+func (l *partitionListener) Addr() net.Addr {
+	return l.addr
+}
+
+// closeConns closes all the accepted connections, the caller should hold the lock
+func (l *partitionListener) closeConns() {
+	for conn := range l.conns {
+		_ = conn.Conn.Close()
+	}
+	l.conns = map[*partitionConn]struct{}{}
+}
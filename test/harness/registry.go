@@ -0,0 +1,174 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Registry is an in-memory Network Service Registry shared by all the nodes of the harness.
+type Registry struct {
+	sync.RWMutex
+	services             map[string]*registry.NetworkService
+	managers             map[string]*registry.NetworkServiceManager
+	endpoints            map[string]*registry.NetworkServiceEndpoint
+	endpointCounter      int
+	clusterConfiguration *registry.ClusterConfiguration
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		services:             map[string]*registry.NetworkService{},
+		managers:             map[string]*registry.NetworkServiceManager{},
+		endpoints:            map[string]*registry.NetworkServiceEndpoint{},
+		clusterConfiguration: &registry.ClusterConfiguration{},
+	}
+}
+
+// Endpoints returns all the registered endpoints.
+func (r *Registry) Endpoints() []*registry.NetworkServiceEndpoint {
+	r.RLock()
+	defer r.RUnlock()
+
+	var rv []*registry.NetworkServiceEndpoint
+	for _, ep := range r.endpoints {
+		rv = append(rv, proto.Clone(ep).(*registry.NetworkServiceEndpoint))
+	}
+	return rv
+}
+
+// Manager returns the registered Network Service Manager.
+func (r *Registry) Manager(name string) *registry.NetworkServiceManager {
+	r.RLock()
+	defer r.RUnlock()
+
+	if nsm, ok := r.managers[name]; ok {
+		return proto.Clone(nsm).(*registry.NetworkServiceManager)
+	}
+	return nil
+}
+
+func (r *Registry) clientFor(nsmName string) *registryClient {
+	return &registryClient{
+		registry: r,
+		nsmName:  nsmName,
+	}
+}
+
+// registryClient implements all the registry clients of a single NSM, the NSM name is assigned by the client
+// the same way k8s registry names NSMs by the node.
+type registryClient struct {
+	registry *Registry
+	nsmName  string
+}
+
+func (c *registryClient) RegisterNSE(ctx context.Context, in *registry.NSERegistration, opts ...grpc.CallOption) (*registry.NSERegistration, error) {
+	logrus.Infof("Registry(%s): register NSE: %v", c.nsmName, in)
+	r := c.registry
+	r.Lock()
+	defer r.Unlock()
+
+	reg := proto.Clone(in).(*registry.NSERegistration)
+	if reg.GetNetworkService() != nil {
+		r.services[reg.GetNetworkService().GetName()] = proto.Clone(reg.GetNetworkService()).(*registry.NetworkService)
+	}
+	if ep := reg.GetNetworkserviceEndpoint(); ep != nil {
+		if ep.EndpointName == "" {
+			r.endpointCounter++
+			ep.EndpointName = fmt.Sprintf("%s-%d", ep.NetworkServiceName, r.endpointCounter)
+		}
+		ep.NetworkServiceManagerName = c.nsmName
+		r.endpoints[ep.EndpointName] = proto.Clone(ep).(*registry.NetworkServiceEndpoint)
+	}
+	if nsm, ok := r.managers[c.nsmName]; ok {
+		reg.NetworkServiceManager = proto.Clone(nsm).(*registry.NetworkServiceManager)
+	}
+	return reg, nil
+}
+
+func (c *registryClient) RemoveNSE(ctx context.Context, in *registry.RemoveNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	logrus.Infof("Registry(%s): remove NSE: %v", c.nsmName, in.GetEndpointName())
+	r := c.registry
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.endpoints, in.GetEndpointName())
+	return &empty.Empty{}, nil
+}
+
+func (c *registryClient) UpdateNSELoad(ctx context.Context, in *registry.UpdateNSELoadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	r := c.registry
+	r.Lock()
+	defer r.Unlock()
+
+	if ep, ok := r.endpoints[in.GetEndpointName()]; ok {
+		ep.Load = in.GetLoad()
+	}
+	return &empty.Empty{}, nil
+}
+
+func (c *registryClient) FindNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
+	r := c.registry
+	r.RLock()
+	defer r.RUnlock()
+
+	service, ok := r.services[in.GetNetworkServiceName()]
+	if !ok {
+		return nil, fmt.Errorf("no network service %s found", in.GetNetworkServiceName())
+	}
+	response := &registry.FindNetworkServiceResponse{
+		NetworkService:         proto.Clone(service).(*registry.NetworkService),
+		NetworkServiceManagers: map[string]*registry.NetworkServiceManager{},
+	}
+	for _, ep := range r.endpoints {
+		if ep.GetNetworkServiceName() != in.GetNetworkServiceName() {
+			continue
+		}
+		response.NetworkServiceEndpoints = append(response.NetworkServiceEndpoints, proto.Clone(ep).(*registry.NetworkServiceEndpoint))
+		if nsm, ok := r.managers[ep.GetNetworkServiceManagerName()]; ok {
+			response.NetworkServiceManagers[nsm.GetName()] = proto.Clone(nsm).(*registry.NetworkServiceManager)
+		}
+	}
+	return response, nil
+}
+
+func (c *registryClient) RegisterNSM(ctx context.Context, in *registry.NetworkServiceManager, opts ...grpc.CallOption) (*registry.NetworkServiceManager, error) {
+	logrus.Infof("Registry(%s): register NSM: %v", c.nsmName, in)
+	r := c.registry
+	r.Lock()
+	defer r.Unlock()
+
+	nsm := proto.Clone(in).(*registry.NetworkServiceManager)
+	nsm.Name = c.nsmName
+	r.managers[c.nsmName] = nsm
+	return proto.Clone(nsm).(*registry.NetworkServiceManager), nil
+}
+
+func (c *registryClient) GetEndpoints(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*registry.NetworkServiceEndpointList, error) {
+	r := c.registry
+	r.RLock()
+	defer r.RUnlock()
+
+	list := &registry.NetworkServiceEndpointList{}
+	for _, ep := range r.endpoints {
+		if ep.GetNetworkServiceManagerName() == c.nsmName {
+			list.NetworkServiceEndpoints = append(list.NetworkServiceEndpoints, proto.Clone(ep).(*registry.NetworkServiceEndpoint))
+		}
+	}
+	return list, nil
+}
+
+func (c *registryClient) GetClusterConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*registry.ClusterConfiguration, error) {
+	r := c.registry
+	r.RLock()
+	defer r.RUnlock()
+
+	return proto.Clone(r.clusterConfiguration).(*registry.ClusterConfiguration), nil
+}
@@ -0,0 +1,149 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	nsmServerSocket = "nsm.io.sock"
+	waitInterval    = 100 * time.Millisecond
+)
+
+// serviceRegistry connects a node to the harness registry, to the other nodes over tcp and to
+// the local dataplane and endpoints over unix sockets inside of the node directory.
+type serviceRegistry struct {
+	node           *Node
+	registryClient *registryClient
+	vniAllocator   vni.VniAllocator
+}
+
+func newServiceRegistry(node *Node, registryClient *registryClient) *serviceRegistry {
+	return &serviceRegistry{
+		node:           node,
+		registryClient: registryClient,
+		vniAllocator:   vni.NewVniAllocator(),
+	}
+}
+
+func (impl *serviceRegistry) GetPublicAPI() string {
+	return impl.node.public.Addr().String()
+}
+
+func (impl *serviceRegistry) DiscoveryClient() (registry.NetworkServiceDiscoveryClient, error) {
+	return impl.registryClient, nil
+}
+
+func (impl *serviceRegistry) NseRegistryClient() (registry.NetworkServiceRegistryClient, error) {
+	return impl.registryClient, nil
+}
+
+func (impl *serviceRegistry) NsmRegistryClient() (registry.NsmRegistryClient, error) {
+	return impl.registryClient, nil
+}
+
+func (impl *serviceRegistry) ClusterInfoClient() (registry.ClusterInfoClient, error) {
+	return impl.registryClient, nil
+}
+
+func (impl *serviceRegistry) Stop() {
+}
+
+func (impl *serviceRegistry) NSMDApiClient() (nsmdapi.NSMDClient, *grpc.ClientConn, error) {
+	conn, err := tools.SocketOperationCheck(tools.SocketPath(impl.node.nsmServerSocket()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return nsmdapi.NewNSMDClient(conn), conn, nil
+}
+
+func (impl *serviceRegistry) DataplaneConnection(dataplane *model.Dataplane) (dataplaneapi.DataplaneClient, *grpc.ClientConn, error) {
+	conn, err := tools.SocketOperationCheck(tools.SocketPath(dataplane.SocketLocation))
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataplaneapi.NewDataplaneClient(conn), conn, nil
+}
+
+func (impl *serviceRegistry) EndpointConnection(ctx context.Context, endpoint *model.Endpoint) (networkservice.NetworkServiceClient, *grpc.ClientConn, error) {
+	conn, err := tools.SocketOperationCheck(tools.SocketPath(endpoint.SocketLocation))
+	if err != nil {
+		logrus.Errorf("%s: unable to connect to nse %v", impl.node.Name, endpoint)
+		return nil, nil, err
+	}
+	return networkservice.NewNetworkServiceClient(conn), conn, nil
+}
+
+func (impl *serviceRegistry) RemoteNetworkServiceClient(ctx context.Context, nsm *registry.NetworkServiceManager) (remote_networkservice.NetworkServiceClient, *grpc.ClientConn, error) {
+	if impl.node.public.Partitioned() {
+		return nil, nil, fmt.Errorf("%s is partitioned from %s", impl.node.Name, nsm.GetName())
+	}
+	if err := tools.WaitForPortAvailable(ctx, "tcp", nsm.GetUrl(), waitInterval); err != nil {
+		return nil, nil, err
+	}
+	conn, err := grpc.DialContext(ctx, nsm.GetUrl(), tools.DialOptions(grpc.WithInsecure())...)
+	if err != nil {
+		logrus.Errorf("%s: failed to dial Remote Network Service Manager %s at %s: %s", impl.node.Name, nsm.GetName(), nsm.GetUrl(), err)
+		return nil, nil, err
+	}
+	return remote_networkservice.NewNetworkServiceClient(conn), conn, nil
+}
+
+func (impl *serviceRegistry) WaitForDataplaneAvailable(model model.Model, timeout time.Duration) error {
+	return WaitFor(timeout, func() bool {
+		dp, _ := model.SelectDataplane()
+		return dp != nil
+	})
+}
+
+func (impl *serviceRegistry) VniAllocator() vni.VniAllocator {
+	return impl.vniAllocator
+}
+
+func (impl *serviceRegistry) NewWorkspaceProvider() serviceregistry.WorkspaceLocationProvider {
+	return nsmd.NewWorkspaceProvider(impl.node.RootDir)
+}
+
+// apiRegistry provides the node listeners, NSM server is served on the unix socket inside of the node directory.
+type apiRegistry struct {
+	node *Node
+}
+
+func (impl *apiRegistry) NewNSMServerListener() (net.Listener, error) {
+	socket := impl.node.nsmServerSocket()
+	if err := tools.SocketCleanup(socket); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", socket)
+}
+
+func (impl *apiRegistry) NewPublicListener() (net.Listener, error) {
+	return impl.node.public, nil
+}
+
+// WaitFor polls the condition until it is true or the timeout is reached.
+func WaitFor(timeout time.Duration, condition func() bool) error {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout %v waiting for condition", timeout)
+		}
+		<-time.After(waitInterval)
+	}
+	return nil
+}
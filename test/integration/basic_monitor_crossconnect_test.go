@@ -8,7 +8,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/test/integration/nsmd_test_utils"
 	"github.com/networkservicemesh/networkservicemesh/test/kube_testing"
@@ -213,7 +212,7 @@ func createCrossConnectClient(address string) (crossconnect.MonitorCrossConnect_
 
 	monitorClient := crossconnect.NewMonitorCrossConnectClient(conn)
 	ctx, cancel := context.WithCancel(context.Background())
-	stream, err := monitorClient.MonitorCrossConnects(ctx, &empty.Empty{})
+	stream, err := monitorClient.MonitorCrossConnects(ctx, &crossconnect.MonitorRequest{})
 	if err != nil {
 		Expect(err).To(BeNil())
 		cancel()
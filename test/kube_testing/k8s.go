@@ -310,21 +310,21 @@ func (o *K8s) ListPods() []v1.Pod {
 func (o *K8s) CleanupCRDs() {
 
 	// Clean up Network Services
-	services, _ := o.versionedClientSet.Networkservicemesh().NetworkServices("default").List(metaV1.ListOptions{})
+	services, _ := o.versionedClientSet.Networkservicemesh().NetworkServices(metaV1.NamespaceAll).List(metaV1.ListOptions{})
 	for _, service := range services.Items {
-		_ = o.versionedClientSet.Networkservicemesh().NetworkServices("default").Delete(service.Name, &metaV1.DeleteOptions{})
+		_ = o.versionedClientSet.Networkservicemesh().NetworkServices(service.Namespace).Delete(service.Name, &metaV1.DeleteOptions{})
 	}
 
 	// Clean up Network Service Endpoints
-	endpoints, _ := o.versionedClientSet.Networkservicemesh().NetworkServiceEndpoints("default").List(metaV1.ListOptions{})
+	endpoints, _ := o.versionedClientSet.Networkservicemesh().NetworkServiceEndpoints(metaV1.NamespaceAll).List(metaV1.ListOptions{})
 	for _, ep := range endpoints.Items {
-		_ = o.versionedClientSet.Networkservicemesh().NetworkServiceEndpoints("default").Delete(ep.Name, &metaV1.DeleteOptions{})
+		_ = o.versionedClientSet.Networkservicemesh().NetworkServiceEndpoints(ep.Namespace).Delete(ep.Name, &metaV1.DeleteOptions{})
 	}
 
 	// Clean up Network Service Managers
-	managers, _ := o.versionedClientSet.Networkservicemesh().NetworkServiceManagers("default").List(metaV1.ListOptions{})
+	managers, _ := o.versionedClientSet.Networkservicemesh().NetworkServiceManagers(metaV1.NamespaceNone).List(metaV1.ListOptions{})
 	for _, mgr := range managers.Items {
-		_ = o.versionedClientSet.Networkservicemesh().NetworkServiceManagers("default").Delete(mgr.Name, &metaV1.DeleteOptions{})
+		_ = o.versionedClientSet.Networkservicemesh().NetworkServiceManagers(metaV1.NamespaceNone).Delete(mgr.Name, &metaV1.DeleteOptions{})
 	}
 }
 